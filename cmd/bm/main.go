@@ -1,11 +1,15 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
-	"os/exec"
-	"runtime"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -14,16 +18,24 @@ import (
 	"github.com/nikbrunner/bm/internal/ai"
 	"github.com/nikbrunner/bm/internal/culler"
 	"github.com/nikbrunner/bm/internal/exporter"
+	"github.com/nikbrunner/bm/internal/gitsync"
 	"github.com/nikbrunner/bm/internal/importer"
 	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/opener"
 	"github.com/nikbrunner/bm/internal/picker"
 	"github.com/nikbrunner/bm/internal/search"
 	"github.com/nikbrunner/bm/internal/storage"
 	"github.com/nikbrunner/bm/internal/tui"
 )
 
+// noAI is set by the global --no-ai flag and turns off AI like the
+// disableAI config option.
+var noAI bool
+
 func main() {
+	os.Args, noAI = stripFlag(os.Args, "--no-ai")
 	if len(os.Args) >= 2 {
+		// Subcommands win over aliases; keep storage.ReservedAliasNames in sync
 		switch os.Args[1] {
 		case "help", "--help", "-h":
 			printHelp()
@@ -38,27 +50,64 @@ func main() {
 			runReset()
 			return
 		case "import":
-			if len(os.Args) < 3 {
-				fmt.Fprintf(os.Stderr, "Usage: bm import <file.html>\n")
-				os.Exit(1)
-			}
-			runImport(os.Args[2])
+			runImport(os.Args[2:])
 			return
 		case "export":
-			// Export with optional path
-			var outputPath string
-			if len(os.Args) >= 3 {
-				outputPath = os.Args[2]
-			}
-			runExport(outputPath)
+			runExport(os.Args[2:])
 			return
 		case "cull":
 			runCull()
 			return
+		case "health":
+			runHealth()
+			return
+		case "sync":
+			runSync()
+			return
+		case "doctor":
+			runDoctor(os.Args[2:])
+			return
+		case "prune-empty":
+			runPruneEmpty()
+			return
+		case "backup":
+			runBackup()
+			return
+		case "restore":
+			runRestore(os.Args[2:])
+			return
+		case "tag":
+			runTag(os.Args[2:])
+			return
+		case "tags":
+			runTags(os.Args[2:])
+			return
+		case "tag-domain":
+			runTagDomain(os.Args[2:])
+			return
+		case "snoozed":
+			runSnoozed()
+			return
+		case "untitled":
+			runUntitled()
+			return
+		case "list":
+			runList(os.Args[2:])
+			return
+		case "domains":
+			runDomains(os.Args[2:])
+			return
+		case "unsnooze":
+			if len(os.Args) < 3 {
+				fmt.Fprintf(os.Stderr, "Usage: bm unsnooze <query|--all>\n")
+				os.Exit(1)
+			}
+			runUnsnooze(strings.Join(os.Args[2:], " "))
+			return
 		default:
-			// Treat as search query (join all remaining args)
+			// Treat as alias or search query (join all remaining args)
 			query := strings.Join(os.Args[1:], " ")
-			runQuickSearch(query)
+			runQuickSearch(query, len(os.Args) == 2)
 			return
 		}
 	}
@@ -67,24 +116,79 @@ func main() {
 	runTUI()
 }
 
+// stripFlag removes every occurrence of flag from args and reports whether it was present.
+func stripFlag(args []string, flag string) ([]string, bool) {
+	kept := make([]string, 0, len(args))
+	found := false
+	for _, arg := range args {
+		if arg == flag {
+			found = true
+			continue
+		}
+		kept = append(kept, arg)
+	}
+	return kept, found
+}
+
 func printHelp() {
 	help := `bm - vim-style bookmark manager
 
 Usage:
   bm                    Open interactive TUI
-  bm <query>            Quick search → select → open
+  bm <query>            Quick search → select → open (exact alias opens directly)
   bm add                Quick add URL from clipboard to Read Later
   bm init               Create config with sample data
   bm reset              Clear all data (requires confirmation)
-  bm import <file>      Import bookmarks from HTML
-  bm export [path]      Export bookmarks to HTML
+  bm import <file> [--under <path>] [--report <out.json>] [--interactive]
+                        Import bookmarks from HTML or a bm JSON export,
+                        optionally nested under a folder and writing which
+                        were added or skipped as duplicates; --interactive
+                        asks what to do with each duplicate instead
+  bm export [--format html|csv|json] [--annotate-health] [path]
+                        Export bookmarks to HTML, CSV (with visit stats) or JSON,
+                        optionally marking each link's status from the last cull
   bm cull               Check all URLs, report dead links
+  bm health             Show the link rot trend of recent cull runs
+  bm sync               Commit data to its git repo, pull and push
+  bm doctor             Find and merge same-named sibling folders
+  bm doctor --flatten-deep
+                        Collapse folders nested deeper than maxFolderDepth
+  bm doctor --fix-encoding
+                        Repair titles with broken encoding (â€™ for ’)
+  bm doctor --dedup-tags
+                        Drop duplicate, padded and blank tags from bookmarks
+  bm prune-empty        Delete folders with no bookmarks (keeps pinned/locked)
+  bm backup             Save a timestamped copy of the database to backups/
+  bm restore [file]     Pick a backup to restore (the current data is backed up first)
+  bm tags               List every tag with its bookmark count
+  bm tag <tag> [--open|--pick]
+                        List bookmarks tagged <tag>; open all or pick one
+                        (bm tags <tag> works the same)
+  bm tag merge <from> <into>
+                        Replace tag <from> with <into> everywhere
+  bm tag-domain <domain> <tag>
+                        Tag every bookmark on domain (--remove to untag)
+  bm snoozed            List snoozed bookmarks
+  bm unsnooze <query>   Wake snoozed bookmarks matching query (--all for every one)
+  bm untitled           List bookmarks whose title is empty or just the URL
+  bm list [path] [--urls-only]
+                        Print the folder tree (or the subtree at path) with
+                        titles and URLs; --urls-only prints just the URLs
+  bm domains [host]     List hosts by bookmark count, or the bookmarks on host
   bm help               Show this help
 
+Global Options:
+  --no-ai               Don't call the AI for this run (see disableAI in the config)
+
 Quick Add Options:
-  bm add                Read URL from clipboard
+  bm add                Read URL from clipboard, or from stdin when piped
   bm add --url URL      Use specified URL
   bm add --title TITLE  Override AI-generated title
+  bm add --priority low|medium|high
+                        Queue with a priority for triage (+ cycles it in the TUI)
+  bm add --template NAME [URL]
+                        File under a template's folder, tags and title prefix
+  bm add --folder PATH  File under PATH (e.g. "Development/Go"), created if missing
 
 TUI Keybindings:
   Navigation:
@@ -98,8 +202,10 @@ TUI Keybindings:
     /           Filter current folder
     o           Cycle sort mode
     Y           Copy URL to clipboard
+    M           Copy as link (markdown, or copyLinkFormat)
     *           Pin/unpin item
     c           Toggle delete confirmations
+    ta          Show/hide archived bookmarks inline
 
   Editing:
     a/A         Add bookmark/folder
@@ -108,6 +214,13 @@ TUI Keybindings:
     e           Edit selected item
     t           Edit tags
     m           Move to folder
+    z           Snooze bookmark (e.g. 3d, 1w)
+    @           Set alias (bm <alias> opens it)
+    X           Archive/unarchive bookmark
+    !           Lock/unlock folder (read-only)
+    F           Promote bookmark to its own folder
+    v/V         Select item / visual line selection
+    :           Bulk actions menu for the selection
     y           Yank (copy)
     d           Delete
     x           Cut (delete + buffer)
@@ -121,13 +234,14 @@ TUI Keybindings:
 Data Storage:
   ~/.config/bm/bookmarks.db     SQLite database
   ~/.config/bm/config.json      Settings (quick add folder, etc.)
+  BM_DB_PATH, BM_CONFIG_PATH    Override either location (e.g. a scratch database)
 `
 	fmt.Print(help)
 }
 
 // runTUI runs the full interactive TUI.
 func runTUI() {
-	configPath, err := storage.DefaultConfigFilePath()
+	configPath, err := storage.ConfigFilePath()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting config path: %v\n", err)
 		os.Exit(1)
@@ -141,25 +255,62 @@ func runTUI() {
 		os.Exit(1)
 	}
 
-	app := tui.NewApp(tui.AppParams{Store: store, Storage: dataStorage, Config: config})
+	// A read-only location only fails on the first save, so check up front
+	dbPath := "the database"
+	var unwritable error
+	if p, ok := dataStorage.(interface{ Path() string }); ok {
+		dbPath = p.Path()
+		unwritable = storage.CheckWritable(dbPath)
+	}
+	if unwritable == nil {
+		unwritable = storage.CheckWritable(configPath)
+	}
+
+	firstRun := len(store.Folders) == 0 && len(store.Bookmarks) == 0
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: dataStorage, Config: config, ConfigPath: configPath, NoAI: noAI, FirstRun: firstRun, RequireStorage: true, Unwritable: unwritable})
 	p := tea.NewProgram(app, tea.WithAltScreen())
-	_, err = p.Run()
+	final, err := p.Run()
 	if err != nil {
 		closeStorage()
 		fmt.Fprintf(os.Stderr, "Error running app: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Note: Auto-save happens after each mutation in the TUI,
-	// so we don't need to save on exit anymore.
+	// Auto-save happens after each mutation in the TUI (debounced saves are
+	// flushed before quitting), so there is nothing to save here. A save that
+	// was still failing at exit means the session's edits are lost.
 	closeStorage()
+	if app, ok := final.(interface{ SaveErr() string }); ok && app.SaveErr() != "" {
+		fmt.Fprintf(os.Stderr, "Error: changes were NOT saved to %s: %s\n", dbPath, app.SaveErr())
+		os.Exit(1)
+	}
 }
 
 // runQuickSearch performs a fuzzy search and opens the selected bookmark.
-func runQuickSearch(query string) {
+// With checkAlias, an exact alias match from the config wins over fuzzy search.
+func runQuickSearch(query string, checkAlias bool) {
 	store, dataStorage, closeStorage := loadStorage()
 	defer closeStorage()
 
+	if checkAlias {
+		// Aliases are a shortcut; a missing or unreadable config just falls through to search
+		if configPath, err := storage.ConfigFilePath(); err == nil {
+			if config, err := loadConfig(configPath); err == nil {
+				if bookmark, url, ok := search.ResolveAlias(store, config.Aliases, query); ok {
+					if bookmark != nil {
+						bookmark.MarkVisited(time.Now())
+						if err := dataStorage.Save(store); err != nil {
+							fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+						}
+					}
+					fmt.Printf("Opening: %s\n", url)
+					openURL(url)
+					return
+				}
+			}
+		}
+	}
+
 	// Search
 	results := search.FuzzySearchBookmarks(store, query)
 
@@ -198,8 +349,7 @@ func runQuickSearch(query string) {
 	// Update visitedAt
 	bookmark := store.GetBookmarkByID(selectedBookmark.ID)
 	if bookmark != nil {
-		now := time.Now()
-		bookmark.VisitedAt = &now
+		bookmark.MarkVisited(time.Now())
 		if err := dataStorage.Save(store); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
 		}
@@ -209,24 +359,73 @@ func runQuickSearch(query string) {
 	openURL(selectedBookmark.URL)
 }
 
-// openURL opens a URL in the default browser.
-func openURL(url string) {
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+// confirmOpenAll asks before opening more than openAllThreshold URLs at once.
+// Returns true when the URLs should be opened.
+func confirmOpenAll(count int) bool {
+	configPath, err := storage.ConfigFilePath()
+	if err != nil {
+		return true
 	}
-	if cmd != nil {
-		_ = cmd.Start()
+	config, err := loadConfig(configPath)
+	if err != nil || !config.ConfirmOpenAll(count) {
+		return true
 	}
+
+	fmt.Printf("Open %d URLs? [y/N] ", count)
+	var confirm string
+	_, _ = fmt.Scanln(&confirm)
+	return strings.EqualFold(confirm, "y") || strings.EqualFold(confirm, "yes")
+}
+
+// openURLs opens several URLs in the default browser, opener.Stagger apart.
+// All multi-open paths go through here after confirmOpenAll.
+func openURLs(urls []string) {
+	_ = opener.OpenAll(urls)
+}
+
+// openURL opens a URL in the default browser.
+func openURL(url string) {
+	_ = opener.Open(url)
 }
 
 // runImport handles the import subcommand.
-func runImport(filePath string) {
+// Usage: bm import <file> [--under <path>] [--report <out.json>] [--interactive]
+func runImport(args []string) {
+	const usage = "Usage: bm import <file.html|file.json> [--under <folder path>] [--report <out.json>] [--interactive]\n"
+	var filePath, reportPath, under string
+	var interactive bool
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--interactive":
+			interactive = true
+		case args[i] == "--report":
+			// A missing value must not quietly become "no report"
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "--") {
+				fmt.Fprint(os.Stderr, "--report needs an output path\n"+usage)
+				os.Exit(1)
+			}
+			reportPath = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--report="):
+			reportPath = strings.TrimPrefix(args[i], "--report=")
+			if reportPath == "" {
+				fmt.Fprint(os.Stderr, "--report needs an output path\n"+usage)
+				os.Exit(1)
+			}
+		case args[i] == "--under" && i+1 < len(args):
+			under = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--under="):
+			under = strings.TrimPrefix(args[i], "--under=")
+		default:
+			filePath = args[i]
+		}
+	}
+	if filePath == "" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(1)
+	}
+
 	store, dataStorage, closeStorage := loadStorage()
 	defer closeStorage()
 
@@ -237,32 +436,195 @@ func runImport(filePath string) {
 	}
 	defer func() { _ = file.Close() }()
 
+	// failImport records a parse error in the report (if requested) before exiting
+	failImport := func(format string, err error) {
+		fmt.Fprintf(os.Stderr, format, err)
+		report := model.NewImportReport()
+		report.Errors = append(report.Errors, err.Error())
+		writeImportReport(reportPath, report)
+		os.Exit(1)
+	}
+
+	// nestImport places the import's top level in the --under folder, created if missing
+	nestImport := func(folders []model.Folder, bookmarks []model.Bookmark) {
+		if under == "" {
+			return
+		}
+		parent, _ := store.GetOrCreateFolderByPath(under)
+		if parent == nil {
+			fmt.Fprintf(os.Stderr, "Invalid folder path for --under: %q\n", under)
+			os.Exit(1)
+		}
+		model.NestUnder(folders, bookmarks, parent.ID)
+	}
+
+	// bm's own JSON export round-trips everything; browser HTML only the basics
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		imported, err := importer.ParseJSON(file)
+		if err != nil {
+			failImport("Error parsing JSON: %v\n", err)
+		}
+		nestImport(imported.Folders, imported.Bookmarks)
+		foldersBefore := len(store.Folders)
+		report := store.ImportStoreResolve(imported, importResolver(store, interactive))
+		if err := dataStorage.Save(store); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+			os.Exit(1)
+		}
+		printImportSummary(report, len(store.Folders)-foldersBefore)
+		writeImportReport(reportPath, report)
+		return
+	}
+
 	folders, bookmarks, err := importer.ParseHTMLBookmarks(file)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing HTML: %v\n", err)
-		os.Exit(1)
+		failImport("Error parsing HTML: %v\n", err)
+	}
+
+	// Imported tags follow the same normalization as tags entered in the TUI
+	if configPath, err := storage.ConfigFilePath(); err == nil {
+		if config, err := loadConfig(configPath); err == nil && config.LowercaseTags {
+			for i := range bookmarks {
+				bookmarks[i].Tags = model.NormalizeTags(bookmarks[i].Tags, true)
+			}
+		}
 	}
 
-	added, skipped := store.ImportMerge(folders, bookmarks)
+	nestImport(folders, bookmarks)
+	report := store.ImportMergeResolve(folders, bookmarks, importResolver(store, interactive))
 
 	if err := dataStorage.Save(store); err != nil {
 		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("Imported %d bookmarks, %d folders", added, len(folders))
-	if skipped > 0 {
-		fmt.Printf(" (%d duplicates skipped)", skipped)
+	printImportSummary(report, len(folders))
+	writeImportReport(reportPath, report)
+}
+
+// printImportSummary prints the one-line outcome of an import.
+func printImportSummary(report model.ImportReport, folders int) {
+	fmt.Printf("Imported %d bookmarks, %d folders", len(report.Added), folders)
+	if len(report.Updated) > 0 {
+		fmt.Printf(", %d existing updated", len(report.Updated))
+	}
+	if len(report.Duplicates) > 0 {
+		fmt.Printf(" (%d duplicates skipped)", len(report.Duplicates))
 	}
 	fmt.Println()
 }
 
+// importDuplicatePrompt is the choice line shown for each duplicate; an
+// uppercase answer applies to every remaining duplicate as well.
+const importDuplicatePrompt = "[k]eep existing, [r]eplace, [m]erge tags, keep [b]oth (uppercase: all remaining) [k] "
+
+// importDecisions maps an answer to promptImportDuplicate onto its decision.
+var importDecisions = map[string]model.ImportDecision{
+	"k": model.ImportKeep,
+	"r": model.ImportReplace,
+	"m": model.ImportMergeTags,
+	"b": model.ImportKeepBoth,
+}
+
+// importResolver asks about each duplicate on stdin when interactive, and
+// remembers an "all remaining" answer. Otherwise it is nil, skipping them all.
+func importResolver(store *model.Store, interactive bool) model.ImportResolver {
+	if !interactive {
+		return nil
+	}
+	var all *model.ImportDecision
+	return func(existing, imported model.Bookmark) model.ImportDecision {
+		if all != nil {
+			return *all
+		}
+		decision, remaining := promptImportDuplicate(existing, imported,
+			store.GetFolderPath(existing.FolderID), store.GetFolderPath(imported.FolderID))
+		if remaining {
+			all = &decision
+		}
+		return decision
+	}
+}
+
+// promptImportDuplicate shows an existing bookmark next to the incoming one
+// with the same URL and asks what to do with it. It also reports whether
+// the answer applies to all remaining duplicates.
+func promptImportDuplicate(existing, imported model.Bookmark, existingPath, importedPath string) (model.ImportDecision, bool) {
+	const width = 36
+	cell := func(s string) string {
+		if r := []rune(s); len(r) > width {
+			s = string(r[:width-1]) + "…"
+		}
+		return s + strings.Repeat(" ", width-len([]rune(s)))
+	}
+	fmt.Printf("\n%s\n", existing.URL)
+	fmt.Printf("  %-8s%s  %s\n", "", cell("existing"), "incoming")
+	fmt.Printf("  %-8s%s  %s\n", "title", cell(existing.Title), imported.Title)
+	fmt.Printf("  %-8s%s  %s\n", "tags", cell(strings.Join(existing.Tags, ", ")), strings.Join(imported.Tags, ", "))
+	fmt.Printf("  %-8s%s  %s\n", "folder", cell(existingPath), importedPath)
+	for {
+		fmt.Print(importDuplicatePrompt)
+		var answer string
+		_, err := fmt.Scanln(&answer)
+		if errors.Is(err, io.EOF) {
+			// Input ran out: keep what exists for this and every later duplicate
+			return model.ImportKeep, true
+		}
+		if answer == "" {
+			return model.ImportKeep, false
+		}
+		if decision, ok := importDecisions[strings.ToLower(answer)]; ok {
+			return decision, answer != strings.ToLower(answer)
+		}
+	}
+}
+
+// writeImportReport writes report to path as JSON; an empty path writes nothing.
+func writeImportReport(path string, report model.ImportReport) {
+	if path == "" {
+		return
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing import report: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() { _ = file.Close() }()
+	if err := importer.WriteReport(file, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing import report: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Report written to %s\n", path)
+}
+
 // runExport handles the export subcommand.
-func runExport(outputPath string) {
+// Usage: bm export [--format html|csv|json] [--annotate-health] [path]
+func runExport(args []string) {
+	format := "html"
+	var outputPath string
+	annotateHealth := false
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--annotate-health":
+			annotateHealth = true
+		case args[i] == "--format" && i+1 < len(args):
+			format = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--format="):
+			format = strings.TrimPrefix(args[i], "--format=")
+		default:
+			outputPath = args[i]
+		}
+	}
+	if format != "html" && format != "csv" && format != "json" {
+		fmt.Fprintf(os.Stderr, "Unknown export format %q (use html, csv or json)\n", format)
+		os.Exit(1)
+	}
+
 	// Determine output path
 	if outputPath == "" {
 		var err error
-		outputPath, err = exporter.DefaultExportPath()
+		outputPath, err = exporter.DefaultExportPath(format)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting default export path: %v\n", err)
 			os.Exit(1)
@@ -270,14 +632,42 @@ func runExport(outputPath string) {
 	}
 
 	// Load store
-	store, _, closeStorage := loadStorage()
+	store, dataStorage, closeStorage := loadStorage()
 	defer closeStorage()
 
-	// Generate HTML
-	html := exporter.ExportHTML(store)
+	// Last-known link status from the cull cache, if requested
+	var health map[string]exporter.Health
+	if annotateHealth {
+		cache, err := storage.ReadCullCache(dataStorage)
+		if errors.Is(err, storage.ErrNoCache) || errors.Is(err, storage.ErrCorruptCache) {
+			fmt.Fprintf(os.Stderr, "No cull results to annotate with; run a cull (C in the TUI) first\n")
+			os.Exit(1)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading cull cache: %v\n", err)
+			os.Exit(1)
+		}
+		health = exporter.HealthFromCullCache(cache)
+	}
+
+	// Generate output
+	var content string
+	switch format {
+	case "csv":
+		content = exporter.ExportCSVWithHealth(store, health)
+	case "json":
+		var err error
+		content, err = exporter.ExportJSONWithHealth(store, health)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		content = exporter.ExportHTMLWithHealth(store, health)
+	}
 
 	// Write to file
-	if err := os.WriteFile(outputPath, []byte(html), 0644); err != nil {
+	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
 		fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
 		os.Exit(1)
 	}
@@ -288,47 +678,66 @@ func runExport(outputPath string) {
 
 // runCull checks all bookmark URLs and reports/deletes dead ones.
 func runCull() {
-	store, _, closeStorage := loadStorage()
+	store, dataStorage, closeStorage := loadStorage()
 	defer closeStorage()
 
 	// Load config for excluded domains
-	configPath, err := storage.DefaultConfigFilePath()
+	configPath, err := storage.ConfigFilePath()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting config path: %v\n", err)
 		os.Exit(1)
 	}
-	config, err := storage.LoadConfig(configPath)
+	config, err := loadConfig(configPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
 
-	if len(store.Bookmarks) == 0 {
+	// Archived bookmarks are left alone
+	bookmarks := store.GetActiveBookmarks()
+	if len(bookmarks) == 0 {
 		fmt.Println("No bookmarks to check.")
 		return
 	}
 
-	fmt.Printf("Checking %d bookmarks...\n", len(store.Bookmarks))
+	ignorePath, err := storage.CullIgnoreFilePath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting cull-ignore path: %v\n", err)
+		os.Exit(1)
+	}
+	ignore, err := culler.LoadIgnoreFile(ignorePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", ignorePath, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Checking %d bookmarks...\n", len(bookmarks))
 	if len(config.CullExcludeDomains) > 0 {
 		fmt.Printf("Excluding domains: %v\n", config.CullExcludeDomains)
 	}
+	if len(ignore) > 0 {
+		fmt.Printf("Skipping %d patterns from %s\n", len(ignore), ignorePath)
+	}
 
 	// Progress callback
 	onProgress := func(completed, total int) {
 		fmt.Printf("\rChecking %d bookmarks... [%d/%d]", total, completed, total)
 	}
 
-	results := culler.CheckURLs(store.Bookmarks, 10, 10*time.Second, config.CullExcludeDomains, onProgress)
+	results := culler.CheckURLs(context.Background(), bookmarks, 10, 10*time.Second, config.CullExcludeDomains, ignore, onProgress)
 	fmt.Println() // New line after progress
 
 	// Categorize results
 	var dead, unreachable []culler.Result
+	skipped := 0
 	for _, r := range results {
 		switch r.Status {
 		case culler.Dead:
 			dead = append(dead, r)
 		case culler.Unreachable:
 			unreachable = append(unreachable, r)
+		case culler.Skipped:
+			skipped++
 		}
 	}
 
@@ -356,119 +765,623 @@ func runCull() {
 		}
 	}
 
-	healthy := len(store.Bookmarks) - len(dead) - len(unreachable)
-	fmt.Printf("\nSummary: %d healthy, %d dead, %d unreachable\n", healthy, len(dead), len(unreachable))
+	healthy := len(bookmarks) - len(dead) - len(unreachable) - skipped
+	fmt.Printf("\nSummary: %d healthy, %d dead, %d unreachable", healthy, len(dead), len(unreachable))
+	if skipped > 0 {
+		fmt.Printf(", %d skipped", skipped)
+	}
+	fmt.Println()
 	fmt.Println("\nUse 'C' in TUI for interactive cull mode.")
-}
 
-// runAdd handles the quick add command.
-func runAdd(args []string) {
-	// Parse flags
-	var urlFlag, titleFlag string
-	for i := 0; i < len(args); i++ {
-		switch args[i] {
-		case "--url":
-			if i+1 < len(args) {
-				urlFlag = args[i+1]
-				i++
-			}
-		case "--title":
-			if i+1 < len(args) {
-				titleFlag = args[i+1]
-				i++
-			}
+	if hs, ok := dataStorage.(storage.CullHistoryStorage); ok {
+		run := storage.CullRun{
+			Timestamp:   time.Now(),
+			Total:       len(results) - skipped,
+			Dead:        len(dead),
+			Unreachable: len(unreachable),
 		}
-	}
-
-	// Get URL from flag or clipboard
-	bookmarkURL := urlFlag
-	if bookmarkURL == "" {
-		var err error
-		bookmarkURL, err = clipboard.ReadAll()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading clipboard: %v\n", err)
-			os.Exit(1)
+		if err := hs.AppendCullRun(run); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not record cull history: %v\n", err)
 		}
-		bookmarkURL = strings.TrimSpace(bookmarkURL)
 	}
+}
 
-	if bookmarkURL == "" {
-		fmt.Fprintf(os.Stderr, "No URL found in clipboard\n")
+// healthRuns is how many recent cull runs bm health reports.
+const healthRuns = 10
+
+// runHealth prints the link rot trend across the most recent cull runs.
+func runHealth() {
+	_, dataStorage, closeStorage := loadStorage()
+	defer closeStorage()
+
+	hs, ok := dataStorage.(storage.CullHistoryStorage)
+	if !ok {
+		fmt.Println("Cull history is not available for this storage backend.")
+		return
+	}
+	runs, err := hs.LoadCullHistory(healthRuns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading cull history: %v\n", err)
 		os.Exit(1)
 	}
+	if len(runs) == 0 {
+		fmt.Println("No cull runs recorded yet. Run 'bm cull' or press 'C' in the TUI.")
+		return
+	}
 
-	// Validate URL
-	parsedURL, err := url.Parse(bookmarkURL)
-	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
-		fmt.Fprintf(os.Stderr, "Invalid URL: %s\n", bookmarkURL)
-		os.Exit(1)
+	fmt.Printf("Link rot over the last %d cull runs:\n\n", len(runs))
+	fmt.Printf("  %-10s %7s %7s %7s %12s\n", "WHEN", "TOTAL", "HEALTHY", "DEAD", "UNREACHABLE")
+	for _, r := range runs {
+		fmt.Printf("  %-10s %7d %7d %7d %12d\n", model.FormatTimeAgo(r.Timestamp), r.Total, r.Healthy(), r.Dead, r.Unreachable)
 	}
 
-	// Load config
-	configFilePath, err := storage.DefaultConfigFilePath()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting config path: %v\n", err)
-		os.Exit(1)
+	broken := make([]int, len(runs))
+	for i, r := range runs {
+		broken[i] = r.Dead + r.Unreachable
 	}
+	fmt.Printf("\n  Broken links: %s\n", sparkline(broken))
+}
 
-	config, err := storage.LoadConfig(configFilePath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+// sparkline renders values as a row of block characters scaled to the maximum.
+func sparkline(values []int) string {
+	blocks := []rune("▁▂▃▄▅▆▇█")
+	maxValue := 0
+	for _, v := range values {
+		maxValue = max(maxValue, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if maxValue > 0 {
+			idx = v * (len(blocks) - 1) / maxValue
+		}
+		b.WriteRune(blocks[idx])
+	}
+	return b.String()
+}
+
+// runTag lists every bookmark carrying a tag, optionally opening them all
+// (--open) or one chosen in the picker (--pick). Exits 1 if none match.
+// bm tag merge <from> <into> is handed to runTagMerge.
+func runTag(args []string) {
+	if len(args) == 3 && args[0] == "merge" {
+		runTagMerge(args[1], args[2])
+		return
+	}
+
+	openAll, pick := false, false
+	var positional []string
+	for _, arg := range args {
+		switch arg {
+		case "--open":
+			openAll = true
+		case "--pick":
+			pick = true
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) != 1 || (openAll && pick) {
+		fmt.Fprintf(os.Stderr, "Usage: bm tag <tag> [--open|--pick]\n       bm tag merge <from> <into>\n")
 		os.Exit(1)
 	}
+	tag := positional[0]
 
-	// Load store
 	store, dataStorage, closeStorage := loadStorage()
 	defer closeStorage()
 
-	// Find or create the quick add folder
-	folderID := findOrCreateFolder(store, config.QuickAddFolder)
-
-	// Determine title and tags
-	var title string
-	var tags []string
+	tagged := store.BookmarksWithTag(tag)
+	if len(tagged) == 0 {
+		fmt.Fprintf(os.Stderr, "No bookmarks tagged '%s'\n", tag)
+		os.Exit(1)
+	}
 
-	if titleFlag != "" {
-		// Use provided title
-		title = titleFlag
-	} else {
-		// Try to use AI
-		aiClient, err := ai.NewClient()
+	var toOpen []model.Bookmark
+	switch {
+	case openAll:
+		toOpen = tagged
+	case pick:
+		results := make([]search.SearchResult, len(tagged))
+		for i := range tagged {
+			results[i] = search.SearchResult{Bookmark: &tagged[i]}
+		}
+		finalModel, err := tea.NewProgram(picker.New(results, "#"+tag)).Run()
 		if err != nil {
-			// AI unavailable - use URL as title
-			fmt.Printf("AI unavailable (%v) - using URL as title\n", err)
-			title = bookmarkURL
-		} else {
-			// Build context for AI
-			context := ai.BuildContext(store)
-			response, err := aiClient.SuggestBookmark(bookmarkURL, context)
-			if err != nil {
-				fmt.Printf("AI request failed (%v) - using URL as title\n", err)
-				title = bookmarkURL
-			} else {
-				title = response.Title
-				tags = response.Tags
-			}
+			fmt.Fprintf(os.Stderr, "Error running picker: %v\n", err)
+			os.Exit(1)
+		}
+		finalPicker := finalModel.(picker.Picker)
+		if finalPicker.Cancelled() || finalPicker.SelectedBookmark() == nil {
+			return
+		}
+		toOpen = []model.Bookmark{*finalPicker.SelectedBookmark()}
+	default:
+		fmt.Printf("Tagged #%s (%d):\n", tag, len(tagged))
+		for _, b := range tagged {
+			fmt.Printf("  • \"%s\" - %s (%s)\n", b.Title, b.URL, store.GetFolderPath(b.FolderID))
 		}
+		return
 	}
 
-	// Create bookmark
-	newBookmark := model.NewBookmark(model.NewBookmarkParams{
-		Title:    title,
-		URL:      bookmarkURL,
-		FolderID: &folderID,
-		Tags:     tags,
-	})
-
-	store.AddBookmark(newBookmark)
+	if !confirmOpenAll(len(toOpen)) {
+		fmt.Println("Aborted")
+		return
+	}
+
+	now := time.Now()
+	urls := make([]string, len(toOpen))
+	for i, b := range toOpen {
+		fmt.Printf("Opening: %s\n", b.Title)
+		urls[i] = b.URL
+		if bookmark := store.GetBookmarkByID(b.ID); bookmark != nil {
+			bookmark.MarkVisited(now)
+		}
+	}
+	openURLs(urls)
+	if err := dataStorage.Save(store); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+	}
+}
+
+// runTags lists every tag alphabetically with the number of bookmarks
+// carrying it, wherever they are nested. With a tag it is bm tag, so
+// bm tags <tag> [--open|--pick] lists, opens or picks that tag's bookmarks.
+// Merging stays with bm tag merge.
+func runTags(args []string) {
+	if len(args) > 0 {
+		var positional []string
+		for _, arg := range args {
+			if arg != "--open" && arg != "--pick" {
+				positional = append(positional, arg)
+			}
+		}
+		bothModes := slices.Contains(args, "--open") && slices.Contains(args, "--pick")
+		if len(positional) != 1 || positional[0] == "merge" || strings.HasPrefix(positional[0], "-") || bothModes {
+			fmt.Fprintf(os.Stderr, "Usage: bm tags [<tag> [--open|--pick]]\n")
+			os.Exit(1)
+		}
+		runTag(args)
+		return
+	}
+
+	store, _, closeStorage := loadStorage()
+	defer closeStorage()
+
+	// Count what bm tag lists, so archived bookmarks are left out
+	active := model.Store{Bookmarks: store.GetActiveBookmarks()}
+	counts := active.TagCounts()
+	if len(counts) == 0 {
+		fmt.Println("No tags.")
+		return
+	}
+	for _, tc := range counts {
+		fmt.Printf("%5d  %s\n", tc.Count, tc.Tag)
+	}
+}
+
+// runTagMerge replaces the tag from with into on every bookmark, so two tags
+// that mean the same thing (js, javascript) become one.
+func runTagMerge(from, into string) {
+	// The target follows the same normalization as tags entered in the TUI
+	lowercase := true
+	if configPath, err := storage.ConfigFilePath(); err == nil {
+		if config, err := loadConfig(configPath); err == nil {
+			lowercase = config.LowercaseTags
+		}
+	}
+	normalized := model.NormalizeTags([]string{into}, lowercase)
+	if strings.TrimSpace(from) == "" || len(normalized) == 0 {
+		fmt.Fprintf(os.Stderr, "Tags must not be empty\n")
+		os.Exit(1)
+	}
+	into = normalized[0]
+	if strings.EqualFold(strings.TrimSpace(from), into) {
+		fmt.Fprintf(os.Stderr, "Cannot merge a tag into itself\n")
+		os.Exit(1)
+	}
+
+	store, dataStorage, closeStorage := loadStorage()
+	defer closeStorage()
+
+	changed := store.MergeTags(from, into)
+	if changed == 0 {
+		fmt.Printf("No bookmarks tagged '%s'\n", from)
+		return
+	}
+	if err := dataStorage.Save(store); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Merged '%s' into '%s' on %d bookmarks\n", from, into, changed)
+}
+
+// runTagDomain adds (or with --remove, removes) a tag on every bookmark whose
+// host matches a domain or one of its subdomains.
+func runTagDomain(args []string) {
+	remove := false
+	var positional []string
+	for _, arg := range args {
+		if arg == "--remove" {
+			remove = true
+			continue
+		}
+		positional = append(positional, arg)
+	}
+	if len(positional) != 2 {
+		fmt.Fprintf(os.Stderr, "Usage: bm tag-domain [--remove] <domain> <tag>\n")
+		os.Exit(1)
+	}
+	domain, tag := positional[0], strings.TrimSpace(positional[1])
+	if tag == "" {
+		fmt.Fprintf(os.Stderr, "Tag must not be empty\n")
+		os.Exit(1)
+	}
+
+	store, dataStorage, closeStorage := loadStorage()
+	defer closeStorage()
+
+	var changed int
+	if remove {
+		changed = store.RemoveTagByDomain(domain, tag)
+	} else {
+		changed = store.AddTagByDomain(domain, tag)
+	}
+
+	if changed == 0 {
+		fmt.Println("No bookmarks changed.")
+		return
+	}
+
+	if err := dataStorage.Save(store); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+		os.Exit(1)
+	}
+
+	if remove {
+		fmt.Printf("Removed '%s' from %d bookmarks on %s\n", tag, changed, domain)
+	} else {
+		fmt.Printf("Tagged %d bookmarks on %s with '%s'\n", changed, domain, tag)
+	}
+}
+
+// runSnoozed lists bookmarks that are currently hidden by a snooze.
+func runSnoozed() {
+	store, _, closeStorage := loadStorage()
+	defer closeStorage()
+
+	snoozed := store.GetSnoozedBookmarks()
+	if len(snoozed) == 0 {
+		fmt.Println("No snoozed bookmarks.")
+		return
+	}
+
+	fmt.Printf("Snoozed (%d):\n", len(snoozed))
+	for _, b := range snoozed {
+		fmt.Printf("  • \"%s\" - %s (until %s)\n", b.Title, b.URL, b.SnoozeUntil.Format("Mon Jan 2 15:04"))
+	}
+}
+
+// runUntitled lists bookmarks without a real title so they can be fixed.
+func runUntitled() {
+	store, _, closeStorage := loadStorage()
+	defer closeStorage()
+
+	untitled := store.UntitledBookmarks()
+	if len(untitled) == 0 {
+		fmt.Println("No untitled bookmarks.")
+		return
+	}
+
+	fmt.Printf("Untitled (%d):\n", len(untitled))
+	for _, b := range untitled {
+		fmt.Printf("  • %s (%s)\n", b.URL, store.GetFolderPath(b.FolderID))
+	}
+	fmt.Println("\nPress U in the TUI to review and rename them.")
+}
+
+// runList prints the bookmark tree as indented text, or with --urls-only
+// just the URLs one per line. A folder path argument scopes it to a subtree.
+// Snoozed and archived bookmarks are listed too.
+func runList(args []string) {
+	args, urlsOnly := stripFlag(args, "--urls-only")
+	if len(args) > 1 {
+		fmt.Fprintf(os.Stderr, "Usage: bm list [folder-path] [--urls-only]\n")
+		os.Exit(1)
+	}
+
+	store, _, closeStorage := loadStorage()
+	defer closeStorage()
+
+	var rootID *string
+	if len(args) == 1 && strings.Trim(args[0], "/") != "" {
+		folder := store.GetFolderByPath(strings.Trim(args[0], "/"))
+		if folder == nil {
+			fmt.Fprintf(os.Stderr, "No folder '%s'\n", args[0])
+			os.Exit(1)
+		}
+		rootID = &folder.ID
+	}
+
+	fmt.Print(exporter.ExportTree(store, rootID, urlsOnly))
+}
+
+// runDomains lists hosts by bookmark count, or with a host argument the
+// bookmarks on it, to spot over-represented sites.
+func runDomains(args []string) {
+	if len(args) > 1 {
+		fmt.Fprintf(os.Stderr, "Usage: bm domains [host]\n")
+		os.Exit(1)
+	}
+	merge := false
+	if configPath, err := storage.ConfigFilePath(); err == nil {
+		if config, err := loadConfig(configPath); err == nil {
+			merge = config.MergeSubdomains
+		}
+	}
+
+	store, _, closeStorage := loadStorage()
+	defer closeStorage()
+
+	groups := store.GroupByDomain(merge)
+	if len(args) == 0 {
+		if len(groups) == 0 {
+			fmt.Println("No bookmarks.")
+			return
+		}
+		for _, g := range groups {
+			fmt.Printf("%5d  %s\n", len(g.Bookmarks), g.Domain)
+		}
+		return
+	}
+
+	host := model.URLHost(args[0])
+	for _, g := range groups {
+		if g.Domain != host {
+			continue
+		}
+		fmt.Printf("%s (%d):\n", g.Domain, len(g.Bookmarks))
+		for _, b := range g.Bookmarks {
+			fmt.Printf("  • \"%s\" - %s (%s)\n", b.Title, b.URL, store.GetFolderPath(b.FolderID))
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "No bookmarks on '%s'\n", args[0])
+	os.Exit(1)
+}
+
+// runUnsnooze wakes snoozed bookmarks whose title or URL contains the query.
+func runUnsnooze(query string) {
+	store, dataStorage, closeStorage := loadStorage()
+	defer closeStorage()
+
+	all := query == "--all"
+	query = strings.ToLower(query)
+
+	var woken []model.Bookmark
+	for _, b := range store.GetSnoozedBookmarks() {
+		if all || strings.Contains(strings.ToLower(b.Title), query) || strings.Contains(strings.ToLower(b.URL), query) {
+			_ = store.UnsnoozeBookmark(b.ID)
+			woken = append(woken, b)
+		}
+	}
+
+	if len(woken) == 0 {
+		fmt.Printf("No snoozed bookmarks match '%s'\n", query)
+		return
+	}
 
-	// Save
 	if err := dataStorage.Save(store); err != nil {
 		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("Added to %s: %s\n", config.QuickAddFolder, title)
+	for _, b := range woken {
+		fmt.Printf("Woke: \"%s\"\n", b.Title)
+	}
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal rather
+// than a pipe or file.
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err != nil || info.Mode()&os.ModeCharDevice != 0
+}
+
+// readFirstLine returns the first non-blank line of r, trimmed, or "" if
+// there is none.
+func readFirstLine(r io.Reader) string {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// runAdd handles the quick add command.
+func runAdd(args []string) {
+	// Parse flags
+	var urlFlag, titleFlag, templateFlag, priorityFlag, folderFlag string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--url":
+			if i+1 < len(args) {
+				urlFlag = args[i+1]
+				i++
+			}
+		case "--title":
+			if i+1 < len(args) {
+				titleFlag = args[i+1]
+				i++
+			}
+		case "--template":
+			if i+1 < len(args) {
+				templateFlag = args[i+1]
+				i++
+			}
+		case "--priority":
+			if i+1 < len(args) {
+				priorityFlag = args[i+1]
+				i++
+			}
+		case "--folder":
+			if i+1 < len(args) {
+				folderFlag = args[i+1]
+				i++
+			}
+		default:
+			if urlFlag == "" && !strings.HasPrefix(args[i], "--") {
+				urlFlag = args[i]
+			}
+		}
+	}
+
+	// Get URL from flag, piped stdin or clipboard
+	bookmarkURL := urlFlag
+	if bookmarkURL == "" && !stdinIsTerminal() {
+		bookmarkURL = readFirstLine(os.Stdin)
+		if bookmarkURL == "" {
+			fmt.Fprintf(os.Stderr, "No URL on stdin\n")
+			os.Exit(1)
+		}
+	}
+	if bookmarkURL == "" {
+		var err error
+		bookmarkURL, err = clipboard.ReadAll()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Clipboard unavailable (%v); pass the URL with --url or on stdin\n", err)
+			os.Exit(1)
+		}
+		bookmarkURL = strings.TrimSpace(bookmarkURL)
+	}
+
+	if bookmarkURL == "" {
+		fmt.Fprintf(os.Stderr, "No URL found in clipboard\n")
+		os.Exit(1)
+	}
+
+	priority := model.PriorityNone
+	if priorityFlag != "" {
+		p, err := model.ParsePriority(priorityFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		priority = p
+	}
+
+	// Validate URL
+	parsedURL, err := url.Parse(bookmarkURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		fmt.Fprintf(os.Stderr, "Invalid URL: %s\n", bookmarkURL)
+		os.Exit(1)
+	}
+
+	// Load config
+	configFilePath, err := storage.ConfigFilePath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting config path: %v\n", err)
+		os.Exit(1)
+	}
+
+	config, err := loadConfig(configFilePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	var template storage.Template
+	if templateFlag != "" {
+		var ok bool
+		template, ok = config.Templates[templateFlag]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown template %q", templateFlag)
+			if names := config.TemplateNames(); len(names) > 0 {
+				fmt.Fprintf(os.Stderr, " (have: %s)", strings.Join(names, ", "))
+			}
+			fmt.Fprintln(os.Stderr)
+			os.Exit(1)
+		}
+	}
+
+	// Load store
+	store, dataStorage, closeStorage := loadStorage()
+	defer closeStorage()
+
+	// Find or create the target folder: --folder wins over the quick add folder
+	var folderID string
+	if folderFlag != "" {
+		// The same limits as filing through the TUI: no locked folders, no
+		// chains deeper than maxFolderDepth
+		if err := store.CheckFolderPath(folderFlag, config.MaxFolderDepth); err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot add to %q: %v\n", folderFlag, err)
+			os.Exit(1)
+		}
+		folder, _ := store.GetOrCreateFolderByPath(strings.Trim(folderFlag, "/"))
+		if folder == nil {
+			fmt.Fprintf(os.Stderr, "Invalid folder path: %q\n", folderFlag)
+			os.Exit(1)
+		}
+		folderID = folder.ID
+	} else {
+		folderID = findOrCreateFolder(store, config.QuickAddFolder)
+	}
+
+	// Determine title and tags
+	var title string
+	var tags []string
+
+	if titleFlag != "" {
+		// Use provided title
+		title = titleFlag
+	} else if noAI || config.DisableAI {
+		title = bookmarkURL
+	} else {
+		// Try to use AI
+		aiClient, err := ai.NewClient()
+		if err != nil {
+			// AI unavailable - use URL as title
+			fmt.Printf("AI unavailable (%v) - using URL as title\n", err)
+			title = bookmarkURL
+		} else {
+			// Build context for AI
+			storeContext := ai.BuildContext(store)
+			response, err := aiClient.SuggestBookmark(context.Background(), bookmarkURL, storeContext)
+			if err != nil {
+				fmt.Printf("AI request failed (%v) - using URL as title\n", err)
+				title = bookmarkURL
+			} else {
+				title = response.Title
+				tags = model.NormalizeTags(response.Tags, config.LowercaseTags)
+			}
+		}
+	}
+
+	// Create bookmark
+	newBookmark := model.NewBookmark(model.NewBookmarkParams{
+		Title:    title,
+		URL:      bookmarkURL,
+		FolderID: &folderID,
+		Tags:     tags,
+	})
+	if templateFlag != "" {
+		template.Apply(store, &newBookmark, config.LowercaseTags)
+		if folderFlag != "" {
+			newBookmark.FolderID = &folderID
+		}
+	}
+	newBookmark.Priority = priority
+
+	store.AddBookmark(newBookmark)
+
+	// Save
+	if err := dataStorage.Save(store); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Added to %s: %s\n", store.GetFolderPath(newBookmark.FolderID), newBookmark.Title)
 }
 
 // findOrCreateFolder finds a folder by name or creates it at root level.
@@ -491,13 +1404,13 @@ func findOrCreateFolder(store *model.Store, name string) string {
 
 // runInit creates the config and data files with sample data.
 func runInit() {
-	dataPath, err := storage.DefaultSQLitePath()
+	dataPath, err := storage.SQLitePath()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting data path: %v\n", err)
 		os.Exit(1)
 	}
 
-	configPath, err := storage.DefaultConfigFilePath()
+	configPath, err := storage.ConfigFilePath()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting config path: %v\n", err)
 		os.Exit(1)
@@ -641,6 +1554,445 @@ func runReset() {
 	fmt.Println("All data cleared")
 }
 
+// runDoctor reports sibling folders that share a name, which make path-based
+// lookups ambiguous, and offers to merge each group into its first folder.
+func runDoctor(args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "--flatten-deep":
+			runFlattenDeep()
+			return
+		case "--fix-encoding":
+			runFixEncoding()
+			return
+		case "--dedup-tags":
+			runDedupTags()
+			return
+		}
+	}
+
+	store, dataStorage, closeStorage := loadStorage()
+	defer closeStorage()
+
+	// Problems with their own pass are only counted here
+	broken := len(findMojibake(store))
+	if broken > 0 {
+		fmt.Printf("Names with broken encoding (%d): bm doctor --fix-encoding repairs them\n", broken)
+	}
+	messy := len(findMessyTags(store))
+	if messy > 0 {
+		fmt.Printf("Bookmarks with duplicate or blank tags (%d): bm doctor --dedup-tags cleans them\n", messy)
+	}
+
+	collisions := store.FindSiblingNameCollisions()
+	if len(collisions) == 0 {
+		if broken == 0 && messy == 0 {
+			fmt.Println("No problems found.")
+		}
+		return
+	}
+
+	fmt.Printf("Same-named sibling folders (%d):\n", len(collisions))
+	for _, group := range collisions {
+		items := 0
+		for _, f := range group {
+			items += store.CountItemsInFolder(f.ID)
+		}
+		fmt.Printf("  • %s ×%d (%d items)\n", store.GetFolderPath(&group[0].ID), len(group), items)
+	}
+
+	fmt.Print("\nMerge each group into one folder? [y/N] ")
+	var confirm string
+	_, _ = fmt.Scanln(&confirm)
+	if !strings.EqualFold(confirm, "y") && !strings.EqualFold(confirm, "yes") {
+		fmt.Println("Aborted")
+		return
+	}
+
+	removed := store.MergeSiblingNameCollisions()
+	if err := dataStorage.Save(store); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Merged %d duplicate folders\n", removed)
+}
+
+// runPruneEmpty deletes folders whose subtree holds no bookmarks after
+// listing them and asking for confirmation.
+func runPruneEmpty() {
+	store, dataStorage, closeStorage := loadStorage()
+	defer closeStorage()
+
+	empty := store.EmptyFolders()
+	if len(empty) == 0 {
+		fmt.Println("No empty folders.")
+		return
+	}
+
+	fmt.Printf("Empty folders (%d):\n", len(empty))
+	for _, f := range empty {
+		fmt.Printf("  • %s\n", store.GetFolderPath(&f.ID))
+	}
+
+	fmt.Print("\nDelete these folders? [y/N] ")
+	var confirm string
+	_, _ = fmt.Scanln(&confirm)
+	if !strings.EqualFold(confirm, "y") && !strings.EqualFold(confirm, "yes") {
+		fmt.Println("Aborted")
+		return
+	}
+
+	removed := store.PruneEmptyFolders()
+	if err := dataStorage.Save(store); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Removed %d empty folders\n", removed)
+}
+
+// openBackupStorage opens the storage for bm backup and bm restore,
+// exiting if the backend cannot be backed up.
+func openBackupStorage() (storage.BackupStorage, func()) {
+	_, dataStorage, closeStorage := loadStorage()
+	bs, ok := dataStorage.(storage.BackupStorage)
+	if !ok {
+		closeStorage()
+		fmt.Fprintf(os.Stderr, "Backups are not available for this storage backend\n")
+		os.Exit(1)
+	}
+	return bs, closeStorage
+}
+
+// runBackup saves a timestamped copy of the database to the backups directory.
+func runBackup() {
+	bs, closeStorage := openBackupStorage()
+	defer closeStorage()
+
+	path, err := bs.Backup(storage.BackupDir(bs.Path()), time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating backup: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Backed up to %s\n", path)
+}
+
+// runRestore replaces the database with a backup. Without an argument it lists
+// the backups, asks which one to restore and confirms; with a file name or
+// path it restores that backup directly. The current data is backed up first.
+func runRestore(args []string) {
+	if len(args) > 1 {
+		fmt.Fprintf(os.Stderr, "Usage: bm restore [file]\n")
+		os.Exit(1)
+	}
+
+	bs, closeStorage := openBackupStorage()
+	defer closeStorage()
+
+	dir := storage.BackupDir(bs.Path())
+	backups, err := storage.ListBackups(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing backups: %v\n", err)
+		os.Exit(1)
+	}
+
+	var backupPath string
+	if len(args) == 1 {
+		backupPath = args[0]
+		if _, err := os.Stat(backupPath); err != nil {
+			// Not a path: look it up by name in the backups directory
+			backup, err := storage.SelectBackup(backups, args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			backupPath = backup.Path
+		}
+	} else {
+		if len(backups) == 0 {
+			fmt.Printf("No backups in %s. Create one with 'bm backup'.\n", dir)
+			return
+		}
+		fmt.Printf("Backups in %s:\n", dir)
+		for i, b := range backups {
+			fmt.Printf("  %2d. %s  %s  %s\n", i+1, b.Time.Format("2006-01-02 15:04:05"), formatSize(b.Size), b.Name())
+		}
+
+		fmt.Printf("\nRestore which backup? [1-%d] ", len(backups))
+		var choice string
+		_, _ = fmt.Scanln(&choice)
+		if choice == "" {
+			fmt.Println("Aborted")
+			return
+		}
+		backup, err := storage.SelectBackup(backups, choice)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Replace your current bookmarks with %s? [y/N] ", backup.Name())
+		var confirm string
+		_, _ = fmt.Scanln(&confirm)
+		if !strings.EqualFold(confirm, "y") && !strings.EqualFold(confirm, "yes") {
+			fmt.Println("Aborted")
+			return
+		}
+		backupPath = backup.Path
+	}
+
+	current, err := bs.Backup(dir, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error backing up current data: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Current data backed up to %s\n", current)
+
+	if err := bs.Restore(backupPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error restoring backup: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Restored %s\n", backupPath)
+}
+
+// formatSize renders a byte count for listings, e.g. "12.3 KB".
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
+
+// runFlattenDeep collapses folders nested deeper than maxFolderDepth.
+func runFlattenDeep() {
+	configPath, err := storage.ConfigFilePath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting config path: %v\n", err)
+		os.Exit(1)
+	}
+	config, err := loadConfig(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	if config.MaxFolderDepth <= 0 {
+		fmt.Fprintf(os.Stderr, "maxFolderDepth is not set in %s\n", configPath)
+		os.Exit(1)
+	}
+
+	store, dataStorage, closeStorage := loadStorage()
+	defer closeStorage()
+
+	var tooDeep []string
+	for _, f := range store.Folders {
+		if store.FolderDepth(&f.ID) == config.MaxFolderDepth+1 {
+			tooDeep = append(tooDeep, store.GetFolderPath(&f.ID))
+		}
+	}
+	if len(tooDeep) == 0 {
+		fmt.Printf("No folders deeper than %d.\n", config.MaxFolderDepth)
+		return
+	}
+
+	fmt.Printf("Branches deeper than %d (%d):\n", config.MaxFolderDepth, len(tooDeep))
+	for _, path := range tooDeep {
+		fmt.Printf("  • %s\n", path)
+	}
+
+	fmt.Print("\nCollapse them into their parents? [y/N] ")
+	var confirm string
+	_, _ = fmt.Scanln(&confirm)
+	if !strings.EqualFold(confirm, "y") && !strings.EqualFold(confirm, "yes") {
+		fmt.Println("Aborted")
+		return
+	}
+
+	removed := store.FlattenDeepFolders(config.MaxFolderDepth)
+	if err := dataStorage.Save(store); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Removed %d over-deep folders\n", removed)
+}
+
+// mojibakeFix is a bookmark title or folder name with broken encoding and
+// its repaired form.
+type mojibakeFix struct {
+	name  *string // the title or name to overwrite
+	fixed string
+}
+
+// findMojibake returns the bookmark titles and folder names that
+// model.FixMojibake can repair.
+func findMojibake(store *model.Store) []mojibakeFix {
+	var fixes []mojibakeFix
+	for i := range store.Folders {
+		if fixed, ok := model.FixMojibake(store.Folders[i].Name); ok {
+			fixes = append(fixes, mojibakeFix{&store.Folders[i].Name, fixed})
+		}
+	}
+	for i := range store.Bookmarks {
+		if fixed, ok := model.FixMojibake(store.Bookmarks[i].Title); ok {
+			fixes = append(fixes, mojibakeFix{&store.Bookmarks[i].Title, fixed})
+		}
+	}
+	return fixes
+}
+
+// runFixEncoding lists titles and folder names whose UTF-8 was decoded as
+// Windows-1252 (â€™ for ’) and repairs them after confirmation.
+func runFixEncoding() {
+	store, dataStorage, closeStorage := loadStorage()
+	defer closeStorage()
+
+	fixes := findMojibake(store)
+	if len(fixes) == 0 {
+		fmt.Println("No broken encoding found.")
+		return
+	}
+
+	fmt.Printf("Names with broken encoding (%d):\n", len(fixes))
+	for _, f := range fixes {
+		fmt.Printf("  • %s → %s\n", *f.name, f.fixed)
+	}
+
+	fmt.Print("\nRepair them? [y/N] ")
+	var confirm string
+	_, _ = fmt.Scanln(&confirm)
+	if !strings.EqualFold(confirm, "y") && !strings.EqualFold(confirm, "yes") {
+		fmt.Println("Aborted")
+		return
+	}
+
+	for _, f := range fixes {
+		*f.name = f.fixed
+	}
+	if err := dataStorage.Save(store); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Repaired %d names\n", len(fixes))
+}
+
+// findMessyTags returns the bookmarks whose tags change under
+// Bookmark.NormalizeTags: padded, blank or repeated in another case.
+func findMessyTags(store *model.Store) []*model.Bookmark {
+	var messy []*model.Bookmark
+	for i := range store.Bookmarks {
+		b := &store.Bookmarks[i]
+		if !slices.Equal(model.NormalizeTags(b.Tags, false), b.Tags) {
+			messy = append(messy, b)
+		}
+	}
+	return messy
+}
+
+// runDedupTags lists bookmarks with duplicate, padded or blank tags and
+// normalizes them after confirmation.
+func runDedupTags() {
+	store, dataStorage, closeStorage := loadStorage()
+	defer closeStorage()
+
+	messy := findMessyTags(store)
+	if len(messy) == 0 {
+		fmt.Println("No duplicate tags found.")
+		return
+	}
+
+	fmt.Printf("Bookmarks with duplicate or blank tags (%d):\n", len(messy))
+	for _, b := range messy {
+		fmt.Printf("  • %s: %q → %q\n", b.Title, b.Tags, model.NormalizeTags(b.Tags, false))
+	}
+
+	fmt.Print("\nClean them up? [y/N] ")
+	var confirm string
+	_, _ = fmt.Scanln(&confirm)
+	if !strings.EqualFold(confirm, "y") && !strings.EqualFold(confirm, "yes") {
+		fmt.Println("Aborted")
+		return
+	}
+
+	now := time.Now()
+	for _, b := range messy {
+		b.NormalizeTags()
+		b.MarkModified(now)
+	}
+	if err := dataStorage.Save(store); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving bookmarks: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Cleaned tags on %d bookmarks\n", len(messy))
+}
+
+// runSync commits the database and config to the git repository containing
+// the data directory, then pulls and pushes. It shells out to git.
+func runSync() {
+	dbPath, err := storage.SQLitePath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting database path: %v\n", err)
+		os.Exit(1)
+	}
+	configPath, err := storage.ConfigFilePath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting config path: %v\n", err)
+		os.Exit(1)
+	}
+	config, err := loadConfig(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Opening and closing the database checkpoints the WAL into the main file
+	_, _, closeStorage := loadStorage()
+	closeStorage()
+
+	dataDir := filepath.Dir(dbPath)
+	res, err := gitsync.Sync(gitsync.Options{
+		Dir:    dataDir,
+		Files:  []string{dbPath, configPath},
+		Remote: config.SyncRemote,
+		Branch: config.SyncBranch,
+		Now:    time.Now(),
+	})
+	if errors.Is(err, gitsync.ErrNotRepo) {
+		fmt.Printf("%s is not a git repository, nothing to sync\n", dataDir)
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Sync failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if res.Committed {
+		fmt.Println("Committed changes")
+	} else {
+		fmt.Println("No changes to commit")
+	}
+	switch {
+	case res.Pushed:
+		fmt.Printf("Pushed to %s\n", config.SyncRemote)
+	case res.NoRemote:
+		fmt.Printf("Remote %q not configured, skipped push\n", config.SyncRemote)
+	}
+}
+
+// loadConfig reads the config for a CLI command and prints on stderr any
+// invalid value that was replaced with its default.
+func loadConfig(path string) (*storage.Config, error) {
+	config, err := storage.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range config.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: config %s\n", w)
+	}
+	return config, nil
+}
+
 // loadStorage opens the appropriate storage backend and returns it with a cleanup function.
 func loadStorage() (*model.Store, storage.Storage, func()) {
 	dataStorage, err := storage.OpenStorage()
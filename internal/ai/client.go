@@ -2,6 +2,7 @@ package ai
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,6 +22,7 @@ const (
 
 var (
 	ErrNoAPIKey        = errors.New("ANTHROPIC_API_KEY environment variable not set")
+	ErrDisabled        = errors.New("AI is disabled")
 	ErrAPIRequest      = errors.New("API request failed")
 	ErrInvalidResponse = errors.New("invalid API response")
 )
@@ -48,8 +50,9 @@ func NewClient() (*Client, error) {
 }
 
 // SuggestBookmark calls the AI to suggest title, folder, and tags for a URL.
-func (c *Client) SuggestBookmark(url string, context string) (*Response, error) {
-	prompt := buildPrompt(url, context)
+// storeContext describes the existing folders and tags (see BuildContext).
+func (c *Client) SuggestBookmark(ctx context.Context, url string, storeContext string) (*Response, error) {
+	prompt := buildPrompt(url, storeContext)
 
 	reqBody := apiRequest{
 		Model:     haikuModel,
@@ -77,7 +80,7 @@ func (c *Client) SuggestBookmark(url string, context string) (*Response, error)
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -120,8 +123,8 @@ func (c *Client) SuggestBookmark(url string, context string) (*Response, error)
 }
 
 // SuggestOrganize calls the AI to suggest folder and tags for an item.
-func (c *Client) SuggestOrganize(title, url, currentPath string, tags []string, isFolder bool, context string) (*OrganizeResponse, error) {
-	prompt := buildOrganizePrompt(title, url, currentPath, tags, isFolder, context)
+func (c *Client) SuggestOrganize(ctx context.Context, title, url, currentPath string, tags []string, isFolder bool, storeContext string) (*OrganizeResponse, error) {
+	prompt := buildOrganizePrompt(title, url, currentPath, tags, isFolder, storeContext)
 
 	reqBody := apiRequest{
 		Model:     haikuModel,
@@ -150,7 +153,7 @@ func (c *Client) SuggestOrganize(title, url, currentPath string, tags []string,
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
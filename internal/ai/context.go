@@ -2,6 +2,7 @@ package ai
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/nikbrunner/bm/internal/model"
@@ -36,26 +37,106 @@ func buildFolderTree(sb *strings.Builder, store *model.Store, parentID *string,
 
 	for _, folder := range folders {
 		currentPath := path + "/" + folder.Name
+		writeFolderEntry(sb, store, folder.ID, currentPath)
 
-		sb.WriteString(currentPath)
+		// Recurse into subfolders
+		buildFolderTree(sb, store, &folder.ID, currentPath)
+	}
+}
+
+// writeFolderEntry writes a folder path followed by a few sample bookmark titles.
+func writeFolderEntry(sb *strings.Builder, store *model.Store, folderID, path string) {
+	sb.WriteString(path)
+	sb.WriteString("\n")
+
+	bookmarks := store.GetAllBookmarksInFolder(&folderID)
+	sampleCount := min(len(bookmarks), maxSampleTitles)
+	if sampleCount > 0 {
+		titles := make([]string, sampleCount)
+		for i := 0; i < sampleCount; i++ {
+			titles[i] = fmt.Sprintf("\"%s\"", bookmarks[i].Title)
+		}
+		sb.WriteString("  - ")
+		sb.WriteString(strings.Join(titles, ", "))
 		sb.WriteString("\n")
+	}
+}
+
+// BuildFolderContext is like BuildContext but scoped to organizing a single folder.
+// The folder's subtree is listed in full with sample titles; the rest of the store
+// is reduced to the folders along the path to it (top level, ancestors' children),
+// and tags are limited to those used inside the subtree.
+func BuildFolderContext(store *model.Store, folderID string) string {
+	folder := store.GetFolderByID(folderID)
+	if folder == nil {
+		return BuildContext(store)
+	}
+
+	var sb strings.Builder
+	folderPath := store.GetFolderPath(&folderID)
+
+	sb.WriteString("Folder being organized (with sample bookmarks):\n")
+	writeFolderEntry(&sb, store, folderID, folderPath)
+	buildFolderTree(&sb, store, &folderID, folderPath)
+
+	// Shallow view: siblings at each level from the root down to this folder
+	var chain []*string
+	for parentID := folder.ParentID; parentID != nil; {
+		chain = append([]*string{parentID}, chain...)
+		parent := store.GetFolderByID(*parentID)
+		if parent == nil {
+			break
+		}
+		parentID = parent.ParentID
+	}
+	chain = append([]*string{nil}, chain...)
 
-		// Add sample bookmark titles
-		bookmarks := store.GetBookmarksInFolder(&folder.ID)
-		sampleCount := min(len(bookmarks), maxSampleTitles)
-		if sampleCount > 0 {
-			titles := make([]string, sampleCount)
-			for i := 0; i < sampleCount; i++ {
-				titles[i] = fmt.Sprintf("\"%s\"", bookmarks[i].Title)
+	var others []string
+	for _, parentID := range chain {
+		for _, f := range store.GetFoldersInFolder(parentID) {
+			if f.ID == folderID {
+				continue
 			}
-			sb.WriteString("  - ")
-			sb.WriteString(strings.Join(titles, ", "))
-			sb.WriteString("\n")
+			others = append(others, store.GetFolderPath(&f.ID))
 		}
+	}
+	if len(others) > 0 {
+		sb.WriteString("\nOther folders:\n")
+		sb.WriteString(strings.Join(others, "\n"))
+		sb.WriteString("\n")
+	}
 
-		// Recurse into subfolders
-		buildFolderTree(sb, store, &folder.ID, currentPath)
+	tags := collectSubtreeTags(store, folderID)
+	if len(tags) > 0 {
+		sb.WriteString("\nExisting tags in this folder: ")
+		sb.WriteString(strings.Join(tags, ", "))
+	}
+
+	return sb.String()
+}
+
+// collectSubtreeTags returns the sorted unique tags of bookmarks in a folder and its subfolders.
+func collectSubtreeTags(store *model.Store, folderID string) []string {
+	tagSet := make(map[string]bool)
+	var walk func(id string)
+	walk = func(id string) {
+		for _, b := range store.GetAllBookmarksInFolder(&id) {
+			for _, tag := range b.Tags {
+				tagSet[tag] = true
+			}
+		}
+		for _, f := range store.GetFoldersInFolder(&id) {
+			walk(f.ID)
+		}
 	}
+	walk(folderID)
+
+	tags := make([]string, 0, len(tagSet))
+	for tag := range tagSet {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
 }
 
 // GetAllUniqueTags returns all unique tags from the bookmark store.
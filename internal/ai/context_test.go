@@ -0,0 +1,85 @@
+package ai_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikbrunner/bm/internal/ai"
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+func TestBuildFolderContext_ScopesToSubtree(t *testing.T) {
+	dev, golang, rust, cooking := "dev", "go", "rust", "cooking"
+	tools, crates, baking := "tools", "crates", "baking"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: dev, Name: "Dev"},
+			{ID: golang, Name: "Go", ParentID: &dev},
+			{ID: tools, Name: "Tools", ParentID: &golang},
+			{ID: rust, Name: "Rust", ParentID: &dev},
+			{ID: crates, Name: "Crates", ParentID: &rust},
+			{ID: cooking, Name: "Cooking"},
+			{ID: baking, Name: "Baking", ParentID: &cooking},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Effective Go", FolderID: &golang, Tags: []string{"golang"}},
+			{ID: "b2", Title: "gopls", FolderID: &tools, Tags: []string{"lsp"}},
+			{ID: "b3", Title: "The Rust Book", FolderID: &rust, Tags: []string{"rustlang"}},
+			{ID: "b4", Title: "Serde", FolderID: &crates, Tags: []string{"serialization"}},
+			{ID: "b5", Title: "Sourdough", FolderID: &baking, Tags: []string{"bread"}},
+		},
+	}
+
+	context := ai.BuildFolderContext(store, golang)
+
+	for _, want := range []string{
+		"/Dev/Go\n", "/Dev/Go/Tools\n", `"Effective Go"`, `"gopls"`, // full subtree
+		"/Dev\n", "/Dev/Rust\n", "/Cooking\n", // shallow view along the path
+		"golang", "lsp",
+	} {
+		if !strings.Contains(context, want) {
+			t.Errorf("expected context to contain %q, got:\n%s", want, context)
+		}
+	}
+
+	for _, unwanted := range []string{
+		"/Dev/Rust/Crates", "/Cooking/Baking", // far-away subfolders
+		"The Rust Book", "Serde", "Sourdough", // samples outside the subtree
+		"rustlang", "serialization", "bread", // tags outside the subtree
+	} {
+		if strings.Contains(context, unwanted) {
+			t.Errorf("expected context to exclude %q, got:\n%s", unwanted, context)
+		}
+	}
+}
+
+func TestBuildFolderContext_UnknownFolderFallsBack(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{{ID: "f1", Name: "Dev"}},
+	}
+
+	if got, want := ai.BuildFolderContext(store, "missing"), ai.BuildContext(store); got != want {
+		t.Errorf("expected fallback to full context, got:\n%s", got)
+	}
+}
+
+func TestBuildFolderContext_IncludesSnoozedAndArchived(t *testing.T) {
+	dev := "dev"
+	later := time.Now().Add(24 * time.Hour)
+	store := &model.Store{
+		Folders: []model.Folder{{ID: dev, Name: "Dev"}},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Snoozed", FolderID: &dev, Tags: []string{"later"}, SnoozeUntil: &later},
+			{ID: "b2", Title: "Archived", FolderID: &dev, Tags: []string{"old"}, Archived: true},
+		},
+	}
+
+	// Hidden in the browser, but still part of what the folder is about
+	context := ai.BuildFolderContext(store, dev)
+	for _, want := range []string{`"Snoozed"`, `"Archived"`, "later", "old"} {
+		if !strings.Contains(context, want) {
+			t.Errorf("expected context to contain %q, got:\n%s", want, context)
+		}
+	}
+}
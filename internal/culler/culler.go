@@ -1,10 +1,8 @@
 package culler
 
 import (
-	"io"
-	"log"
+	"context"
 	"net/http"
-	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +17,7 @@ const (
 	Healthy     Status = iota // 2xx or 3xx response
 	Dead                      // 404 or 410 Gone
 	Unreachable               // timeout, DNS failure, connection refused, etc.
+	Skipped                   // matched a cull-ignore pattern, not checked
 )
 
 // Result holds the check result for a single bookmark.
@@ -35,22 +34,14 @@ type ProgressFunc func(completed, total int)
 
 // CheckURLs checks all bookmark URLs concurrently and returns results.
 // excludeDomains is a list of domains where 404s should be treated as "possibly private" instead of dead.
-func CheckURLs(bookmarks []model.Bookmark, concurrency int, timeout time.Duration, excludeDomains []string, onProgress ProgressFunc) []Result {
+// Bookmarks matching an ignore pattern (see IsIgnored) are not requested at
+// all and come back Skipped. Cancelling ctx aborts the requests in flight and skips the rest, so the
+// results are then incomplete.
+func CheckURLs(ctx context.Context, bookmarks []model.Bookmark, concurrency int, timeout time.Duration, excludeDomains, ignore []string, onProgress ProgressFunc) []Result {
 	if len(bookmarks) == 0 {
 		return nil
 	}
 
-	// Suppress noisy HTTP client logging (protocol errors, unsolicited responses, etc.)
-	originalOutput := log.Writer()
-	log.SetOutput(io.Discard)
-	defer log.SetOutput(originalOutput)
-
-	// Build exclude map for fast lookup
-	excludeMap := make(map[string]bool)
-	for _, domain := range excludeDomains {
-		excludeMap[strings.ToLower(domain)] = true
-	}
-
 	results := make([]Result, len(bookmarks))
 	jobs := make(chan int, len(bookmarks))
 	var wg sync.WaitGroup
@@ -59,16 +50,7 @@ func CheckURLs(bookmarks []model.Bookmark, concurrency int, timeout time.Duratio
 	var progressMu sync.Mutex
 	completed := 0
 
-	client := &http.Client{
-		Timeout: timeout,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			// Follow redirects but limit to 10
-			if len(via) >= 10 {
-				return http.ErrUseLastResponse
-			}
-			return nil
-		},
-	}
+	client := newClient(timeout)
 
 	// Start workers
 	for w := 0; w < concurrency; w++ {
@@ -76,7 +58,14 @@ func CheckURLs(bookmarks []model.Bookmark, concurrency int, timeout time.Duratio
 		go func() {
 			defer wg.Done()
 			for idx := range jobs {
-				results[idx] = checkURL(client, &bookmarks[idx], excludeMap)
+				if ctx.Err() != nil {
+					continue // cancelled: drain the queue without checking
+				}
+				if IsIgnored(bookmarks[idx].URL, ignore) {
+					results[idx] = Result{Bookmark: &bookmarks[idx], Status: Skipped}
+				} else {
+					results[idx] = checkURL(ctx, client, &bookmarks[idx], excludeDomains)
+				}
 
 				if onProgress != nil {
 					progressMu.Lock()
@@ -98,17 +87,43 @@ func CheckURLs(bookmarks []model.Bookmark, concurrency int, timeout time.Duratio
 	return results
 }
 
+// CheckOne checks a single bookmark URL, e.g. right before opening it.
+// It applies the same rules as CheckURLs.
+func CheckOne(bookmark model.Bookmark, timeout time.Duration, excludeDomains []string) Result {
+	return checkURL(context.Background(), newClient(timeout), &bookmark, excludeDomains)
+}
+
+// newClient returns an HTTP client that follows up to 10 redirects.
+// Keep-alives are off: each check hits a different site anyway, and servers
+// that answer on an idle connection make the transport log "Unsolicited
+// response" through the global logger, which would scribble over the TUI.
+func newClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// Follow redirects but limit to 10
+			if len(via) >= 10 {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+}
+
 // checkURL checks a single URL and returns the result.
-func checkURL(client *http.Client, bookmark *model.Bookmark, excludeMap map[string]bool) Result {
+func checkURL(ctx context.Context, client *http.Client, bookmark *model.Bookmark, excludeDomains []string) Result {
 	result := Result{
 		Bookmark: bookmark,
 	}
 
 	// Try HEAD first (faster, less bandwidth)
-	resp, err := client.Head(bookmark.URL)
+	resp, err := request(ctx, client, http.MethodHead, bookmark.URL)
 	if err != nil {
 		// HEAD failed, try GET as fallback (some servers don't support HEAD)
-		resp, err = client.Get(bookmark.URL)
+		resp, err = request(ctx, client, http.MethodGet, bookmark.URL)
 		if err != nil {
 			result.Status = Unreachable
 			result.Error = normalizeError(err.Error())
@@ -124,7 +139,7 @@ func checkURL(client *http.Client, bookmark *model.Bookmark, excludeMap map[stri
 		result.Status = Healthy
 	case resp.StatusCode == 404 || resp.StatusCode == 410:
 		// Check if this domain is excluded (e.g., private repos)
-		if isExcludedDomain(bookmark.URL, excludeMap) {
+		if isExcludedDomain(bookmark.URL, excludeDomains) {
 			result.Status = Unreachable
 			result.Error = "Possibly private (auth required)"
 		} else {
@@ -140,20 +155,19 @@ func checkURL(client *http.Client, bookmark *model.Bookmark, excludeMap map[stri
 	return result
 }
 
-// isExcludedDomain checks if the URL's domain is in the exclude list.
-func isExcludedDomain(rawURL string, excludeMap map[string]bool) bool {
-	parsed, err := url.Parse(rawURL)
+// request sends a bodiless request that is aborted when ctx is cancelled.
+func request(ctx context.Context, client *http.Client, method, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
-		return false
-	}
-	host := strings.ToLower(parsed.Host)
-	// Check exact match and parent domain (e.g., "api.github.com" matches "github.com")
-	if excludeMap[host] {
-		return true
+		return nil, err
 	}
-	// Check if host ends with excluded domain
-	for domain := range excludeMap {
-		if strings.HasSuffix(host, "."+domain) || host == domain {
+	return client.Do(req)
+}
+
+// isExcludedDomain checks if the URL's domain (or a parent domain) is in the exclude list.
+func isExcludedDomain(rawURL string, excludeDomains []string) bool {
+	for _, domain := range excludeDomains {
+		if model.URLMatchesDomain(rawURL, domain) {
 			return true
 		}
 	}
@@ -0,0 +1,111 @@
+package culler_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nikbrunner/bm/internal/culler"
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+func TestCheckURLs_SkipsBookmarksInIgnoreFile(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cull-ignore")
+	ignoreFile := "# intranet pages need a login\n\n*/admin/*\n"
+	if err := os.WriteFile(path, []byte(ignoreFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ignore, err := culler.LoadIgnoreFile(path)
+	if err != nil {
+		t.Fatalf("LoadIgnoreFile: %v", err)
+	}
+
+	bookmarks := []model.Bookmark{
+		{ID: "admin", URL: server.URL + "/admin/users"},
+		{ID: "gone", URL: server.URL + "/gone"},
+	}
+	results := culler.CheckURLs(context.Background(), bookmarks, 2, 5*time.Second, nil, ignore, nil)
+
+	if got := results[0].Status; got != culler.Skipped {
+		t.Errorf("expected the /admin/ bookmark to be skipped, got status %v", got)
+	}
+	if got := results[1].Status; got != culler.Dead {
+		t.Errorf("expected the other bookmark to be checked and dead, got status %v", got)
+	}
+	if n := requests.Load(); n == 0 || n > 2 {
+		t.Errorf("expected only the unignored bookmark to be requested, got %d requests", n)
+	}
+}
+
+func TestCheckOne_LeavesGlobalLoggerAlone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	original := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(original)
+
+	// Concurrent checks used to swap log output and restore each other's
+	// writer; run them side by side so -race would catch that again
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := culler.CheckOne(model.Bookmark{URL: server.URL}, 5*time.Second, nil).Status; got != culler.Healthy {
+				t.Errorf("expected Healthy, got %v", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if log.Writer() != &buf {
+		t.Error("expected CheckOne to leave the global logger's output alone")
+	}
+}
+
+func TestIsIgnored(t *testing.T) {
+	patterns := []string{"intranet.example.com", "*/admin/*", "github.com/me/private-*"}
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://intranet.example.com/wiki", true},
+		{"https://docs.intranet.example.com", true},
+		{"https://example.com/admin/settings", true},
+		{"https://example.com/administrator", false},
+		{"https://github.com/me/private-notes", true},
+		{"https://github.com/me/public", false},
+		{"https://example.com", false},
+	}
+	for _, tt := range tests {
+		if got := culler.IsIgnored(tt.url, patterns); got != tt.want {
+			t.Errorf("IsIgnored(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestLoadIgnoreFile_MissingFileHasNoPatterns(t *testing.T) {
+	patterns, err := culler.LoadIgnoreFile(filepath.Join(t.TempDir(), "cull-ignore"))
+	if err != nil || patterns != nil {
+		t.Errorf("expected no patterns and no error, got %v, %v", patterns, err)
+	}
+}
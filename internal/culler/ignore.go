@@ -0,0 +1,82 @@
+package culler
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// LoadIgnoreFile reads cull-ignore patterns from path, one per line. Blank
+// lines and lines starting with # are skipped; a missing file has none.
+func LoadIgnoreFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var patterns []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, nil
+}
+
+// IsIgnored reports whether rawURL matches one of patterns. A pattern
+// without "/" names a domain and its subdomains, like CullExcludeDomains.
+// One with "/" is a glob over the whole URL, with or without its scheme,
+// where * matches any run of characters and ? a single one (e.g. "*/admin/*"
+// or "github.com/me/*").
+func IsIgnored(rawURL string, patterns []string) bool {
+	lower := strings.ToLower(rawURL)
+	_, noScheme, _ := strings.Cut(lower, "://")
+	for _, p := range patterns {
+		if !strings.Contains(p, "/") {
+			if model.URLMatchesDomain(rawURL, p) {
+				return true
+			}
+			continue
+		}
+		p = strings.ToLower(p)
+		if globMatch(p, lower) || (noScheme != "" && globMatch(p, noScheme)) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch reports whether s matches pattern, where * matches any run of
+// characters (including "/") and ? exactly one.
+func globMatch(pattern, s string) bool {
+	p, t := []rune(pattern), []rune(s)
+	pi, si := 0, 0
+	star, mark := -1, 0
+	for si < len(t) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == t[si]):
+			pi++
+			si++
+		case pi < len(p) && p[pi] == '*':
+			star, mark = pi, si
+			pi++
+		case star >= 0:
+			// Let the last * swallow one more character and retry
+			mark++
+			pi, si = star+1, mark
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
@@ -0,0 +1,59 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// csvHeader lists the columns written by ExportCSV.
+var csvHeader = []string{"title", "url", "folder", "tags", "created_at", "visited_at", "visit_count"}
+
+// ExportCSV exports one row per bookmark for spreadsheet analysis.
+// Tags are semicolon-joined; timestamps are RFC3339 (empty if never visited).
+func ExportCSV(store *model.Store) string {
+	return ExportCSVWithHealth(store, nil)
+}
+
+// ExportCSVWithHealth is ExportCSV with health and health_checked columns
+// when health is non-nil; both are empty for bookmarks that weren't checked.
+func ExportCSVWithHealth(store *model.Store, health map[string]Health) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+
+	header := csvHeader
+	if health != nil {
+		header = append(slices.Clone(csvHeader), "health", "health_checked")
+	}
+	_ = w.Write(header)
+	for _, bm := range store.Bookmarks {
+		visitedAt := ""
+		if bm.VisitedAt != nil {
+			visitedAt = bm.VisitedAt.Format(time.RFC3339)
+		}
+		row := []string{
+			bm.Title,
+			bm.URL,
+			store.GetFolderPath(bm.FolderID),
+			strings.Join(bm.Tags, ";"),
+			bm.CreatedAt.Format(time.RFC3339),
+			visitedAt,
+			strconv.Itoa(bm.VisitCount),
+		}
+		if health != nil {
+			status, checked := "", ""
+			if h, ok := health[bm.ID]; ok {
+				status, checked = h.Status, h.CheckedAt.Format(time.RFC3339)
+			}
+			row = append(row, status, checked)
+		}
+		_ = w.Write(row)
+	}
+
+	w.Flush()
+	return b.String()
+}
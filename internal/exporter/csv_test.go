@@ -0,0 +1,64 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+func TestExportCSV_ParsesBack(t *testing.T) {
+	store := model.NewStore()
+	store.AddFolder(model.Folder{ID: "f1", Name: "Dev"})
+	folderID := "f1"
+
+	created := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	visited := time.Date(2025, 3, 2, 12, 30, 0, 0, time.UTC)
+	store.AddBookmark(model.Bookmark{
+		ID:         "b1",
+		Title:      `Go, "the" language`,
+		URL:        "https://go.dev",
+		FolderID:   &folderID,
+		Tags:       []string{"go", "docs"},
+		CreatedAt:  created,
+		VisitedAt:  &visited,
+		VisitCount: 3,
+	})
+	store.AddBookmark(model.Bookmark{
+		ID:        "b2",
+		Title:     "Never visited",
+		URL:       "https://example.com",
+		Tags:      []string{},
+		CreatedAt: created,
+	})
+
+	records, err := csv.NewReader(strings.NewReader(ExportCSV(store))).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d records", len(records))
+	}
+	for i, rec := range records {
+		if len(rec) != 7 {
+			t.Errorf("record %d: expected 7 fields, got %d", i, len(rec))
+		}
+	}
+	if strings.Join(records[0], ",") != "title,url,folder,tags,created_at,visited_at,visit_count" {
+		t.Errorf("unexpected header: %v", records[0])
+	}
+
+	want := []string{`Go, "the" language`, "https://go.dev", "/Dev", "go;docs", "2025-03-01T10:00:00Z", "2025-03-02T12:30:00Z", "3"}
+	for i, field := range want {
+		if records[1][i] != field {
+			t.Errorf("row 1 field %d: got %q, want %q", i, records[1][i], field)
+		}
+	}
+
+	if records[2][2] != "/" || records[2][5] != "" || records[2][6] != "0" {
+		t.Errorf("unexpected root/unvisited row: %v", records[2])
+	}
+}
@@ -0,0 +1,35 @@
+package exporter
+
+import (
+	"time"
+
+	"github.com/nikbrunner/bm/internal/culler"
+	"github.com/nikbrunner/bm/internal/storage"
+)
+
+// Health is a bookmark's last-known link status, from the cull cache.
+type Health struct {
+	Status    string    `json:"status"` // "healthy", "dead" or "unreachable"
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// HealthFromCullCache maps bookmark IDs to their status in the last cull run.
+func HealthFromCullCache(cache *storage.CullCache) map[string]Health {
+	health := make(map[string]Health, len(cache.Results))
+	for _, r := range cache.Results {
+		health[r.BookmarkID] = Health{Status: statusName(culler.Status(r.Status)), CheckedAt: cache.Timestamp}
+	}
+	return health
+}
+
+// statusName returns the lowercase name of a cull status.
+func statusName(s culler.Status) string {
+	switch s {
+	case culler.Dead:
+		return "dead"
+	case culler.Unreachable:
+		return "unreachable"
+	default:
+		return "healthy"
+	}
+}
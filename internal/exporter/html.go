@@ -11,19 +11,30 @@ import (
 	"github.com/nikbrunner/bm/internal/model"
 )
 
-// DefaultExportPath returns the default export file path.
-// Format: ~/Downloads/bookmarks-export-YYYY-MM-DD.html
-func DefaultExportPath() (string, error) {
+// DefaultExportPath returns the default export file path for the given extension.
+// Format: ~/Downloads/bookmarks-export-YYYY-MM-DD.<ext>
+func DefaultExportPath(ext string) (string, error) {
+	return DatedExportPath("bookmarks-export", ext)
+}
+
+// DatedExportPath returns ~/Downloads/<name>-YYYY-MM-DD.<ext>.
+func DatedExportPath(name, ext string) (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	filename := fmt.Sprintf("bookmarks-export-%s.html", time.Now().Format("2006-01-02"))
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("2006-01-02"), ext)
 	return filepath.Join(home, "Downloads", filename), nil
 }
 
 // ExportHTML exports the store to Netscape bookmark HTML format.
 func ExportHTML(store *model.Store) string {
+	return ExportHTMLWithHealth(store, nil)
+}
+
+// ExportHTMLWithHealth is ExportHTML with each checked bookmark's last-known
+// status in HEALTH and HEALTH_CHECKED attributes, which browsers ignore.
+func ExportHTMLWithHealth(store *model.Store, health map[string]Health) string {
 	var b strings.Builder
 
 	// Header
@@ -34,7 +45,7 @@ func ExportHTML(store *model.Store) string {
 	b.WriteString("<DL><p>\n")
 
 	// Write root level items
-	writeItems(&b, store, nil, 1)
+	writeItems(&b, store, health, nil, 1)
 
 	// Footer
 	b.WriteString("</DL><p>\n")
@@ -43,7 +54,7 @@ func ExportHTML(store *model.Store) string {
 }
 
 // writeItems recursively writes folders and bookmarks for a given parent.
-func writeItems(b *strings.Builder, store *model.Store, parentID *string, indent int) {
+func writeItems(b *strings.Builder, store *model.Store, health map[string]Health, parentID *string, indent int) {
 	prefix := strings.Repeat("    ", indent)
 
 	// Get folders at this level
@@ -55,21 +66,26 @@ func writeItems(b *strings.Builder, store *model.Store, parentID *string, indent
 
 		// Recurse into folder
 		folderID := folder.ID
-		writeItems(b, store, &folderID, indent+1)
+		writeItems(b, store, health, &folderID, indent+1)
 
 		// Close folder
 		fmt.Fprintf(b, "%s</DL><p>\n", prefix)
 	}
 
 	// Get bookmarks at this level
-	bookmarks := store.GetBookmarksInFolder(parentID)
+	bookmarks := store.GetAllBookmarksInFolder(parentID)
 	for _, bookmark := range bookmarks {
 		timestamp := bookmark.CreatedAt.Unix()
+		annotation := ""
+		if h, ok := health[bookmark.ID]; ok {
+			annotation = fmt.Sprintf(" HEALTH=\"%s\" HEALTH_CHECKED=\"%d\"", h.Status, h.CheckedAt.Unix())
+		}
 		fmt.Fprintf(b,
-			"%s<DT><A HREF=\"%s\" ADD_DATE=\"%d\">%s</A>\n",
+			"%s<DT><A HREF=\"%s\" ADD_DATE=\"%d\"%s>%s</A>\n",
 			prefix,
 			html.EscapeString(bookmark.URL),
 			timestamp,
+			annotation,
 			html.EscapeString(bookmark.Title),
 		)
 	}
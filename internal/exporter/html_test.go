@@ -5,7 +5,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nikbrunner/bm/internal/culler"
 	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/storage"
 )
 
 func TestExportHTML_EmptyStore(t *testing.T) {
@@ -204,3 +206,33 @@ func TestExportHTML_MultipleRootItems(t *testing.T) {
 		t.Error("expected root bookmark")
 	}
 }
+
+func TestExportWithHealth_MarksDeadBookmark(t *testing.T) {
+	store := model.NewStore()
+	store.AddBookmark(model.Bookmark{ID: "b1", Title: "Gone", URL: "https://gone.example", Tags: []string{}, CreatedAt: time.Unix(1700000000, 0)})
+	store.AddBookmark(model.Bookmark{ID: "b2", Title: "Fine", URL: "https://fine.example", Tags: []string{}, CreatedAt: time.Unix(1700000000, 0)})
+
+	checked := time.Unix(1750000000, 0).UTC()
+	health := HealthFromCullCache(&storage.CullCache{
+		Timestamp: checked,
+		Results: []storage.CullCacheResult{
+			{BookmarkID: "b1", Status: int(culler.Dead), StatusCode: 404},
+		},
+	})
+
+	html := ExportHTMLWithHealth(store, health)
+	if !strings.Contains(html, `HREF="https://gone.example" ADD_DATE="1700000000" HEALTH="dead" HEALTH_CHECKED="1750000000">Gone</A>`) {
+		t.Errorf("expected dead bookmark to be annotated, got:\n%s", html)
+	}
+	if strings.Count(html, "HEALTH=") != 1 {
+		t.Errorf("expected only the checked bookmark to be annotated, got:\n%s", html)
+	}
+
+	out, err := ExportJSONWithHealth(store, health)
+	if err != nil {
+		t.Fatalf("ExportJSONWithHealth: %v", err)
+	}
+	if !strings.Contains(out, `"status": "dead"`) || strings.Count(out, `"health"`) != 1 {
+		t.Errorf("expected one health field marking the dead bookmark, got:\n%s", out)
+	}
+}
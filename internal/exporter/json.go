@@ -0,0 +1,44 @@
+package exporter
+
+import (
+	"encoding/json"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// ExportJSON exports the full store as indented JSON, preserving IDs,
+// timestamps and pin/archive/lock state. importer.ParseJSON reads it back.
+func ExportJSON(store *model.Store) (string, error) {
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// healthBookmark is a bookmark with its last-known status alongside.
+type healthBookmark struct {
+	model.Bookmark
+	Health *Health `json:"health,omitempty"`
+}
+
+// ExportJSONWithHealth is ExportJSON with a health field on each checked
+// bookmark. importer.ParseJSON ignores the field.
+func ExportJSONWithHealth(store *model.Store, health map[string]Health) (string, error) {
+	bookmarks := make([]healthBookmark, len(store.Bookmarks))
+	for i, b := range store.Bookmarks {
+		bookmarks[i].Bookmark = b
+		if h, ok := health[b.ID]; ok {
+			bookmarks[i].Health = &h
+		}
+	}
+	data, err := json.MarshalIndent(struct {
+		SchemaVersion int              `json:"schemaVersion"`
+		Folders       []model.Folder   `json:"folders"`
+		Bookmarks     []healthBookmark `json:"bookmarks"`
+	}{store.SchemaVersion, store.Folders, bookmarks}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
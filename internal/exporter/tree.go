@@ -0,0 +1,45 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// ExportTree lists the folder rootID (nil for the whole store) as an indented
+// tree: the folder's path, then its subfolders with a trailing "/" and its
+// bookmarks as "title - url". With urlsOnly it lists just the URLs, one per
+// line. Snoozed and archived bookmarks are included.
+func ExportTree(store *model.Store, rootID *string, urlsOnly bool) string {
+	var b strings.Builder
+	if !urlsOnly {
+		heading := store.GetFolderPath(rootID)
+		if heading != "/" {
+			heading += "/"
+		}
+		b.WriteString(heading + "\n")
+	}
+	writeTree(&b, store, rootID, 1, urlsOnly)
+	return b.String()
+}
+
+// writeTree writes the folders and bookmarks under parentID, indented by
+// depth, descending into each folder before its siblings' bookmarks.
+func writeTree(b *strings.Builder, store *model.Store, parentID *string, depth int, urlsOnly bool) {
+	indent := strings.Repeat("  ", depth)
+	for _, folder := range store.GetFoldersInFolder(parentID) {
+		if !urlsOnly {
+			fmt.Fprintf(b, "%s%s/\n", indent, folder.Name)
+		}
+		folderID := folder.ID
+		writeTree(b, store, &folderID, depth+1, urlsOnly)
+	}
+	for _, bookmark := range store.GetAllBookmarksInFolder(parentID) {
+		if urlsOnly {
+			b.WriteString(bookmark.URL + "\n")
+			continue
+		}
+		fmt.Fprintf(b, "%s%s - %s\n", indent, bookmark.Title, bookmark.URL)
+	}
+}
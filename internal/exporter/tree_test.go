@@ -0,0 +1,58 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+func treeTestStore() *model.Store {
+	devID, goID := "dev", "go"
+	snoozed := time.Now().Add(time.Hour)
+	store := model.NewStore()
+	store.AddFolder(model.Folder{ID: devID, Name: "Dev"})
+	store.AddFolder(model.Folder{ID: goID, Name: "Go", ParentID: &devID})
+	store.AddFolder(model.Folder{ID: "misc", Name: "Misc"})
+	store.AddBookmark(model.Bookmark{ID: "b1", Title: "Go docs", URL: "https://go.dev/doc", FolderID: &goID})
+	store.AddBookmark(model.Bookmark{ID: "b2", Title: "GitHub", URL: "https://github.com", FolderID: &devID, Archived: true})
+	store.AddBookmark(model.Bookmark{ID: "b3", Title: "Later", URL: "https://later.dev", FolderID: &devID, SnoozeUntil: &snoozed})
+	store.AddBookmark(model.Bookmark{ID: "b4", Title: "Root", URL: "https://root.dev"})
+	return store
+}
+
+func TestExportTree_WholeStore(t *testing.T) {
+	want := "/\n" +
+		"  Dev/\n" +
+		"    Go/\n" +
+		"      Go docs - https://go.dev/doc\n" +
+		"    GitHub - https://github.com\n" +
+		"    Later - https://later.dev\n" +
+		"  Misc/\n" +
+		"  Root - https://root.dev\n"
+	if got := ExportTree(treeTestStore(), nil, false); got != want {
+		t.Errorf("ExportTree() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExportTree_ScopedToFolder(t *testing.T) {
+	store := treeTestStore()
+	dev := store.GetFolderByPath("Dev")
+	if dev == nil {
+		t.Fatal("expected Dev folder")
+	}
+
+	want := "/Dev/\n" +
+		"  Go/\n" +
+		"    Go docs - https://go.dev/doc\n" +
+		"  GitHub - https://github.com\n" +
+		"  Later - https://later.dev\n"
+	if got := ExportTree(store, &dev.ID, false); got != want {
+		t.Errorf("ExportTree(Dev) =\n%s\nwant\n%s", got, want)
+	}
+
+	wantURLs := "https://go.dev/doc\nhttps://github.com\nhttps://later.dev\n"
+	if got := ExportTree(store, &dev.ID, true); got != wantURLs {
+		t.Errorf("ExportTree(Dev, urlsOnly) = %q, want %q", got, wantURLs)
+	}
+}
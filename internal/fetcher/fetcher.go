@@ -0,0 +1,119 @@
+// Package fetcher reads a web page's title and description for the peek view.
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// maxHeadBytes bounds how much of a page is read; the metadata is in <head>.
+const maxHeadBytes = 512 << 10
+
+// client gives up on pages that are slow to answer.
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// Meta is what a page says about itself.
+type Meta struct {
+	Title       string // <title>, or og:title
+	Description string // meta description, or og:description
+	Image       string // og:image URL
+}
+
+// Fetch downloads url and extracts its Meta. It fails on a non-2xx status.
+func Fetch(ctx context.Context, url string) (Meta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Meta{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Meta{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Meta{}, fmt.Errorf("%s", resp.Status)
+	}
+	return Parse(io.LimitReader(resp.Body, maxHeadBytes)), nil
+}
+
+// Parse extracts Meta from an HTML document, stopping at <body>.
+func Parse(r io.Reader) Meta {
+	var meta, og Meta
+	z := html.NewTokenizer(r)
+	inTitle := false
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return meta.withFallback(og)
+		case html.TextToken:
+			if inTitle {
+				meta.Title += string(z.Text())
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "title" {
+				inTitle = false
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = true
+			case "body":
+				return meta.withFallback(og)
+			case "meta":
+				if !hasAttr {
+					continue
+				}
+				attrs := map[string]string{}
+				for more := true; more; {
+					var key, val []byte
+					key, val, more = z.TagAttr()
+					attrs[string(key)] = string(val)
+				}
+				switch {
+				case strings.EqualFold(attrs["name"], "description"):
+					meta.Description = attrs["content"]
+				case attrs["property"] == "og:title":
+					og.Title = attrs["content"]
+				case attrs["property"] == "og:description":
+					og.Description = attrs["content"]
+				case attrs["property"] == "og:image":
+					meta.Image = attrs["content"]
+				}
+			}
+		}
+	}
+}
+
+// withFallback fills empty fields of m from og and tidies whitespace.
+func (m Meta) withFallback(og Meta) Meta {
+	if strings.TrimSpace(m.Title) == "" {
+		m.Title = og.Title
+	}
+	if strings.TrimSpace(m.Description) == "" {
+		m.Description = og.Description
+	}
+	m.Title = clean(m.Title)
+	m.Description = clean(m.Description)
+	m.Image = clean(m.Image)
+	return m
+}
+
+// clean drops control characters, so a page can't send escape sequences to the
+// terminal, and collapses whitespace.
+func clean(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
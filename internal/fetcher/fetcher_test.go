@@ -0,0 +1,57 @@
+package fetcher_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikbrunner/bm/internal/fetcher"
+)
+
+func TestParse_ReadsTitleDescriptionAndImage(t *testing.T) {
+	page := `<!doctype html><html><head>
+<title> Go &amp; Tools
+</title>
+<meta name="Description" content="Build simple, secure software.">
+<meta property="og:image" content="https://go.dev/images/go-logo.png">
+</head><body><title>Ignored</title></body></html>`
+
+	got := fetcher.Parse(strings.NewReader(page))
+	want := fetcher.Meta{
+		Title:       "Go & Tools",
+		Description: "Build simple, secure software.",
+		Image:       "https://go.dev/images/go-logo.png",
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestParse_FallsBackToOpenGraph(t *testing.T) {
+	page := `<html><head>
+<meta property="og:title" content="OG Title">
+<meta property="og:description" content="OG description">
+</head></html>`
+
+	got := fetcher.Parse(strings.NewReader(page))
+	if got.Title != "OG Title" || got.Description != "OG description" {
+		t.Errorf("expected og: values, got %+v", got)
+	}
+}
+
+func TestParse_StripsControlCharacters(t *testing.T) {
+	page := "<html><head>\n" +
+		"<title>Evil\x1b[2J Title\x07</title>\n" +
+		"<meta name=\"description\" content=\"Red\x1b[31m text\">\n" +
+		"<meta property=\"og:image\" content=\"https://x.dev/a.png\x1b]0;pwned\x07\">\n" +
+		"</head></html>"
+
+	got := fetcher.Parse(strings.NewReader(page))
+	for _, field := range []string{got.Title, got.Description, got.Image} {
+		if strings.ContainsAny(field, "\x1b\x07") {
+			t.Errorf("expected control characters to be stripped, got %q", field)
+		}
+	}
+	if got.Title != "Evil[2J Title" {
+		t.Errorf("expected the rest of the title to be kept, got %q", got.Title)
+	}
+}
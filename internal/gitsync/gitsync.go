@@ -0,0 +1,140 @@
+// Package gitsync commits and pushes bm's data files by shelling out to git.
+// It never initializes repositories or configures remotes; it only works in a
+// data directory the user already keeps under git.
+package gitsync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrNotRepo is returned when the data directory isn't inside a git work tree.
+var ErrNotRepo = errors.New("not a git repository")
+
+// Options configures a sync run.
+type Options struct {
+	Dir    string    // directory inside the repository (usually the data dir)
+	Files  []string  // files to stage; missing files and files outside the repository are ignored
+	Remote string    // remote to pull from and push to; empty skips pull/push
+	Branch string    // branch to pull/push; empty uses the current branch
+	Now    time.Time // timestamp for the commit message
+}
+
+// Result reports what a sync run did.
+type Result struct {
+	Committed bool // a commit was created (false when nothing changed)
+	Pushed    bool // the branch was pulled and pushed
+	NoRemote  bool // Remote isn't configured in the repository
+}
+
+// Sync stages Files, commits them with a timestamped message if they changed,
+// then pulls (rebasing onto the remote) and pushes. Git failures are returned
+// with git's own error output.
+func Sync(opts Options) (Result, error) {
+	var res Result
+
+	top, err := git(opts.Dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return res, ErrNotRepo
+	}
+
+	files := filesInRepo(top, opts.Files)
+	if len(files) == 0 {
+		return res, fmt.Errorf("no bm files inside %s", top)
+	}
+
+	addArgs := append([]string{"add", "--"}, files...)
+	if _, err := git(top, addArgs...); err != nil {
+		return res, err
+	}
+
+	// diff --cached --quiet exits 1 when the staged files differ from HEAD
+	diffArgs := append([]string{"diff", "--cached", "--quiet", "--"}, files...)
+	if _, err := git(top, diffArgs...); err != nil {
+		msg := "bm sync: " + opts.Now.Format("2006-01-02 15:04:05")
+		commitArgs := append([]string{"commit", "-m", msg, "--"}, files...)
+		if _, err := git(top, commitArgs...); err != nil {
+			return res, err
+		}
+		res.Committed = true
+	}
+
+	if opts.Remote == "" {
+		return res, nil
+	}
+	if _, err := git(top, "remote", "get-url", opts.Remote); err != nil {
+		res.NoRemote = true
+		return res, nil
+	}
+
+	branch := opts.Branch
+	if branch == "" {
+		if branch, err = git(top, "rev-parse", "--abbrev-ref", "HEAD"); err != nil {
+			return res, err
+		}
+	}
+
+	// A brand-new remote has nothing to pull yet
+	if _, err := git(top, "ls-remote", "--exit-code", "--heads", opts.Remote, branch); err == nil {
+		if _, err := git(top, "pull", "--rebase", opts.Remote, branch); err != nil {
+			return res, err
+		}
+	}
+	if _, err := git(top, "push", opts.Remote, "HEAD:"+branch); err != nil {
+		return res, err
+	}
+	res.Pushed = true
+	return res, nil
+}
+
+// filesInRepo returns the paths relative to top for existing files inside it.
+func filesInRepo(top string, files []string) []string {
+	// Resolve symlinks so paths compare equal to git's canonical toplevel
+	if resolved, err := filepath.EvalSymlinks(top); err == nil {
+		top = resolved
+	}
+
+	var rel []string
+	for _, f := range files {
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(abs); err != nil {
+			continue
+		}
+		if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+			abs = filepath.Join(dir, filepath.Base(abs))
+		}
+		r, err := filepath.Rel(top, abs)
+		if err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+			continue
+		}
+		rel = append(rel, r)
+	}
+	return rel
+}
+
+// git runs a git command in dir and returns its trimmed stdout. Errors carry
+// git's stderr so conflicts and auth failures are readable.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		detail := strings.TrimSpace(stderr.String())
+		if detail == "" {
+			detail = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], detail)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
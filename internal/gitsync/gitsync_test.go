@@ -0,0 +1,82 @@
+package gitsync_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikbrunner/bm/internal/gitsync"
+)
+
+func run(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestSync_NotARepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	_, err := gitsync.Sync(gitsync.Options{Dir: dir, Files: []string{filepath.Join(dir, "bm.db")}})
+	if !errors.Is(err, gitsync.ErrNotRepo) {
+		t.Errorf("expected ErrNotRepo, got %v", err)
+	}
+}
+
+func TestSync_CommitsAndPushes(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	remote := t.TempDir()
+	run(t, remote, "init", "--bare", "-b", "main")
+
+	dir := t.TempDir()
+	run(t, dir, "init", "-b", "main")
+	run(t, dir, "config", "user.email", "test@example.com")
+	run(t, dir, "config", "user.name", "Test")
+	run(t, dir, "remote", "add", "origin", remote)
+
+	db := filepath.Join(dir, "bm.db")
+	other := filepath.Join(dir, "notes.txt")
+	for _, f := range []string{db, other} {
+		if err := os.WriteFile(f, []byte("v1"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	opts := gitsync.Options{Dir: dir, Files: []string{db}, Remote: "origin", Now: now}
+	res, err := gitsync.Sync(opts)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if !res.Committed || !res.Pushed {
+		t.Errorf("expected commit and push, got %+v", res)
+	}
+	if msg := run(t, remote, "log", "-1", "--format=%s", "main"); msg != "bm sync: 2024-05-01 12:00:00" {
+		t.Errorf("unexpected commit message %q", msg)
+	}
+	if files := run(t, dir, "show", "--name-only", "--format=", "HEAD"); files != "bm.db" {
+		t.Errorf("expected only bm.db committed, got %q", files)
+	}
+
+	// Nothing changed: no new commit
+	res, err = gitsync.Sync(opts)
+	if err != nil {
+		t.Fatalf("second Sync: %v", err)
+	}
+	if res.Committed {
+		t.Error("expected no commit when nothing changed")
+	}
+}
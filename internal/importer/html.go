@@ -76,12 +76,16 @@ func ParseHTMLBookmarks(r io.Reader) ([]model.Folder, []model.Bookmark, error) {
 					}
 				}
 
+				// Parse TAGS (comma-separated, as exported by Firefox). Case is kept;
+				// the caller applies the LowercaseTags setting.
+				tags := model.NormalizeTags(strings.Split(getAttr(n, "tags"), ","), false)
+
 				bookmark := model.Bookmark{
 					ID:        model.GenerateUUID(),
 					Title:     title,
 					URL:       href,
 					FolderID:  folderID,
-					Tags:      []string{},
+					Tags:      tags,
 					CreatedAt: createdAt,
 					VisitedAt: nil,
 				}
@@ -209,3 +209,27 @@ func TestParseHTML_MissingHref(t *testing.T) {
 		t.Errorf("expected 'Valid' bookmark, got %q", bookmarks[0].Title)
 	}
 }
+
+func TestParseHTML_Tags(t *testing.T) {
+	html := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><A HREF="https://react.dev" TAGS="React, docs,react,">React Docs</A>
+    <DT><A HREF="https://go.dev">Go</A>
+</DL><p>`
+
+	_, bookmarks, err := importer.ParseHTMLBookmarks(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bookmarks) != 2 {
+		t.Fatalf("expected 2 bookmarks, got %d", len(bookmarks))
+	}
+
+	tags := bookmarks[0].Tags
+	if len(tags) != 2 || tags[0] != "React" || tags[1] != "docs" {
+		t.Errorf("expected [React docs], got %q", tags)
+	}
+	if bookmarks[1].Tags == nil || len(bookmarks[1].Tags) != 0 {
+		t.Errorf("expected empty tags without TAGS attribute, got %q", bookmarks[1].Tags)
+	}
+}
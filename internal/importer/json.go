@@ -0,0 +1,43 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/storage"
+)
+
+// ParseJSON reads a store written by exporter.ExportJSON.
+// All fields are kept as-is so the result can be merged with Store.ImportStore.
+// An export from an older bm is migrated to the current schema first; one from
+// a newer bm is refused rather than merged with fields this build doesn't know.
+func ParseJSON(r io.Reader) (*model.Store, error) {
+	var store model.Store
+	if err := json.NewDecoder(r).Decode(&store); err != nil {
+		return nil, fmt.Errorf("invalid bm JSON export: %w", err)
+	}
+	if err := storage.Migrate(&store); err != nil {
+		return nil, fmt.Errorf("bm JSON export: %w", err)
+	}
+
+	for _, f := range store.Folders {
+		if f.ID == "" {
+			return nil, fmt.Errorf("folder %q has no id", f.Name)
+		}
+	}
+	for _, b := range store.Bookmarks {
+		if b.ID == "" {
+			return nil, fmt.Errorf("bookmark %q has no id", b.URL)
+		}
+	}
+
+	if store.Folders == nil {
+		store.Folders = []model.Folder{}
+	}
+	if store.Bookmarks == nil {
+		store.Bookmarks = []model.Bookmark{}
+	}
+	return &store, nil
+}
@@ -0,0 +1,98 @@
+package importer_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikbrunner/bm/internal/exporter"
+	"github.com/nikbrunner/bm/internal/importer"
+	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/storage"
+)
+
+func TestParseJSON_RoundTrip(t *testing.T) {
+	devID := "f1"
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	visited := time.Date(2024, 6, 2, 18, 5, 12, 0, time.UTC)
+	snoozed := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	original := &model.Store{
+		SchemaVersion: storage.CurrentSchemaVersion,
+		Folders: []model.Folder{
+			{ID: devID, Name: "Dev", Pinned: true, PinOrder: 2, Locked: true},
+			{ID: "f2", Name: "Go", ParentID: &devID},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev", FolderID: &devID, Tags: []string{"go", "lang"},
+				CreatedAt: created, VisitedAt: &visited, VisitCount: 7, Pinned: true, PinOrder: 1},
+			{ID: "b2", Title: "Old", URL: "https://old.example.com", Tags: []string{},
+				CreatedAt: created, SnoozeUntil: &snoozed, Archived: true},
+			{ID: "b3", Title: "Pinned last", URL: "https://example.com", Tags: []string{},
+				CreatedAt: created, Pinned: true, PinOrder: 3},
+		},
+	}
+
+	data, err := exporter.ExportJSON(original)
+	if err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+	parsed, err := importer.ParseJSON(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseJSON: %v", err)
+	}
+
+	restored := model.NewStore()
+	added, skipped := restored.ImportStore(parsed)
+	if added != 3 || skipped != 0 {
+		t.Errorf("expected 3 added, 0 skipped; got %d, %d", added, skipped)
+	}
+	if !reflect.DeepEqual(restored.Folders, original.Folders) {
+		t.Errorf("folders differ:\n got %+v\nwant %+v", restored.Folders, original.Folders)
+	}
+	if !reflect.DeepEqual(restored.Bookmarks, original.Bookmarks) {
+		t.Errorf("bookmarks differ:\n got %+v\nwant %+v", restored.Bookmarks, original.Bookmarks)
+	}
+
+	// Importing again is a no-op
+	added, skipped = restored.ImportStore(parsed)
+	if added != 0 || skipped != 3 {
+		t.Errorf("re-import: expected 0 added, 3 skipped; got %d, %d", added, skipped)
+	}
+}
+
+func TestParseJSON_Invalid(t *testing.T) {
+	if _, err := importer.ParseJSON(strings.NewReader("<html>")); err == nil {
+		t.Error("expected error for non-JSON input")
+	}
+	if _, err := importer.ParseJSON(strings.NewReader(`{"bookmarks":[{"url":"https://x.com"}]}`)); err == nil {
+		t.Error("expected error for bookmark without id")
+	}
+}
+
+func TestParseJSON_MigratesOlderExport(t *testing.T) {
+	// Version 9 predates modified_at (v10): the backfill must still run
+	data := `{"schemaVersion": 9, "folders": [], "bookmarks": [
+		{"id": "b1", "title": "Go", "url": "https://go.dev", "createdAt": "2024-03-01T09:30:00Z"}
+	]}`
+	parsed, err := importer.ParseJSON(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseJSON: %v", err)
+	}
+	if parsed.SchemaVersion != storage.CurrentSchemaVersion {
+		t.Errorf("expected schema version %d, got %d", storage.CurrentSchemaVersion, parsed.SchemaVersion)
+	}
+	b := parsed.Bookmarks[0]
+	if !b.ModifiedAt.Equal(b.CreatedAt) {
+		t.Errorf("expected ModifiedAt backfilled from CreatedAt, got %v", b.ModifiedAt)
+	}
+}
+
+func TestParseJSON_RefusesNewerExport(t *testing.T) {
+	data := fmt.Sprintf(`{"schemaVersion": %d, "folders": [], "bookmarks": []}`, storage.CurrentSchemaVersion+1)
+	if _, err := importer.ParseJSON(strings.NewReader(data)); err == nil {
+		t.Error("expected an error for an export from a newer bm")
+	}
+}
@@ -0,0 +1,15 @@
+package importer
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// WriteReport writes an import report as indented JSON, for bm import --report.
+func WriteReport(w io.Writer, report model.ImportReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
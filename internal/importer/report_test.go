@@ -0,0 +1,58 @@
+package importer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nikbrunner/bm/internal/importer"
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+func TestWriteReport_ListsDuplicatesWithExistingMatch(t *testing.T) {
+	devID := "dev"
+	store := &model.Store{
+		Folders: []model.Folder{{ID: devID, Name: "Dev"}},
+		Bookmarks: []model.Bookmark{
+			{ID: "existing", Title: "Go", URL: "https://go.dev", FolderID: &devID, Tags: []string{}},
+		},
+	}
+
+	html := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><A HREF="https://go.dev">The Go Programming Language</A>
+    <DT><A HREF="https://pkg.go.dev">Go Packages</A>
+</DL><p>`
+	folders, bookmarks, err := importer.ParseHTMLBookmarks(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := importer.WriteReport(&buf, store.ImportMergeReport(folders, bookmarks)); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+
+	var report model.ImportReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("report is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if len(report.Added) != 1 || report.Added[0].URL != "https://pkg.go.dev" {
+		t.Errorf("expected only pkg.go.dev to be added, got %+v", report.Added)
+	}
+	if len(report.Duplicates) != 1 {
+		t.Fatalf("expected 1 duplicate, got %+v", report.Duplicates)
+	}
+	dup := report.Duplicates[0]
+	if dup.Title != "The Go Programming Language" || dup.URL != "https://go.dev" {
+		t.Errorf("expected the imported go.dev entry, got %+v", dup)
+	}
+	if dup.Existing.ID != "existing" || dup.Existing.Folder != "/Dev" {
+		t.Errorf("expected match against the existing bookmark in /Dev, got %+v", dup.Existing)
+	}
+	if !strings.Contains(buf.String(), `"errors": []`) {
+		t.Errorf("expected an empty errors list, got\n%s", buf.String())
+	}
+}
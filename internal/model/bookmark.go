@@ -1,18 +1,64 @@
 package model
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Bookmark represents a saved URL with metadata.
 type Bookmark struct {
-	ID        string     `json:"id"`
-	Title     string     `json:"title"`
-	URL       string     `json:"url"`
-	FolderID  *string    `json:"folderId"` // nil = root level
-	Tags      []string   `json:"tags"`
-	CreatedAt time.Time  `json:"createdAt"`
-	VisitedAt *time.Time `json:"visitedAt"` // nil = never visited
-	Pinned    bool       `json:"pinned"`
-	PinOrder  int        `json:"pinOrder"` // 1-9 for pinned items, 0 = not pinned
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	FolderID    *string    `json:"folderId"` // nil = root level
+	Tags        []string   `json:"tags"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ModifiedAt  time.Time  `json:"modifiedAt"` // zero = predates modification tracking
+	VisitedAt   *time.Time `json:"visitedAt"`  // nil = never visited
+	VisitCount  int        `json:"visitCount"`
+	Pinned      bool       `json:"pinned"`
+	PinOrder    int        `json:"pinOrder"`    // 1-9 for pinned items, 0 = not pinned
+	SnoozeUntil *time.Time `json:"snoozeUntil"` // nil = not snoozed
+	Archived    bool       `json:"archived"`    // hidden unless shown inline (ta)
+	RemindAt    *time.Time `json:"remindAt"`    // nil = no reminder
+	Priority    int        `json:"priority"`    // PriorityNone to PriorityHigh
+}
+
+// Bookmark priorities, for triaging a reading backlog.
+const (
+	PriorityNone = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+)
+
+// priorityNames maps each priority to its name, indexed by value.
+var priorityNames = []string{"none", "low", "medium", "high"}
+
+// ParsePriority parses a priority name (none, low, medium, high) or its number (0-3).
+func ParsePriority(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for p, name := range priorityNames {
+		if s == name || s == strconv.Itoa(p) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid priority %q: want none, low, medium or high", s)
+}
+
+// PriorityName returns the name of priority p, e.g. "high".
+func PriorityName(p int) string {
+	if p < PriorityNone || p > PriorityHigh {
+		return priorityNames[PriorityNone]
+	}
+	return priorityNames[p]
+}
+
+// CyclePriority steps the priority up, wrapping from high back to none.
+func (b *Bookmark) CyclePriority() {
+	b.Priority = (b.Priority + 1) % (PriorityHigh + 1)
 }
 
 // NewBookmarkParams holds parameters for creating a new Bookmark.
@@ -30,13 +76,59 @@ func NewBookmark(params NewBookmarkParams) Bookmark {
 		tags = []string{}
 	}
 
+	now := time.Now()
 	return Bookmark{
-		ID:        GenerateUUID(),
-		Title:     params.Title,
-		URL:       params.URL,
-		FolderID:  params.FolderID,
-		Tags:      tags,
-		CreatedAt: time.Now(),
-		VisitedAt: nil,
+		ID:         GenerateUUID(),
+		Title:      params.Title,
+		URL:        params.URL,
+		FolderID:   params.FolderID,
+		Tags:       tags,
+		CreatedAt:  now,
+		ModifiedAt: now,
+		VisitedAt:  nil,
 	}
 }
+
+// IsSnoozed reports whether the bookmark is hidden at the given time.
+func (b Bookmark) IsSnoozed(now time.Time) bool {
+	return b.SnoozeUntil != nil && now.Before(*b.SnoozeUntil)
+}
+
+// IsReminderDue reports whether the bookmark has a reminder at or before now.
+func (b Bookmark) IsReminderDue(now time.Time) bool {
+	return b.RemindAt != nil && !now.Before(*b.RemindAt)
+}
+
+// IsUntitled reports whether the bookmark has no real title: it is empty or
+// just repeats the URL, as happens with some imports and failed AI lookups.
+func (b Bookmark) IsUntitled() bool {
+	title := strings.TrimSpace(b.Title)
+	return title == "" || strings.TrimSuffix(title, "/") == strings.TrimSuffix(b.URL, "/")
+}
+
+// SearchText is what search matches a bookmark against: the title, then the
+// URL and tags. The title comes first so match positions below len(Title)
+// can be highlighted in it.
+func (b Bookmark) SearchText() string {
+	return strings.Join(append([]string{b.Title, b.URL}, b.Tags...), " ")
+}
+
+// LastModified returns when the bookmark was last edited, falling back to
+// CreatedAt for bookmarks that predate modification tracking.
+func (b Bookmark) LastModified() time.Time {
+	if b.ModifiedAt.IsZero() {
+		return b.CreatedAt
+	}
+	return b.ModifiedAt
+}
+
+// MarkModified records an edit to the title, URL or tags at the given time.
+func (b *Bookmark) MarkModified(now time.Time) {
+	b.ModifiedAt = now
+}
+
+// MarkVisited records a visit at the given time.
+func (b *Bookmark) MarkVisited(now time.Time) {
+	b.VisitedAt = &now
+	b.VisitCount++
+}
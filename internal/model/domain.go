@@ -0,0 +1,102 @@
+package model
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// URLMatchesDomain reports whether the URL's host is domain or one of its subdomains
+// (e.g. "api.github.com" matches "github.com", "notgithub.com" does not).
+func URLMatchesDomain(rawURL, domain string) bool {
+	domain = strings.TrimSuffix(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "*."), ".")
+	if domain == "" {
+		return false
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// URLHost returns the lowercased host of rawURL without port, trailing dot
+// or leading "www." (e.g. "https://www.Example.com:8080/a" -> "example.com").
+// A missing scheme is tolerated. Returns "" if there is no host.
+func URLHost(rawURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	if parsed.Scheme == "" && parsed.Host == "" {
+		// "example.com/page" parses as a path; retry as a host
+		if parsed, err = url.Parse("//" + strings.TrimSpace(rawURL)); err != nil {
+			return ""
+		}
+	}
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	return strings.TrimPrefix(host, "www.")
+}
+
+// RegistrableDomain returns the domain a site registered under, e.g.
+// "docs.github.com" -> "github.com" and "news.bbc.co.uk" -> "bbc.co.uk".
+// IPs and hosts without a public suffix (localhost) are returned unchanged.
+func RegistrableDomain(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	return domain
+}
+
+// DomainGroup is a host and the bookmarks on it, see Store.GroupByDomain.
+type DomainGroup struct {
+	Domain    string
+	Bookmarks []*Bookmark
+}
+
+// GroupByDomain groups the non-archived bookmarks by URLHost, largest group
+// first and ties alphabetically. With mergeSubdomains, subdomains count
+// towards their registrable domain (docs.github.com under github.com).
+// Bookmarks without a host are left out.
+func (s *Store) GroupByDomain(mergeSubdomains bool) []DomainGroup {
+	index := make(map[string]int)
+	var groups []DomainGroup
+	for i := range s.Bookmarks {
+		b := &s.Bookmarks[i]
+		if b.Archived {
+			continue
+		}
+		domain := URLHost(b.URL)
+		if domain == "" {
+			continue
+		}
+		if mergeSubdomains {
+			domain = RegistrableDomain(domain)
+		}
+		idx, ok := index[domain]
+		if !ok {
+			idx = len(groups)
+			index[domain] = idx
+			groups = append(groups, DomainGroup{Domain: domain})
+		}
+		groups[idx].Bookmarks = append(groups[idx].Bookmarks, b)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if len(groups[i].Bookmarks) != len(groups[j].Bookmarks) {
+			return len(groups[i].Bookmarks) > len(groups[j].Bookmarks)
+		}
+		return groups[i].Domain < groups[j].Domain
+	})
+	return groups
+}
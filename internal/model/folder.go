@@ -7,6 +7,28 @@ type Folder struct {
 	ParentID *string `json:"parentId"` // nil = root level
 	Pinned   bool    `json:"pinned"`
 	PinOrder int     `json:"pinOrder"` // 1-9 for pinned items, 0 = not pinned
+	Locked   bool    `json:"locked"`   // read-only: contents can't be deleted, moved or organized
+	ViewMode string  `json:"viewMode"` // how the browser lists the folder; "" = columns
+}
+
+// Folder view modes (Folder.ViewMode).
+const (
+	ViewColumns = ""        // subfolders, then the folder's own bookmarks
+	ViewFlat    = "flat"    // every bookmark in the subtree as one list
+	ViewReading = "reading" // like flat, unvisited first, oldest first
+)
+
+// NextViewMode returns the view mode after mode, cycling columns → flat →
+// reading → columns. Unknown modes continue with columns.
+func NextViewMode(mode string) string {
+	switch mode {
+	case ViewColumns:
+		return ViewFlat
+	case ViewFlat:
+		return ViewReading
+	default:
+		return ViewColumns
+	}
 }
 
 // NewFolderParams holds parameters for creating a new Folder.
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// ImportReport records what an import did with each bookmark, so it can be
+// audited afterwards (bm import --report).
+type ImportReport struct {
+	Added      []ImportEntry     `json:"added"`
+	Duplicates []ImportDuplicate `json:"duplicates"`
+	Updated    []ImportEntry     `json:"updated"` // existing bookmarks changed by an ImportResolver
+	Errors     []string          `json:"errors"`
+}
+
+// ImportEntry identifies a bookmark in an ImportReport.
+type ImportEntry struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Folder string `json:"folder"` // full path, "/" for root
+}
+
+// ImportDuplicate is an imported bookmark that was skipped because Existing
+// already has its URL (or, for ImportStore, its ID).
+type ImportDuplicate struct {
+	Title    string      `json:"title"`
+	URL      string      `json:"url"`
+	Existing ImportEntry `json:"existing"`
+}
+
+// NewImportReport returns an empty report whose lists encode as [] rather than null.
+func NewImportReport() ImportReport {
+	return ImportReport{
+		Added:      []ImportEntry{},
+		Duplicates: []ImportDuplicate{},
+		Updated:    []ImportEntry{},
+		Errors:     []string{},
+	}
+}
+
+// importEntry describes a bookmark in s for an ImportReport.
+func (s *Store) importEntry(b Bookmark) ImportEntry {
+	return ImportEntry{ID: b.ID, Title: b.Title, URL: b.URL, Folder: s.GetFolderPath(b.FolderID)}
+}
+
+// addDuplicate records that imported was skipped in favour of existing.
+func (r *ImportReport) addDuplicate(s *Store, imported, existing Bookmark) {
+	r.Duplicates = append(r.Duplicates, ImportDuplicate{
+		Title:    imported.Title,
+		URL:      imported.URL,
+		Existing: s.importEntry(existing),
+	})
+}
+
+// ImportDecision is what an import does with a bookmark whose URL is already saved.
+type ImportDecision int
+
+const (
+	ImportKeep      ImportDecision = iota // skip the imported bookmark (the default)
+	ImportReplace                         // take the imported title, tags and folder
+	ImportMergeTags                       // keep the existing bookmark, adding the imported tags
+	ImportKeepBoth                        // add the imported bookmark alongside the existing one
+)
+
+// ImportResolver chooses what to do with imported, whose URL matches existing.
+// imported.FolderID already points into s, so its folder path can be shown.
+type ImportResolver func(existing, imported Bookmark) ImportDecision
+
+// resolveDuplicate applies resolve's decision for imported against existing,
+// recording it in report. It reports whether imported should still be added.
+func (s *Store) resolveDuplicate(existing *Bookmark, imported Bookmark, resolve ImportResolver, report *ImportReport) bool {
+	decision := ImportKeep
+	if resolve != nil {
+		decision = resolve(*existing, imported)
+	}
+	switch decision {
+	case ImportReplace:
+		existing.Title = imported.Title
+		existing.Tags = append([]string(nil), imported.Tags...)
+		existing.FolderID = imported.FolderID
+		existing.MarkModified(time.Now())
+		report.Updated = append(report.Updated, s.importEntry(*existing))
+	case ImportMergeTags:
+		merged := NormalizeTags(append(append([]string(nil), existing.Tags...), imported.Tags...), false)
+		if slices.Equal(merged, existing.Tags) {
+			// Nothing new to add, so it's just a duplicate
+			report.addDuplicate(s, imported, *existing)
+			break
+		}
+		existing.Tags = merged
+		existing.MarkModified(time.Now())
+		report.Updated = append(report.Updated, s.importEntry(*existing))
+	case ImportKeepBoth:
+		return true
+	default:
+		report.addDuplicate(s, imported, *existing)
+	}
+	return false
+}
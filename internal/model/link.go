@@ -0,0 +1,27 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// MarkdownLinkFormat is the default template for copying a bookmark as a link.
+const MarkdownLinkFormat = "[{title}]({url})"
+
+// ErrInvalidLinkFormat is returned for link templates missing a placeholder.
+var ErrInvalidLinkFormat = errors.New("link format must contain {url} and {title}")
+
+// ValidateLinkFormat checks that a link template contains both the {url}
+// and {title} placeholders.
+func ValidateLinkFormat(format string) error {
+	if !strings.Contains(format, "{url}") || !strings.Contains(format, "{title}") {
+		return ErrInvalidLinkFormat
+	}
+	return nil
+}
+
+// FormatLink renders a bookmark through a link template such as
+// "[[{url}][{title}]]" for org-mode.
+func FormatLink(format string, b Bookmark) string {
+	return strings.NewReplacer("{url}", b.URL, "{title}", b.Title).Replace(format)
+}
@@ -2,6 +2,8 @@ package model_test
 
 import (
 	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -159,15 +161,24 @@ func TestStore_GetBookmarksInFolder(t *testing.T) {
 		},
 	}
 
-	rootBookmarks := store.GetBookmarksInFolder(nil)
+	rootBookmarks := store.GetBookmarksInFolder(nil, false)
 	if len(rootBookmarks) != 2 {
 		t.Errorf("expected 2 root bookmarks, got %d", len(rootBookmarks))
 	}
 
-	nestedBookmarks := store.GetBookmarksInFolder(&f1ID)
+	nestedBookmarks := store.GetBookmarksInFolder(&f1ID, false)
 	if len(nestedBookmarks) != 1 {
 		t.Errorf("expected 1 nested bookmark, got %d", len(nestedBookmarks))
 	}
+
+	// Archived bookmarks only show when asked for
+	store.Bookmarks[2].Archived = true
+	if got := store.GetBookmarksInFolder(nil, false); len(got) != 1 {
+		t.Errorf("expected archived bookmark hidden, got %d", len(got))
+	}
+	if got := store.GetBookmarksInFolder(nil, true); len(got) != 2 {
+		t.Errorf("expected archived bookmark included, got %d", len(got))
+	}
 }
 
 func TestStore_GetFolderByID(t *testing.T) {
@@ -309,6 +320,77 @@ func TestStore_ImportMerge_CountsCorrectly(t *testing.T) {
 	}
 }
 
+func TestStore_ImportMergeResolve_AppliesEachDecision(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{{ID: "dev", Name: "Dev"}},
+		Bookmarks: []model.Bookmark{
+			{ID: "e1", Title: "Keep Me", URL: "https://keep.com", Tags: []string{"a"}},
+			{ID: "e2", Title: "Old Title", URL: "https://replace.com", Tags: []string{"a"}},
+			{ID: "e3", Title: "Merge Me", URL: "https://merge.com", Tags: []string{"a", "b"}},
+			{ID: "e4", Title: "Both", URL: "https://both.com"},
+		},
+	}
+
+	newFolders := []model.Folder{{ID: "imported-dev", Name: "Dev"}}
+	newBookmarks := []model.Bookmark{
+		{ID: "n1", Title: "Incoming Keep", URL: "https://keep.com", Tags: []string{"x"}},
+		{ID: "n2", Title: "New Title", URL: "https://replace.com", Tags: []string{"x"}, FolderID: stringPtr("imported-dev")},
+		{ID: "n3", Title: "Incoming Merge", URL: "https://merge.com", Tags: []string{"b", "c"}},
+		{ID: "n4", Title: "Both Again", URL: "https://both.com"},
+	}
+
+	decisions := map[string]model.ImportDecision{
+		"https://keep.com":    model.ImportKeep,
+		"https://replace.com": model.ImportReplace,
+		"https://merge.com":   model.ImportMergeTags,
+		"https://both.com":    model.ImportKeepBoth,
+	}
+	var asked []string
+	report := store.ImportMergeResolve(newFolders, newBookmarks, func(existing, imported model.Bookmark) model.ImportDecision {
+		if existing.URL != imported.URL {
+			t.Errorf("resolver got mismatched URLs %q and %q", existing.URL, imported.URL)
+		}
+		asked = append(asked, imported.Title)
+		return decisions[imported.URL]
+	})
+
+	if len(asked) != 4 {
+		t.Fatalf("expected resolver to be asked about 4 duplicates, got %v", asked)
+	}
+	if len(report.Duplicates) != 1 || len(report.Updated) != 2 || len(report.Added) != 1 {
+		t.Errorf("expected 1 skipped, 2 updated, 1 added; got %d, %d, %d",
+			len(report.Duplicates), len(report.Updated), len(report.Added))
+	}
+
+	kept := store.GetBookmarkByID("e1")
+	if kept.Title != "Keep Me" || !slices.Equal(kept.Tags, []string{"a"}) {
+		t.Errorf("kept bookmark should be unchanged, got %q %v", kept.Title, kept.Tags)
+	}
+
+	replaced := store.GetBookmarkByID("e2")
+	if replaced.Title != "New Title" || !slices.Equal(replaced.Tags, []string{"x"}) {
+		t.Errorf("replaced bookmark should take incoming title and tags, got %q %v", replaced.Title, replaced.Tags)
+	}
+	if replaced.FolderID == nil || *replaced.FolderID != "dev" {
+		t.Errorf("replaced bookmark should move to the reused Dev folder, got %v", replaced.FolderID)
+	}
+
+	merged := store.GetBookmarkByID("e3")
+	if merged.Title != "Merge Me" || !slices.Equal(merged.Tags, []string{"a", "b", "c"}) {
+		t.Errorf("merged bookmark should keep its title and gain tag c, got %q %v", merged.Title, merged.Tags)
+	}
+
+	var both int
+	for _, b := range store.Bookmarks {
+		if b.URL == "https://both.com" {
+			both++
+		}
+	}
+	if both != 2 {
+		t.Errorf("expected 2 bookmarks for https://both.com, got %d", both)
+	}
+}
+
 // === Pinned Items Tests ===
 
 func TestStore_GetPinnedBookmarks(t *testing.T) {
@@ -425,3 +507,927 @@ func TestStore_TogglePinFolder(t *testing.T) {
 		t.Error("expected error for non-existent folder")
 	}
 }
+
+func TestNormalizeFolderPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{name: "canonical", input: "/Dev/Go", want: "/Dev/Go", wantOK: true},
+		{name: "missing leading slash", input: "Dev/Go", want: "/Dev/Go", wantOK: true},
+		{name: "trailing slash", input: "/Dev/Go/", want: "/Dev/Go", wantOK: true},
+		{name: "double slashes", input: "//Dev//Go", want: "/Dev/Go", wantOK: true},
+		{name: "padded segments", input: "  / Dev /  Go  ", want: "/Dev/Go", wantOK: true},
+		{name: "empty", input: "", wantOK: false},
+		{name: "only slashes", input: "///", wantOK: false},
+		{name: "relative segment", input: "/Dev/../etc", wantOK: false},
+		{name: "backslash", input: `C:\Users\Dev`, wantOK: false},
+		{name: "control character", input: "/De\x00v/Go", wantOK: false},
+		{name: "invalid characters", input: "/<folder>?", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := model.NormalizeFolderPath(tt.input)
+			if ok != tt.wantOK {
+				t.Fatalf("NormalizeFolderPath(%q) ok = %v, want %v", tt.input, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeFolderPath(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSnoozeDuration(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "30m", want: now.Add(30 * time.Minute)},
+		{input: "2h", want: now.Add(2 * time.Hour)},
+		{input: "3d", want: now.AddDate(0, 0, 3)},
+		{input: "1w", want: now.AddDate(0, 0, 7)},
+		{input: " 2W ", want: now.AddDate(0, 0, 14)},
+		{input: "", wantErr: true},
+		{input: "d", wantErr: true},
+		{input: "3", wantErr: true},
+		{input: "0d", wantErr: true},
+		{input: "-1d", wantErr: true},
+		{input: "3y", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := model.ParseSnoozeDuration(tt.input, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseSnoozeDuration(%q) expected error, got %v", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSnoozeDuration(%q) unexpected error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseSnoozeDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookmark_IsSnoozed_Boundary(t *testing.T) {
+	until := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	b := model.Bookmark{ID: "b1", SnoozeUntil: &until}
+
+	if !b.IsSnoozed(until.Add(-time.Second)) {
+		t.Error("expected bookmark to be snoozed just before wake time")
+	}
+	if b.IsSnoozed(until) {
+		t.Error("expected bookmark to reappear at wake time")
+	}
+	if b.IsSnoozed(until.Add(time.Second)) {
+		t.Error("expected bookmark to stay visible after wake time")
+	}
+	if (model.Bookmark{}).IsSnoozed(until) {
+		t.Error("expected bookmark without SnoozeUntil not to be snoozed")
+	}
+}
+
+func TestStore_GetDueReminders(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-48 * time.Hour)
+	later := now.Add(time.Hour)
+	store := model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "exact", RemindAt: &now},
+			{ID: "future", RemindAt: &later},
+			{ID: "overdue", RemindAt: &earlier},
+			{ID: "archived", RemindAt: &earlier, Archived: true},
+			{ID: "none"},
+		},
+	}
+
+	due := store.GetDueReminders(now)
+	if len(due) != 2 || due[0].ID != "overdue" || due[1].ID != "exact" {
+		t.Fatalf("expected overdue then exact, got %+v", due)
+	}
+
+	if err := store.SetReminder("overdue", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if due := store.GetDueReminders(now); len(due) != 1 || due[0].ID != "exact" {
+		t.Errorf("expected cleared reminder to drop out, got %+v", due)
+	}
+	if err := store.SetReminder("missing", &now); err == nil {
+		t.Error("expected error for unknown bookmark")
+	}
+}
+
+func TestStore_UntitledBookmarks(t *testing.T) {
+	store := model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "titled", Title: "Go Docs", URL: "https://go.dev/doc"},
+			{ID: "url-title", Title: "https://example.com/page", URL: "https://example.com/page"},
+			{ID: "slash", Title: "https://example.com/", URL: "https://example.com"},
+			{ID: "empty", Title: "", URL: "https://empty.dev"},
+			{ID: "blank", Title: "   ", URL: "https://blank.dev"},
+			{ID: "archived", Title: "https://old.dev", URL: "https://old.dev", Archived: true},
+			{ID: "domain-title", Title: "example.com", URL: "https://example.com"},
+		},
+	}
+
+	var ids []string
+	for _, b := range store.UntitledBookmarks() {
+		ids = append(ids, b.ID)
+	}
+	want := []string{"url-title", "slash", "empty", "blank"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("UntitledBookmarks() = %v, want %v", ids, want)
+	}
+}
+
+func TestNextViewMode_Cycles(t *testing.T) {
+	mode := model.ViewColumns
+	var got []string
+	for range 3 {
+		mode = model.NextViewMode(mode)
+		got = append(got, mode)
+	}
+	if want := []string{model.ViewFlat, model.ViewReading, model.ViewColumns}; !slices.Equal(got, want) {
+		t.Errorf("NextViewMode cycle = %q, want %q", got, want)
+	}
+	if next := model.NextViewMode("bogus"); next != model.ViewColumns {
+		t.Errorf("NextViewMode(bogus) = %q, want columns", next)
+	}
+}
+
+func TestStore_RecentlyVisited(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	store := model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "week", URL: "https://week.dev", VisitedAt: ago(7 * 24 * time.Hour)},
+			{ID: "never", URL: "https://never.dev"},
+			{ID: "minute", URL: "https://minute.dev", VisitedAt: ago(time.Minute)},
+			{ID: "archived", URL: "https://old.dev", VisitedAt: ago(time.Second), Archived: true},
+			{ID: "hour", URL: "https://hour.dev", VisitedAt: ago(time.Hour)},
+		},
+	}
+
+	ids := func(bookmarks []model.Bookmark) []string {
+		var result []string
+		for _, b := range bookmarks {
+			result = append(result, b.ID)
+		}
+		return result
+	}
+	if got, want := ids(store.RecentlyVisited(0)), []string{"minute", "hour", "week"}; !slices.Equal(got, want) {
+		t.Errorf("RecentlyVisited(0) = %v, want %v", got, want)
+	}
+	if got, want := ids(store.RecentlyVisited(2)), []string{"minute", "hour"}; !slices.Equal(got, want) {
+		t.Errorf("RecentlyVisited(2) = %v, want %v", got, want)
+	}
+}
+
+func TestStore_SnoozeHidesBookmarkUntilWake(t *testing.T) {
+	store := model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Later", URL: "https://later.dev"},
+			{ID: "b2", Title: "Now", URL: "https://now.dev"},
+		},
+	}
+
+	if err := store.SnoozeBookmark("b1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	visible := store.GetBookmarksInFolder(nil, false)
+	if len(visible) != 1 || visible[0].ID != "b2" {
+		t.Errorf("expected only b2 visible, got %+v", visible)
+	}
+	if all := store.GetAllBookmarksInFolder(nil); len(all) != 2 {
+		t.Errorf("expected 2 bookmarks including snoozed, got %d", len(all))
+	}
+	if snoozed := store.GetSnoozedBookmarks(); len(snoozed) != 1 || snoozed[0].ID != "b1" {
+		t.Errorf("expected b1 snoozed, got %+v", snoozed)
+	}
+
+	// Expired snooze reveals the bookmark again
+	if err := store.SnoozeBookmark("b1", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if visible := store.GetBookmarksInFolder(nil, false); len(visible) != 2 {
+		t.Errorf("expected expired snooze to reveal bookmark, got %d visible", len(visible))
+	}
+
+	// Unsnooze on demand
+	_ = store.SnoozeBookmark("b1", time.Now().Add(time.Hour))
+	if err := store.UnsnoozeBookmark("b1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if visible := store.GetBookmarksInFolder(nil, false); len(visible) != 2 {
+		t.Errorf("expected unsnoozed bookmark to be visible, got %d visible", len(visible))
+	}
+
+	if err := store.SnoozeBookmark("nonexistent", time.Now()); err == nil {
+		t.Error("expected error for non-existent bookmark")
+	}
+}
+
+func TestURLMatchesDomain(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		domain string
+		want   bool
+	}{
+		{name: "exact host", url: "https://news.ycombinator.com/item?id=1", domain: "news.ycombinator.com", want: true},
+		{name: "subdomain", url: "https://api.github.com/repos", domain: "github.com", want: true},
+		{name: "nested subdomain", url: "https://a.b.example.com", domain: "example.com", want: true},
+		{name: "case insensitive", url: "https://GitHub.com/x", domain: "GITHUB.com", want: true},
+		{name: "port ignored", url: "http://localhost:8080/", domain: "localhost", want: true},
+		{name: "suffix but not subdomain", url: "https://notgithub.com", domain: "github.com", want: false},
+		{name: "parent of domain", url: "https://ycombinator.com", domain: "news.ycombinator.com", want: false},
+		{name: "empty domain", url: "https://github.com", domain: "", want: false},
+		{name: "invalid url", url: "://bad", domain: "github.com", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := model.URLMatchesDomain(tt.url, tt.domain); got != tt.want {
+				t.Errorf("URLMatchesDomain(%q, %q) = %v, want %v", tt.url, tt.domain, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLHost(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "plain", url: "https://example.com", want: "example.com"},
+		{name: "path and query", url: "https://example.com/a/b?c=d#e", want: "example.com"},
+		{name: "subdomain kept", url: "https://api.github.com/repos", want: "api.github.com"},
+		{name: "www stripped", url: "https://www.example.com/", want: "example.com"},
+		{name: "port and userinfo", url: "http://user:pw@Localhost:8080/x", want: "localhost"},
+		{name: "uppercase and trailing dot", url: "https://EXAMPLE.com./", want: "example.com"},
+		{name: "ipv6", url: "http://[::1]:3000/", want: "::1"},
+		{name: "no scheme", url: "example.com/page", want: "example.com"},
+		{name: "no host", url: "mailto:me@example.com", want: ""},
+		{name: "invalid", url: "https://exa mple.com", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := model.URLHost(tt.url); got != tt.want {
+				t.Errorf("URLHost(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_GroupByDomain(t *testing.T) {
+	store := &model.Store{Bookmarks: []model.Bookmark{
+		{ID: "b1", URL: "https://github.com/a"},
+		{ID: "b2", URL: "https://www.github.com/b"},
+		{ID: "b3", URL: "https://docs.github.com/c"},
+		{ID: "b4", URL: "https://news.bbc.co.uk/d"},
+		{ID: "b5", URL: "https://www.bbc.co.uk/e"},
+		{ID: "b6", URL: "http://127.0.0.1:8080/f"},
+		{ID: "b7", URL: "https://github.com/old", Archived: true},
+		{ID: "b8", URL: "mailto:me@example.com"},
+	}}
+
+	summarize := func(groups []model.DomainGroup) []string {
+		var out []string
+		for _, g := range groups {
+			ids := ""
+			for _, b := range g.Bookmarks {
+				ids += " " + b.ID
+			}
+			out = append(out, g.Domain+":"+ids)
+		}
+		return out
+	}
+
+	// Hosts kept apart: www. is folded, subdomains are not
+	want := []string{"github.com: b1 b2", "127.0.0.1: b6", "bbc.co.uk: b5", "docs.github.com: b3", "news.bbc.co.uk: b4"}
+	if got := summarize(store.GroupByDomain(false)); !slices.Equal(got, want) {
+		t.Errorf("GroupByDomain(false) = %v, want %v", got, want)
+	}
+
+	// Merged: subdomains join their registrable domain, respecting multi-part suffixes
+	want = []string{"github.com: b1 b2 b3", "bbc.co.uk: b4 b5", "127.0.0.1: b6"}
+	if got := summarize(store.GroupByDomain(true)); !slices.Equal(got, want) {
+		t.Errorf("GroupByDomain(true) = %v, want %v", got, want)
+	}
+}
+
+func TestStore_BookmarksWithTag_ExactMatch(t *testing.T) {
+	store := model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Tags: []string{"rust"}},
+			{ID: "b2", Tags: []string{"go", "Rust"}},
+			{ID: "b3", Tags: []string{"rustlang"}},
+			{ID: "b4", Tags: []string{"trust"}},
+			{ID: "b5", Tags: []string{"rust"}, Archived: true},
+			{ID: "b6"},
+		},
+	}
+
+	var ids []string
+	for _, b := range store.BookmarksWithTag(" rust ") {
+		ids = append(ids, b.ID)
+	}
+	if want := []string{"b1", "b2"}; !slices.Equal(ids, want) {
+		t.Errorf("BookmarksWithTag(rust) = %v, want %v", ids, want)
+	}
+	if got := store.BookmarksWithTag("python"); len(got) != 0 {
+		t.Errorf("expected no matches for unused tag, got %v", got)
+	}
+}
+
+func TestStore_MergeTags_DedupesWhenTargetPresent(t *testing.T) {
+	store := model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Tags: []string{"js", "web"}},
+			{ID: "b2", Tags: []string{"JS", "javascript", "react"}},
+			{ID: "b3", Tags: []string{"javascript"}},
+			{ID: "b4", Tags: []string{"js"}, Archived: true},
+			{ID: "b5", Tags: []string{"jsx"}},
+		},
+	}
+
+	if changed := store.MergeTags("js", "javascript"); changed != 3 {
+		t.Errorf("expected 3 bookmarks changed, got %d", changed)
+	}
+
+	want := map[string][]string{
+		"b1": {"javascript", "web"},
+		"b2": {"javascript", "react"},
+		"b3": {"javascript"},
+		"b4": {"javascript"},
+		"b5": {"jsx"},
+	}
+	for _, b := range store.Bookmarks {
+		if !slices.Equal(b.Tags, want[b.ID]) {
+			t.Errorf("%s: tags = %v, want %v", b.ID, b.Tags, want[b.ID])
+		}
+	}
+	if !store.Bookmarks[2].ModifiedAt.IsZero() {
+		t.Error("expected untouched bookmark to keep its ModifiedAt")
+	}
+	if store.Bookmarks[1].ModifiedAt.IsZero() {
+		t.Error("expected merged bookmark to be marked modified")
+	}
+
+	if changed := store.MergeTags("javascript", "JavaScript"); changed != 0 {
+		t.Errorf("expected merging a tag into itself to do nothing, got %d", changed)
+	}
+}
+
+func TestStore_TagCounts_FoldsCase(t *testing.T) {
+	store := model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Tags: []string{"Go", "zig"}},
+			{ID: "b2", Tags: []string{"go", "GO"}},
+			{ID: "b3", Tags: []string{"Rust"}},
+			{ID: "b4", Tags: []string{"apple"}},
+		},
+	}
+
+	// Counted like BookmarksWithTag matches, and sorted regardless of case
+	want := []model.TagCount{{Tag: "apple", Count: 1}, {Tag: "Go", Count: 2}, {Tag: "Rust", Count: 1}, {Tag: "zig", Count: 1}}
+	if got := store.TagCounts(); !slices.Equal(got, want) {
+		t.Errorf("TagCounts() = %v, want %v", got, want)
+	}
+	if got := len(store.BookmarksWithTag("go")); got != 2 {
+		t.Errorf("expected the count to match BookmarksWithTag, got %d", got)
+	}
+}
+
+func TestStore_AddAndRemoveTagByDomain(t *testing.T) {
+	store := model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", URL: "https://news.ycombinator.com/item?id=1", Tags: []string{}},
+			{ID: "b2", URL: "https://news.ycombinator.com/item?id=2", Tags: []string{"hn"}},
+			{ID: "b3", URL: "https://github.com/nikbrunner/bm", Tags: []string{"go"}},
+		},
+	}
+
+	if changed := store.AddTagByDomain("ycombinator.com", "hn"); changed != 1 {
+		t.Errorf("expected 1 bookmark changed (b2 already tagged), got %d", changed)
+	}
+	if len(store.Bookmarks[1].Tags) != 1 {
+		t.Errorf("expected tag not duplicated, got %v", store.Bookmarks[1].Tags)
+	}
+	if len(store.Bookmarks[2].Tags) != 1 {
+		t.Errorf("expected other domains untouched, got %v", store.Bookmarks[2].Tags)
+	}
+
+	if changed := store.RemoveTagByDomain("news.ycombinator.com", "hn"); changed != 2 {
+		t.Errorf("expected 2 bookmarks changed on remove, got %d", changed)
+	}
+	for _, b := range store.Bookmarks[:2] {
+		if len(b.Tags) != 0 {
+			t.Errorf("expected %s to have no tags, got %v", b.ID, b.Tags)
+		}
+	}
+}
+
+func TestStore_CopyFolderContents(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{
+			{ID: "src", Name: "Dev"},
+			{ID: "sub", Name: "Go", ParentID: stringPtr("src")},
+			{ID: "dst", Name: "Dev copy"},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "React", URL: "https://react.dev", FolderID: stringPtr("src"), Tags: []string{"js"}},
+			{ID: "b2", Title: "Go", URL: "https://go.dev", FolderID: stringPtr("sub")},
+		},
+	}
+
+	if count := store.CountItemsInFolder("src"); count != 3 {
+		t.Errorf("expected 3 nested items, got %d", count)
+	}
+
+	if copied := store.CopyFolderContents("src", "dst"); copied != 3 {
+		t.Fatalf("expected 3 items copied, got %d", copied)
+	}
+
+	subCopies := store.GetFoldersInFolder(stringPtr("dst"))
+	if len(subCopies) != 1 || subCopies[0].Name != "Go" || subCopies[0].ID == "sub" {
+		t.Fatalf("expected a fresh copy of Go inside dst, got %+v", subCopies)
+	}
+	top := store.GetAllBookmarksInFolder(stringPtr("dst"))
+	if len(top) != 1 || top[0].ID == "b1" || top[0].URL != "https://react.dev" || len(top[0].Tags) != 1 {
+		t.Errorf("expected a fresh copy of b1 inside dst, got %+v", top)
+	}
+	nested := store.GetAllBookmarksInFolder(&subCopies[0].ID)
+	if len(nested) != 1 || nested[0].ID == "b2" {
+		t.Errorf("expected a fresh copy of b2 inside the copied subfolder, got %+v", nested)
+	}
+
+	// Originals are untouched
+	if store.CountItemsInFolder("src") != 3 {
+		t.Errorf("expected source folder unchanged, got %d items", store.CountItemsInFolder("src"))
+	}
+}
+
+func TestStore_CopyFolderContents_IntoItself(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{
+			{ID: "src", Name: "Dev"},
+			{ID: "dst", Name: "Dev", ParentID: stringPtr("src")},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "React", URL: "https://react.dev", FolderID: stringPtr("src")},
+		},
+	}
+
+	if copied := store.CopyFolderContents("src", "dst"); copied != 1 {
+		t.Errorf("expected only b1 copied, got %d", copied)
+	}
+	if len(store.GetFoldersInFolder(stringPtr("dst"))) != 0 {
+		t.Error("expected the destination not to be copied into itself")
+	}
+}
+
+func TestStore_MergeSiblingNameCollisions(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{
+			{ID: "dev1", Name: "Dev"},
+			{ID: "dev2", Name: "Dev", Pinned: true, PinOrder: 1},
+			{ID: "go1", Name: "Go", ParentID: stringPtr("dev1")},
+			{ID: "go2", Name: "Go", ParentID: stringPtr("dev2")},
+			{ID: "js", Name: "JS", ParentID: stringPtr("dev2")},
+			{ID: "misc", Name: "Misc"},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "React", URL: "https://react.dev", FolderID: stringPtr("dev2")},
+			{ID: "b2", Title: "Go Docs", URL: "https://go.dev", FolderID: stringPtr("go2")},
+			{ID: "b3", Title: "Tour", URL: "https://go.dev/tour", FolderID: stringPtr("go1")},
+		},
+	}
+
+	collisions := store.FindSiblingNameCollisions()
+	if len(collisions) != 1 || len(collisions[0]) != 2 || collisions[0][0].ID != "dev1" {
+		t.Fatalf("expected one root collision on Dev, got %+v", collisions)
+	}
+
+	if removed := store.MergeSiblingNameCollisions(); removed != 2 {
+		t.Errorf("expected 2 folders removed (Dev and nested Go), got %d", removed)
+	}
+	if len(store.FindSiblingNameCollisions()) != 0 {
+		t.Error("expected no collisions after merging")
+	}
+
+	if store.GetFolderByID("dev2") != nil || store.GetFolderByID("go2") != nil {
+		t.Error("expected duplicate folders to be removed")
+	}
+	if b := store.GetBookmarkByID("b1"); b.FolderID == nil || *b.FolderID != "dev1" {
+		t.Errorf("expected b1 moved into dev1, got %v", b.FolderID)
+	}
+	if b := store.GetBookmarkByID("b2"); b.FolderID == nil || *b.FolderID != "go1" {
+		t.Errorf("expected b2 moved into the surviving Go folder, got %v", b.FolderID)
+	}
+	if f := store.GetFolderByID("js"); f.ParentID == nil || *f.ParentID != "dev1" {
+		t.Errorf("expected JS reparented to dev1, got %v", f.ParentID)
+	}
+	if dev := store.GetFolderByID("dev1"); !dev.Pinned || dev.PinOrder != 1 {
+		t.Errorf("expected dev1 to inherit the pin, got pinned=%v order=%d", dev.Pinned, dev.PinOrder)
+	}
+	if len(store.Folders) != 4 || len(store.Bookmarks) != 3 {
+		t.Errorf("expected 4 folders and 3 bookmarks, got %d and %d", len(store.Folders), len(store.Bookmarks))
+	}
+}
+
+func TestStore_FlattenDeepFolders(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{
+			{ID: "dev", Name: "Dev"},
+			{ID: "go", Name: "Go", ParentID: stringPtr("dev")},
+			{ID: "web", Name: "Web", ParentID: stringPtr("go")},
+			{ID: "tmpl", Name: "Templates", ParentID: stringPtr("web")},
+			{ID: "tools", Name: "Tools", ParentID: stringPtr("go")},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", URL: "https://pkg.go.dev/html/template", FolderID: stringPtr("tmpl")},
+			{ID: "b2", URL: "https://go.dev/doc", FolderID: stringPtr("go")},
+		},
+	}
+
+	if depth := store.FolderDepth(stringPtr("tmpl")); depth != 4 {
+		t.Errorf("expected Templates at depth 4, got %d", depth)
+	}
+	if height := store.SubtreeHeight("dev"); height != 4 {
+		t.Errorf("expected Dev subtree height 4, got %d", height)
+	}
+
+	if removed := store.FlattenDeepFolders(2); removed != 3 {
+		t.Errorf("expected 3 folders removed, got %d", removed)
+	}
+	for _, f := range store.Folders {
+		if d := store.FolderDepth(&f.ID); d > 2 {
+			t.Errorf("folder %s still at depth %d", f.Name, d)
+		}
+	}
+	if b := store.GetBookmarkByID("b1"); b.FolderID == nil || *b.FolderID != "go" {
+		t.Errorf("expected b1 collapsed into Go, got %v", b.FolderID)
+	}
+	if len(store.Folders) != 2 {
+		t.Errorf("expected Dev and Go to remain, got %d folders", len(store.Folders))
+	}
+	if removed := store.FlattenDeepFolders(0); removed != 0 {
+		t.Error("expected max depth 0 to be a no-op")
+	}
+}
+
+func TestStore_EmptyFolders_DetectsNestedChain(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{
+			{ID: "old", Name: "Old"},
+			{ID: "mid", Name: "Mid", ParentID: stringPtr("old")},
+			{ID: "leaf", Name: "Leaf", ParentID: stringPtr("mid")},
+			{ID: "dev", Name: "Dev"},
+			{ID: "go", Name: "Go", ParentID: stringPtr("dev")},
+			{ID: "stale", Name: "Stale", ParentID: stringPtr("dev")},
+			{ID: "pins", Name: "Pins"},
+			{ID: "pinned", Name: "Pinned", ParentID: stringPtr("pins"), Pinned: true, PinOrder: 1},
+			{ID: "vault", Name: "Vault", Locked: true},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", URL: "https://go.dev", FolderID: stringPtr("go")},
+		},
+	}
+
+	var ids []string
+	for _, f := range store.EmptyFolders() {
+		ids = append(ids, f.ID)
+	}
+	want := []string{"old", "mid", "leaf", "stale"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("EmptyFolders() = %v, want %v", ids, want)
+	}
+
+	if removed := store.PruneEmptyFolders(); removed != 4 {
+		t.Errorf("expected 4 folders pruned, got %d", removed)
+	}
+	if len(store.EmptyFolders()) != 0 {
+		t.Error("expected no empty folders left after pruning")
+	}
+	if store.GetFolderByID("go") == nil || store.GetFolderByID("pinned") == nil || store.GetFolderByID("vault") == nil {
+		t.Error("expected non-empty, pinned and locked folders to survive")
+	}
+}
+
+func TestStore_FolderStats_CountsRecursively(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{
+			{ID: "dev", Name: "Dev"},
+			{ID: "go", Name: "Go", ParentID: stringPtr("dev")},
+			{ID: "web", Name: "Web", ParentID: stringPtr("go")},
+			{ID: "js", Name: "JS", ParentID: stringPtr("dev")},
+			{ID: "other", Name: "Other"},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", FolderID: stringPtr("dev"), Tags: []string{"docs"}},
+			{ID: "b2", FolderID: stringPtr("go"), Tags: []string{"go", "docs"}},
+			{ID: "b3", FolderID: stringPtr("web"), Tags: []string{"go", "web"}},
+			{ID: "b4", FolderID: stringPtr("web"), Tags: []string{"go"}},
+			{ID: "b5", FolderID: stringPtr("js"), Tags: []string{"web", "js"}},
+			{ID: "archived", FolderID: stringPtr("go"), Tags: []string{"js"}, Archived: true},
+			{ID: "elsewhere", FolderID: stringPtr("other"), Tags: []string{"js"}},
+		},
+	}
+
+	stats := store.FolderStats("dev", 3)
+	if stats.DirectBookmarks != 1 || stats.DirectFolders != 2 {
+		t.Errorf("direct = %d bookmarks, %d folders, want 1, 2", stats.DirectBookmarks, stats.DirectFolders)
+	}
+	if stats.TotalBookmarks != 5 || stats.TotalFolders != 3 {
+		t.Errorf("total = %d bookmarks, %d folders, want 5, 3", stats.TotalBookmarks, stats.TotalFolders)
+	}
+	want := []model.TagCount{{Tag: "go", Count: 3}, {Tag: "docs", Count: 2}, {Tag: "web", Count: 2}}
+	if !slices.Equal(stats.TopTags, want) {
+		t.Errorf("TopTags = %v, want %v", stats.TopTags, want)
+	}
+
+	if leaf := store.FolderStats("web", 3); leaf.TotalBookmarks != 2 || leaf.DirectFolders != 0 {
+		t.Errorf("leaf stats = %+v, want 2 bookmarks and no folders", leaf)
+	}
+}
+
+func TestStore_IsFolderLocked_InheritsFromAncestors(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{
+			{ID: "ref", Name: "Reference"},
+			{ID: "rfc", Name: "RFCs", ParentID: stringPtr("ref")},
+			{ID: "other", Name: "Other"},
+		},
+	}
+
+	if store.IsFolderLocked(stringPtr("rfc")) || store.IsFolderLocked(nil) {
+		t.Fatal("expected nothing locked initially")
+	}
+	if locked, err := store.ToggleLockFolder("ref"); err != nil || !locked {
+		t.Fatalf("expected ref to be locked, got %v, %v", locked, err)
+	}
+	if !store.IsFolderLocked(stringPtr("rfc")) {
+		t.Error("expected subfolder of a locked folder to be locked")
+	}
+	if store.IsFolderLocked(stringPtr("other")) {
+		t.Error("expected sibling folder to stay unlocked")
+	}
+}
+
+func TestStore_CheckFolderPath(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{
+			{ID: "dev", Name: "Dev"},
+			{ID: "go", Name: "Go", ParentID: stringPtr("dev")},
+			{ID: "ref", Name: "Reference", Locked: true},
+		},
+	}
+
+	tests := []struct {
+		path     string
+		maxDepth int
+		wantErr  string
+	}{
+		{"/Dev/Go", 1, ""},          // existing folders are fine even past the limit
+		{"Dev/Go/Tools", 3, ""},     // creating within the limit
+		{"Dev/Go/Tools", 2, "deep"}, // creating past the limit
+		{"Dev/Go/Tools/Lint", 0, ""},
+		{"/Reference", 0, "locked"},
+		{"/Reference/RFCs", 0, "locked"},
+		{"/", 0, "empty"},
+	}
+	for _, tt := range tests {
+		err := store.CheckFolderPath(tt.path, tt.maxDepth)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("CheckFolderPath(%q, %d): unexpected error %v", tt.path, tt.maxDepth, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("CheckFolderPath(%q, %d): expected an error about %q, got %v", tt.path, tt.maxDepth, tt.wantErr, err)
+		}
+	}
+
+	// A path that passes resolves to the existing chain plus new folders
+	folder, created := store.GetOrCreateFolderByPath("Dev/Go/Tools")
+	if !created || folder.ParentID == nil || *folder.ParentID != "go" {
+		t.Errorf("expected Tools created under the existing Go, got %+v (created %v)", folder, created)
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	got := model.ParseTags(" go, ,cli ,,Go ")
+	if !slices.Equal(got, []string{"go", "cli", "Go"}) {
+		t.Errorf("expected [go cli Go], got %q", got)
+	}
+	if got := model.ParseTags("  "); len(got) != 0 {
+		t.Errorf("expected no tags for a blank string, got %q", got)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		tags      []string
+		lowercase bool
+		want      []string
+	}{
+		{"trims and drops blanks", []string{" react ", "", "  "}, true, []string{"react"}},
+		{"collapses case duplicates", []string{"React", "react", " REACT "}, true, []string{"react"}},
+		{"keeps first spelling without lowercase", []string{"React", "react", "Go"}, false, []string{"React", "Go"}},
+		{"nil input", nil, true, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := model.NormalizeTags(tt.tags, tt.lowercase)
+			if len(got) != len(tt.want) {
+				t.Fatalf("NormalizeTags(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("NormalizeTags(%q) = %q, want %q", tt.tags, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBookmark_NormalizeTags(t *testing.T) {
+	b := model.Bookmark{Tags: []string{"go", "Go", " go "}}
+	b.NormalizeTags()
+	if !slices.Equal(b.Tags, []string{"go"}) {
+		t.Errorf("expected a single go tag, got %q", b.Tags)
+	}
+}
+
+func TestStore_AddTagsToBookmark_CleansExistingTags(t *testing.T) {
+	store := model.NewStore()
+	store.AddBookmark(model.Bookmark{ID: "b1", Tags: []string{"go", " Go", ""}})
+
+	if _, err := store.AddTagsToBookmark("b1", []string{"docs"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.GetBookmarkByID("b1").Tags; !slices.Equal(got, []string{"go", "docs"}) {
+		t.Errorf("expected [go docs], got %q", got)
+	}
+}
+
+func TestFormatLink(t *testing.T) {
+	b := model.Bookmark{Title: "Go Docs", URL: "https://go.dev/doc"}
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{"markdown default", model.MarkdownLinkFormat, "[Go Docs](https://go.dev/doc)"},
+		{"org-mode", "[[{url}][{title}]]", "[[https://go.dev/doc][Go Docs]]"},
+		{"wiki", "[{url} {title}]", "[https://go.dev/doc Go Docs]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := model.FormatLink(tt.format, b); got != tt.want {
+				t.Errorf("FormatLink(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateLinkFormat(t *testing.T) {
+	if err := model.ValidateLinkFormat("[[{url}][{title}]]"); err != nil {
+		t.Errorf("expected org-mode format to be valid, got %v", err)
+	}
+	for _, format := range []string{"", "{url}", "[{title}]"} {
+		if err := model.ValidateLinkFormat(format); err == nil {
+			t.Errorf("expected %q to be rejected", format)
+		}
+	}
+}
+
+func TestStore_RemoveFolderKeepContents(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{
+			{ID: "dev", Name: "Dev"},
+			{ID: "tmp", Name: "Tmp", ParentID: stringPtr("dev")},
+			{ID: "go", Name: "Go", ParentID: stringPtr("tmp")},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go Docs", URL: "https://go.dev", FolderID: stringPtr("tmp")},
+			{ID: "b2", Title: "Tour", URL: "https://go.dev/tour", FolderID: stringPtr("go")},
+		},
+	}
+
+	if !store.RemoveFolderKeepContents("tmp") {
+		t.Fatal("expected the folder to be removed")
+	}
+	if store.GetFolderByID("tmp") != nil || len(store.Folders) != 2 || len(store.Bookmarks) != 2 {
+		t.Fatalf("expected only Tmp removed, got %d folders and %d bookmarks", len(store.Folders), len(store.Bookmarks))
+	}
+	if b := store.GetBookmarkByID("b1"); b.FolderID == nil || *b.FolderID != "dev" {
+		t.Errorf("expected b1 moved up to Dev, got %v", b.FolderID)
+	}
+	if f := store.GetFolderByID("go"); f.ParentID == nil || *f.ParentID != "dev" {
+		t.Errorf("expected Go moved up to Dev, got %v", f.ParentID)
+	}
+	if b := store.GetBookmarkByID("b2"); *b.FolderID != "go" {
+		t.Errorf("expected b2 to stay in Go, got %v", *b.FolderID)
+	}
+
+	// A top-level folder hands its contents to the root
+	if !store.RemoveFolderKeepContents("dev") {
+		t.Fatal("expected Dev to be removed")
+	}
+	if b := store.GetBookmarkByID("b1"); b.FolderID != nil {
+		t.Errorf("expected b1 at the root, got %v", *b.FolderID)
+	}
+	if store.RemoveFolderKeepContents("missing") {
+		t.Error("expected false for an unknown folder")
+	}
+}
+
+func TestNestUnder_ImportKeepsStructureBelowPrefix(t *testing.T) {
+	store := model.Store{
+		Folders: []model.Folder{{ID: "dev", Name: "Dev"}},
+	}
+	folders := []model.Folder{
+		{ID: "i1", Name: "Dev"},
+		{ID: "i2", Name: "Go", ParentID: stringPtr("i1")},
+	}
+	bookmarks := []model.Bookmark{
+		{ID: "x1", Title: "Loose", URL: "https://loose.example.com"},
+		{ID: "x2", Title: "Tour", URL: "https://go.dev/tour", FolderID: stringPtr("i2")},
+	}
+
+	prefix, created := store.GetOrCreateFolderByPath("/Imported/2026-10-17")
+	if !created {
+		t.Fatal("expected the prefix folder to be created")
+	}
+	model.NestUnder(folders, bookmarks, prefix.ID)
+	store.ImportMergeReport(folders, bookmarks)
+
+	if len(store.GetFoldersInFolder(nil)) != 2 {
+		t.Errorf("expected only Dev and Imported at the root, got %+v", store.GetFoldersInFolder(nil))
+	}
+	if dev := store.GetFolderByPath("/Dev"); dev == nil || dev.ID != "dev" {
+		t.Error("expected the existing Dev folder to stay as it was")
+	}
+	goFolder := store.GetFolderByPath("/Imported/2026-10-17/Dev/Go")
+	if goFolder == nil {
+		t.Fatal("expected the imported structure below the prefix")
+	}
+	for _, b := range store.Bookmarks {
+		switch b.URL {
+		case "https://loose.example.com":
+			if path := store.GetFolderPath(b.FolderID); path != "/Imported/2026-10-17" {
+				t.Errorf("expected the loose bookmark in the prefix folder, got %s", path)
+			}
+		case "https://go.dev/tour":
+			if b.FolderID == nil || *b.FolderID != goFolder.ID {
+				t.Errorf("expected the tour in the nested Go folder, got %v", b.FolderID)
+			}
+		}
+	}
+}
+
+func TestFixMojibake(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  string
+		fixed bool
+	}{
+		{"Donâ€™t Panic", "Don’t Panic", true},
+		{"â€œQuotedâ€\u009d", "“Quoted”", true},
+		{"CafÃ© MÃ¼ller", "Café Müller", true},
+		{"Ã„rger â€“ ein Leitfaden", "Ärger – ein Leitfaden", true},
+		{"Plain ASCII title", "Plain ASCII title", false},
+		{"Café Müller", "Café Müller", false},
+		{"Don’t Panic", "Don’t Panic", false},
+		{"日本語", "日本語", false},
+	}
+
+	for _, tt := range tests {
+		got, fixed := model.FixMojibake(tt.in)
+		if got != tt.want || fixed != tt.fixed {
+			t.Errorf("FixMojibake(%q) = %q, %v; want %q, %v", tt.in, got, fixed, tt.want, tt.fixed)
+		}
+	}
+}
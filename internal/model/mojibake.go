@@ -0,0 +1,40 @@
+package model
+
+import "unicode/utf8"
+
+// cp1252 maps the Windows-1252 characters in 0x80-0x9F back to their byte.
+// Mis-decoded UTF-8 mostly went through Windows-1252 rather than Latin-1, so
+// ’ shows up as â€™ instead of â plus two control characters.
+var cp1252 = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// FixMojibake repairs text whose UTF-8 bytes were decoded as Windows-1252 or
+// Latin-1, turning "donâ€™t" back into "don’t". It reports false and returns
+// s unchanged when s isn't mojibake, including plain ASCII and correctly
+// encoded accents like "café".
+func FixMojibake(s string) (string, bool) {
+	raw := make([]byte, 0, len(s))
+	multibyte := false
+	for _, r := range s {
+		switch b, ok := cp1252[r]; {
+		case ok:
+			raw = append(raw, b)
+		case r <= 0xFF:
+			raw = append(raw, byte(r))
+		default:
+			// Not a single-byte character, so s wasn't mis-decoded
+			return s, false
+		}
+		if r >= 0x80 {
+			multibyte = true
+		}
+	}
+	if !multibyte || !utf8.Valid(raw) {
+		return s, false
+	}
+	return string(raw), true
+}
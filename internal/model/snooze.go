@@ -0,0 +1,39 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSnoozeDuration is returned when a snooze duration can't be parsed.
+var ErrInvalidSnoozeDuration = errors.New("invalid duration (use e.g. 30m, 2h, 3d, 1w)")
+
+// snoozeUnits maps duration suffixes to their length.
+var snoozeUnits = map[string]time.Duration{
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// ParseSnoozeDuration converts a relative duration like "30m", "2h", "3d" or "1w"
+// into an absolute time after now.
+func ParseSnoozeDuration(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) < 2 {
+		return time.Time{}, ErrInvalidSnoozeDuration
+	}
+
+	unit, ok := snoozeUnits[s[len(s)-1:]]
+	if !ok {
+		return time.Time{}, ErrInvalidSnoozeDuration
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return time.Time{}, ErrInvalidSnoozeDuration
+	}
+
+	return now.Add(time.Duration(n) * unit), nil
+}
@@ -3,8 +3,11 @@ package model
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // MaxPinnedItems is the maximum number of pinned items allowed.
@@ -15,8 +18,9 @@ var ErrMaxPinnedItems = errors.New("maximum pinned items reached (9)")
 
 // Store holds all bookmarks and folders.
 type Store struct {
-	Folders   []Folder   `json:"folders"`
-	Bookmarks []Bookmark `json:"bookmarks"`
+	SchemaVersion int        `json:"schemaVersion"` // 0 = predates versioning
+	Folders       []Folder   `json:"folders"`
+	Bookmarks     []Bookmark `json:"bookmarks"`
 }
 
 // NewStore creates an empty Store with initialized slices.
@@ -40,8 +44,23 @@ func (s *Store) GetFoldersInFolder(parentID *string) []Folder {
 }
 
 // GetBookmarksInFolder returns bookmarks in the given folder.
+// Pass nil for root level bookmarks. Snoozed bookmarks are hidden, and so are
+// archived ones unless includeArchived is set.
+func (s *Store) GetBookmarksInFolder(folderID *string, includeArchived bool) []Bookmark {
+	now := time.Now()
+	var result []Bookmark
+	for _, b := range s.Bookmarks {
+		if ptrEqual(b.FolderID, folderID) && !b.IsSnoozed(now) && (includeArchived || !b.Archived) {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// GetAllBookmarksInFolder returns bookmarks in the given folder, including
+// snoozed and archived ones.
 // Pass nil for root level bookmarks.
-func (s *Store) GetBookmarksInFolder(folderID *string) []Bookmark {
+func (s *Store) GetAllBookmarksInFolder(folderID *string) []Bookmark {
 	var result []Bookmark
 	for _, b := range s.Bookmarks {
 		if ptrEqual(b.FolderID, folderID) {
@@ -51,6 +70,144 @@ func (s *Store) GetBookmarksInFolder(folderID *string) []Bookmark {
 	return result
 }
 
+// CountBookmarksInSubtree counts the bookmarks whose ID is in ids and that live
+// in folderID or any folder below it. A nil folderID covers the whole store.
+func (s *Store) CountBookmarksInSubtree(folderID *string, ids map[string]bool) int {
+	count := 0
+	for _, b := range s.Bookmarks {
+		if !ids[b.ID] {
+			continue
+		}
+		if folderID == nil || s.isInSubtree(b.FolderID, *folderID) {
+			count++
+		}
+	}
+	return count
+}
+
+// GetBookmarksInSubtree returns the bookmarks in folderID and every folder
+// below it, in store order, hiding the same ones as GetBookmarksInFolder.
+func (s *Store) GetBookmarksInSubtree(folderID string, includeArchived bool) []Bookmark {
+	now := time.Now()
+	var result []Bookmark
+	for _, b := range s.Bookmarks {
+		if s.isInSubtree(b.FolderID, folderID) && !b.IsSnoozed(now) && (includeArchived || !b.Archived) {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// isInSubtree reports whether folderID is rootID or one of its descendants.
+func (s *Store) isInSubtree(folderID *string, rootID string) bool {
+	seen := make(map[string]bool)
+	for folderID != nil && !seen[*folderID] {
+		if *folderID == rootID {
+			return true
+		}
+		seen[*folderID] = true
+		f := s.GetFolderByID(*folderID)
+		if f == nil {
+			return false
+		}
+		folderID = f.ParentID
+	}
+	return false
+}
+
+// TagCount is a tag with the number of bookmarks carrying it.
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// FolderStats summarizes the shape of a folder's subtree.
+// Archived bookmarks are not counted.
+type FolderStats struct {
+	DirectBookmarks int        // bookmarks directly in the folder
+	DirectFolders   int        // immediate subfolders
+	TotalBookmarks  int        // bookmarks anywhere in the subtree
+	TotalFolders    int        // folders anywhere below the folder
+	TopTags         []TagCount // most used tags in the subtree, most frequent first
+}
+
+// FolderStats computes FolderStats for a folder, keeping the topTags most
+// used tags (ties broken alphabetically).
+func (s *Store) FolderStats(folderID string, topTags int) FolderStats {
+	var stats FolderStats
+	for _, f := range s.Folders {
+		if f.ID == folderID || !s.isInSubtree(&f.ID, folderID) {
+			continue
+		}
+		stats.TotalFolders++
+		if ptrEqual(f.ParentID, &folderID) {
+			stats.DirectFolders++
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, b := range s.Bookmarks {
+		if b.Archived || !s.isInSubtree(b.FolderID, folderID) {
+			continue
+		}
+		stats.TotalBookmarks++
+		if ptrEqual(b.FolderID, &folderID) {
+			stats.DirectBookmarks++
+		}
+		for _, tag := range b.Tags {
+			counts[tag]++
+		}
+	}
+
+	for tag, n := range counts {
+		stats.TopTags = append(stats.TopTags, TagCount{Tag: tag, Count: n})
+	}
+	sort.Slice(stats.TopTags, func(i, j int) bool {
+		if stats.TopTags[i].Count != stats.TopTags[j].Count {
+			return stats.TopTags[i].Count > stats.TopTags[j].Count
+		}
+		return stats.TopTags[i].Tag < stats.TopTags[j].Tag
+	})
+	if len(stats.TopTags) > topTags {
+		stats.TopTags = stats.TopTags[:topTags]
+	}
+	return stats
+}
+
+// GetActiveBookmarks returns all bookmarks that aren't archived, even while the
+// TUI shows archived ones inline. Cull and organize work on these only.
+func (s *Store) GetActiveBookmarks() []Bookmark {
+	result := []Bookmark{}
+	for _, b := range s.Bookmarks {
+		if !b.Archived {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// ToggleArchiveBookmark flips the Archived flag of a bookmark by ID and
+// returns the new state.
+func (s *Store) ToggleArchiveBookmark(id string) (bool, error) {
+	b := s.GetBookmarkByID(id)
+	if b == nil {
+		return false, fmt.Errorf("bookmark not found: %s", id)
+	}
+	b.Archived = !b.Archived
+	return b.Archived, nil
+}
+
+// CycleBookmarkPriority steps a bookmark's priority up (none, low, medium,
+// high, none) and returns the new priority.
+func (s *Store) CycleBookmarkPriority(id string) (int, error) {
+	b := s.GetBookmarkByID(id)
+	if b == nil {
+		return 0, fmt.Errorf("bookmark not found: %s", id)
+	}
+	b.CyclePriority()
+	return b.Priority, nil
+}
+
 // GetFolderByID finds a folder by ID, returns nil if not found.
 func (s *Store) GetFolderByID(id string) *Folder {
 	for i := range s.Folders {
@@ -103,6 +260,36 @@ func (s *Store) RemoveFolderByID(id string) bool {
 	return false
 }
 
+// RemoveFolderKeepContents removes a folder but keeps what it holds: its
+// bookmarks and subfolders move up to the folder's parent (the root for a
+// top-level folder). Returns true if found and removed.
+func (s *Store) RemoveFolderKeepContents(id string) bool {
+	folder := s.GetFolderByID(id)
+	if folder == nil {
+		return false
+	}
+	parentID := folder.ParentID
+	reparent := func() *string {
+		if parentID == nil {
+			return nil
+		}
+		p := *parentID
+		return &p
+	}
+
+	for i := range s.Bookmarks {
+		if s.Bookmarks[i].FolderID != nil && *s.Bookmarks[i].FolderID == id {
+			s.Bookmarks[i].FolderID = reparent()
+		}
+	}
+	for i := range s.Folders {
+		if s.Folders[i].ParentID != nil && *s.Folders[i].ParentID == id {
+			s.Folders[i].ParentID = reparent()
+		}
+	}
+	return s.RemoveFolderByID(id)
+}
+
 // RemoveBookmarkByID removes a bookmark by ID. Returns true if found and removed.
 func (s *Store) RemoveBookmarkByID(id string) bool {
 	for i, b := range s.Bookmarks {
@@ -157,19 +344,121 @@ func (s *Store) InsertBookmarkAt(b Bookmark, index int) {
 	s.Bookmarks = append(s.Bookmarks[:globalIdx], append([]Bookmark{b}, s.Bookmarks[globalIdx:]...)...)
 }
 
+// CountItemsInFolder returns how many folders and bookmarks are nested anywhere
+// inside the given folder, not counting the folder itself.
+func (s *Store) CountItemsInFolder(folderID string) int {
+	count := len(s.GetAllBookmarksInFolder(&folderID))
+	for _, f := range s.GetFoldersInFolder(&folderID) {
+		count += 1 + s.CountItemsInFolder(f.ID)
+	}
+	return count
+}
+
+// CopyFolderContents deep-copies everything nested inside folder srcID into folder
+// dstID, giving every copied folder and bookmark a new ID. The source subtree is
+// collected before copying, so pasting a folder into itself terminates.
+// Returns the number of items copied.
+func (s *Store) CopyFolderContents(srcID, dstID string) int {
+	// Collect the subtree breadth-first so parents are copied before their children
+	var folders []Folder
+	queue := []string{srcID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, f := range s.GetFoldersInFolder(&id) {
+			if f.ID == dstID {
+				continue
+			}
+			folders = append(folders, f)
+			queue = append(queue, f.ID)
+		}
+	}
+
+	idMap := map[string]string{srcID: dstID}
+	var bookmarks []Bookmark
+	for _, id := range append([]string{srcID}, folderIDs(folders)...) {
+		bookmarks = append(bookmarks, s.GetAllBookmarksInFolder(&id)...)
+	}
+
+	for _, f := range folders {
+		parentID := idMap[*f.ParentID]
+		copied := NewFolder(NewFolderParams{Name: f.Name, ParentID: &parentID})
+		idMap[f.ID] = copied.ID
+		s.AddFolder(copied)
+	}
+	for _, b := range bookmarks {
+		folderID := idMap[*b.FolderID]
+		s.AddBookmark(NewBookmark(NewBookmarkParams{
+			Title:    b.Title,
+			URL:      b.URL,
+			FolderID: &folderID,
+			Tags:     append([]string(nil), b.Tags...),
+		}))
+	}
+
+	return len(folders) + len(bookmarks)
+}
+
+// folderIDs returns the IDs of the given folders.
+func folderIDs(folders []Folder) []string {
+	ids := make([]string, len(folders))
+	for i, f := range folders {
+		ids[i] = f.ID
+	}
+	return ids
+}
+
 // HasBookmarkURL checks if a bookmark with the given URL already exists.
 func (s *Store) HasBookmarkURL(url string) bool {
-	for _, b := range s.Bookmarks {
-		if b.URL == url {
-			return true
+	return s.bookmarkByURL(url) != nil
+}
+
+// bookmarkByURL returns the first bookmark with the given URL, or nil.
+func (s *Store) bookmarkByURL(url string) *Bookmark {
+	for i := range s.Bookmarks {
+		if s.Bookmarks[i].URL == url {
+			return &s.Bookmarks[i]
+		}
+	}
+	return nil
+}
+
+// NestUnder makes parentID the parent of every top-level folder and bookmark
+// of an import, so the import keeps its structure below an existing folder
+// instead of merging into the root.
+func NestUnder(folders []Folder, bookmarks []Bookmark, parentID string) {
+	for i := range folders {
+		if folders[i].ParentID == nil {
+			id := parentID
+			folders[i].ParentID = &id
+		}
+	}
+	for i := range bookmarks {
+		if bookmarks[i].FolderID == nil {
+			id := parentID
+			bookmarks[i].FolderID = &id
 		}
 	}
-	return false
 }
 
 // ImportMerge imports folders and bookmarks, skipping duplicate URLs.
 // Returns the count of bookmarks added and skipped.
 func (s *Store) ImportMerge(folders []Folder, bookmarks []Bookmark) (added, skipped int) {
+	report := s.ImportMergeReport(folders, bookmarks)
+	return len(report.Added), len(report.Duplicates)
+}
+
+// ImportMergeReport is ImportMerge, reporting each added bookmark and each
+// duplicate together with the existing bookmark it matched.
+func (s *Store) ImportMergeReport(folders []Folder, bookmarks []Bookmark) ImportReport {
+	return s.ImportMergeResolve(folders, bookmarks, nil)
+}
+
+// ImportMergeResolve is ImportMergeReport, asking resolve what to do with each
+// duplicate instead of always skipping it. A nil resolve skips them all.
+func (s *Store) ImportMergeResolve(folders []Folder, bookmarks []Bookmark, resolve ImportResolver) ImportReport {
+	report := NewImportReport()
+
 	// Build a map from imported folder IDs to actual IDs (may be remapped)
 	folderIDMap := make(map[string]string)
 
@@ -201,13 +490,8 @@ func (s *Store) ImportMerge(folders []Folder, bookmarks []Bookmark) (added, skip
 		}
 	}
 
-	// Process bookmarks - skip duplicates by URL
+	// Process bookmarks - duplicates by URL go to resolve
 	for _, b := range bookmarks {
-		if s.HasBookmarkURL(b.URL) {
-			skipped++
-			continue
-		}
-
 		// Remap folder ID if it was imported
 		var actualFolderID *string
 		if b.FolderID != nil {
@@ -218,6 +502,13 @@ func (s *Store) ImportMerge(folders []Folder, bookmarks []Bookmark) (added, skip
 			}
 		}
 
+		if existing := s.bookmarkByURL(b.URL); existing != nil {
+			b.FolderID = actualFolderID
+			if !s.resolveDuplicate(existing, b, resolve, &report) {
+				continue
+			}
+		}
+
 		// Create new bookmark with remapped folder ID
 		newBookmark := Bookmark{
 			ID:        GenerateUUID(),
@@ -229,10 +520,140 @@ func (s *Store) ImportMerge(folders []Folder, bookmarks []Bookmark) (added, skip
 			VisitedAt: b.VisitedAt,
 		}
 		s.Bookmarks = append(s.Bookmarks, newBookmark)
-		added++
+		report.Added = append(report.Added, s.importEntry(newBookmark))
+	}
+
+	return report
+}
+
+// ImportStore merges a full-fidelity store (e.g. bm's own JSON export) into s.
+// Unlike ImportMerge it keeps IDs, timestamps, visit counts and pin, snooze,
+// archive and lock state. Folders whose ID already exists, or that match an
+// existing folder by name and parent, are reused; bookmarks whose ID or URL
+// already exists are skipped. Imported pins are appended after existing pins
+// in their original order and dropped once MaxPinnedItems is reached.
+func (s *Store) ImportStore(src *Store) (added, skipped int) {
+	report := s.ImportStoreReport(src)
+	return len(report.Added), len(report.Duplicates)
+}
+
+// ImportStoreReport is ImportStore, reporting each added bookmark and each
+// duplicate together with the existing bookmark it matched.
+func (s *Store) ImportStoreReport(src *Store) ImportReport {
+	return s.ImportStoreResolve(src, nil)
+}
+
+// ImportStoreResolve is ImportStoreReport, asking resolve what to do with each
+// duplicate instead of always skipping it. A nil resolve skips them all.
+func (s *Store) ImportStoreResolve(src *Store, resolve ImportResolver) ImportReport {
+	report := NewImportReport()
+	folderIDMap := make(map[string]string)
+	remap := func(id *string) *string {
+		if id == nil {
+			return nil
+		}
+		if remapped, ok := folderIDMap[*id]; ok {
+			return &remapped
+		}
+		return id
+	}
+
+	pinBase := s.nextPinOrder() - 1
+	pinCount := s.CountPinnedItems()
+	var pinnedFolders, pinnedBookmarks []string
+
+	// Process parents before children so reused parents are remapped first
+	for _, f := range foldersParentsFirst(src.Folders) {
+		parentID := remap(f.ParentID)
+		if existing := s.GetFolderByID(f.ID); existing != nil {
+			folderIDMap[f.ID] = existing.ID
+			continue
+		}
+		if existing := s.findFolderByNameAndParent(f.Name, parentID); existing != nil {
+			folderIDMap[f.ID] = existing.ID
+			continue
+		}
+		f.ParentID = parentID
+		s.Folders = append(s.Folders, f)
+		if f.Pinned {
+			pinnedFolders = append(pinnedFolders, f.ID)
+		}
+	}
+
+	for _, b := range src.Bookmarks {
+		existing := s.GetBookmarkByID(b.ID)
+		if existing == nil {
+			existing = s.bookmarkByURL(b.URL)
+		}
+		b.FolderID = remap(b.FolderID)
+		if existing != nil {
+			if !s.resolveDuplicate(existing, b, resolve, &report) {
+				continue
+			}
+			// Keeping both needs a fresh ID if the import reused this one
+			if s.GetBookmarkByID(b.ID) != nil {
+				b.ID = GenerateUUID()
+			}
+		}
+		s.Bookmarks = append(s.Bookmarks, b)
+		if b.Pinned {
+			pinnedBookmarks = append(pinnedBookmarks, b.ID)
+		}
+		report.Added = append(report.Added, s.importEntry(b))
+	}
+
+	// Renumber imported pins after the existing ones, keeping their relative order
+	type pinRef struct {
+		order int
+		pin   func(order int)
 	}
+	var pins []pinRef
+	for _, id := range pinnedFolders {
+		f := s.GetFolderByID(id)
+		pins = append(pins, pinRef{f.PinOrder, func(order int) {
+			f.Pinned, f.PinOrder = order > 0, order
+		}})
+	}
+	for _, id := range pinnedBookmarks {
+		b := s.GetBookmarkByID(id)
+		pins = append(pins, pinRef{b.PinOrder, func(order int) {
+			b.Pinned, b.PinOrder = order > 0, order
+		}})
+	}
+	sort.SliceStable(pins, func(i, j int) bool { return pins[i].order < pins[j].order })
+	for i, p := range pins {
+		if pinCount+i+1 > MaxPinnedItems {
+			p.pin(0)
+			continue
+		}
+		p.pin(pinBase + i + 1)
+	}
+
+	return report
+}
 
-	return added, skipped
+// foldersParentsFirst returns folders ordered by depth, keeping the original
+// order among folders at the same depth.
+func foldersParentsFirst(folders []Folder) []Folder {
+	byID := make(map[string]Folder, len(folders))
+	for _, f := range folders {
+		byID[f.ID] = f
+	}
+	depth := func(f Folder) int {
+		d := 0
+		for f.ParentID != nil && d <= len(folders) {
+			parent, ok := byID[*f.ParentID]
+			if !ok {
+				break
+			}
+			f = parent
+			d++
+		}
+		return d
+	}
+	sorted := append([]Folder(nil), folders...)
+	sort.SliceStable(sorted, func(i, j int) bool { return depth(sorted[i]) < depth(sorted[j]) })
+	return sorted
 }
 
 // findFolderByNameAndParent finds a folder by name and parent ID.
@@ -331,6 +752,392 @@ func (s *Store) TogglePinBookmark(id string) error {
 	return fmt.Errorf("bookmark not found: %s", id)
 }
 
+// BookmarksWithTag returns non-archived bookmarks carrying tag exactly
+// (case-insensitive), in store order. "rust" does not match "rustlang".
+func (s *Store) BookmarksWithTag(tag string) []Bookmark {
+	tag = strings.TrimSpace(tag)
+	var result []Bookmark
+	for _, b := range s.Bookmarks {
+		if !b.Archived && hasTag(b.Tags, tag) {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// AddTagByDomain adds tag to every bookmark whose host matches domain (or a subdomain).
+// Bookmarks that already have the tag are left alone. Returns the number changed.
+func (s *Store) AddTagByDomain(domain, tag string) int {
+	changed := 0
+	now := time.Now()
+	for i := range s.Bookmarks {
+		b := &s.Bookmarks[i]
+		if !URLMatchesDomain(b.URL, domain) || hasTag(b.Tags, tag) {
+			continue
+		}
+		b.Tags = append(b.Tags, tag)
+		b.NormalizeTags()
+		b.MarkModified(now)
+		changed++
+	}
+	return changed
+}
+
+// RemoveTagByDomain removes tag from every bookmark whose host matches domain
+// (or a subdomain). Returns the number changed.
+func (s *Store) RemoveTagByDomain(domain, tag string) int {
+	changed := 0
+	now := time.Now()
+	for i := range s.Bookmarks {
+		b := &s.Bookmarks[i]
+		if !URLMatchesDomain(b.URL, domain) || !hasTag(b.Tags, tag) {
+			continue
+		}
+		tags := []string{}
+		for _, t := range b.Tags {
+			if !strings.EqualFold(t, tag) {
+				tags = append(tags, t)
+			}
+		}
+		b.Tags = tags
+		b.MarkModified(now)
+		changed++
+	}
+	return changed
+}
+
+// AddTagsToBookmark adds the tags a bookmark doesn't already have.
+// Returns whether the bookmark changed, or an error if it is not found.
+func (s *Store) AddTagsToBookmark(id string, tags []string) (bool, error) {
+	b := s.GetBookmarkByID(id)
+	if b == nil {
+		return false, fmt.Errorf("bookmark not found: %s", id)
+	}
+	changed := false
+	for _, tag := range tags {
+		if !hasTag(b.Tags, tag) {
+			b.Tags = append(b.Tags, tag)
+			changed = true
+		}
+	}
+	if changed {
+		b.NormalizeTags()
+		b.MarkModified(time.Now())
+	}
+	return changed, nil
+}
+
+// MergeTags replaces tag from with into on every bookmark, archived ones
+// included. Bookmarks that already carry into just lose from, so no bookmark
+// ends up with the tag twice. Both tags match case-insensitively; into is
+// written as given. Returns the number of bookmarks changed.
+func (s *Store) MergeTags(from, into string) int {
+	from, into = strings.TrimSpace(from), strings.TrimSpace(into)
+	if from == "" || into == "" || strings.EqualFold(from, into) {
+		return 0
+	}
+
+	changed := 0
+	now := time.Now()
+	for i := range s.Bookmarks {
+		b := &s.Bookmarks[i]
+		if !hasTag(b.Tags, from) {
+			continue
+		}
+		hadInto := hasTag(b.Tags, into)
+		tags := []string{}
+		for _, t := range b.Tags {
+			switch {
+			case !strings.EqualFold(t, from):
+				tags = append(tags, t)
+			case !hadInto:
+				tags = append(tags, into)
+				hadInto = true
+			}
+		}
+		b.Tags = tags
+		b.MarkModified(now)
+		changed++
+	}
+	return changed
+}
+
+// TagCounts returns every tag with the number of bookmarks (archived ones
+// included) carrying it, sorted alphabetically. Tags that differ only in case
+// count as one, as BookmarksWithTag matches them, listed under the first
+// spelling seen.
+func (s *Store) TagCounts() []TagCount {
+	counts := make(map[string]int)
+	spelling := make(map[string]string)
+	for _, b := range s.Bookmarks {
+		seen := make(map[string]bool)
+		for _, tag := range b.Tags {
+			key := strings.ToLower(tag)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if _, ok := spelling[key]; !ok {
+				spelling[key] = tag
+			}
+			counts[key]++
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for key, n := range counts {
+		result = append(result, TagCount{Tag: spelling[key], Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Tag) < strings.ToLower(result[j].Tag)
+	})
+	return result
+}
+
+// hasTag reports whether tags contains tag (case-insensitive).
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// SnoozeBookmark hides a bookmark until the given time.
+// Returns an error if the bookmark is not found.
+func (s *Store) SnoozeBookmark(id string, until time.Time) error {
+	for i := range s.Bookmarks {
+		if s.Bookmarks[i].ID == id {
+			s.Bookmarks[i].SnoozeUntil = &until
+			return nil
+		}
+	}
+	return fmt.Errorf("bookmark not found: %s", id)
+}
+
+// UnsnoozeBookmark makes a snoozed bookmark visible again.
+// Returns an error if the bookmark is not found.
+func (s *Store) UnsnoozeBookmark(id string) error {
+	for i := range s.Bookmarks {
+		if s.Bookmarks[i].ID == id {
+			s.Bookmarks[i].SnoozeUntil = nil
+			return nil
+		}
+	}
+	return fmt.Errorf("bookmark not found: %s", id)
+}
+
+// GetSnoozedBookmarks returns bookmarks that are currently snoozed,
+// sorted by wake-up time (soonest first).
+func (s *Store) GetSnoozedBookmarks() []Bookmark {
+	now := time.Now()
+	var result []Bookmark
+	for _, b := range s.Bookmarks {
+		if b.IsSnoozed(now) {
+			result = append(result, b)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SnoozeUntil.Before(*result[j].SnoozeUntil)
+	})
+	return result
+}
+
+// UntitledBookmarks returns non-archived bookmarks without a real title,
+// in store order.
+func (s *Store) UntitledBookmarks() []Bookmark {
+	var result []Bookmark
+	for _, b := range s.Bookmarks {
+		if b.IsUntitled() && !b.Archived {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// RecentlyVisited returns the n most recently opened non-archived bookmarks,
+// newest visit first. Never-visited bookmarks are left out; n <= 0 returns all.
+func (s *Store) RecentlyVisited(n int) []Bookmark {
+	var result []Bookmark
+	for _, b := range s.Bookmarks {
+		if b.VisitedAt != nil && !b.Archived {
+			result = append(result, b)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].VisitedAt.After(*result[j].VisitedAt)
+	})
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// SetReminder schedules a reminder for a bookmark. A nil at clears it.
+// Returns an error if the bookmark is not found.
+func (s *Store) SetReminder(id string, at *time.Time) error {
+	b := s.GetBookmarkByID(id)
+	if b == nil {
+		return fmt.Errorf("bookmark not found: %s", id)
+	}
+	b.RemindAt = at
+	return nil
+}
+
+// GetDueReminders returns non-archived bookmarks whose reminder is due,
+// sorted by reminder time (oldest first).
+func (s *Store) GetDueReminders(now time.Time) []Bookmark {
+	var result []Bookmark
+	for _, b := range s.Bookmarks {
+		if b.IsReminderDue(now) && !b.Archived {
+			result = append(result, b)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].RemindAt.Before(*result[j].RemindAt)
+	})
+	return result
+}
+
+// ToggleLockFolder flips the Locked flag of a folder by ID and returns the new state.
+func (s *Store) ToggleLockFolder(id string) (bool, error) {
+	f := s.GetFolderByID(id)
+	if f == nil {
+		return false, fmt.Errorf("folder not found: %s", id)
+	}
+	f.Locked = !f.Locked
+	return f.Locked, nil
+}
+
+// CycleFolderViewMode moves a folder to the next view mode (see NextViewMode)
+// and returns it.
+func (s *Store) CycleFolderViewMode(id string) (string, error) {
+	f := s.GetFolderByID(id)
+	if f == nil {
+		return "", fmt.Errorf("folder not found: %s", id)
+	}
+	f.ViewMode = NextViewMode(f.ViewMode)
+	return f.ViewMode, nil
+}
+
+// IsFolderLocked reports whether the folder or any of its ancestors is locked.
+// Root (nil) is never locked.
+func (s *Store) IsFolderLocked(folderID *string) bool {
+	seen := make(map[string]bool)
+	for folderID != nil && !seen[*folderID] {
+		seen[*folderID] = true
+		f := s.GetFolderByID(*folderID)
+		if f == nil {
+			return false
+		}
+		if f.Locked {
+			return true
+		}
+		folderID = f.ParentID
+	}
+	return false
+}
+
+// FolderAncestry returns the folder IDs from the root-level folder down to
+// folderID (inclusive). Root (nil) has no ancestry.
+func (s *Store) FolderAncestry(folderID *string) []string {
+	var path []string
+	seen := make(map[string]bool)
+	for folderID != nil && !seen[*folderID] {
+		seen[*folderID] = true
+		f := s.GetFolderByID(*folderID)
+		if f == nil {
+			break
+		}
+		path = append([]string{f.ID}, path...) // prepend
+		folderID = f.ParentID
+	}
+	return path
+}
+
+// FolderDepth returns how deeply a folder is nested: root-level folders are
+// at depth 1 and root (nil) is 0.
+func (s *Store) FolderDepth(folderID *string) int {
+	return len(s.FolderAncestry(folderID))
+}
+
+// SubtreeHeight returns the number of folder levels in a folder's subtree,
+// counting the folder itself (a folder without subfolders has height 1).
+func (s *Store) SubtreeHeight(folderID string) int {
+	height := 0
+	for _, child := range s.GetFoldersInFolder(&folderID) {
+		if h := s.SubtreeHeight(child.ID); h > height {
+			height = h
+		}
+	}
+	return height + 1
+}
+
+// FlattenDeepFolders collapses every branch nested deeper than maxDepth into
+// its ancestor at maxDepth, merging the over-deep folders (and their
+// bookmarks) into it. Returns the number of folders removed.
+func (s *Store) FlattenDeepFolders(maxDepth int) int {
+	if maxDepth <= 0 {
+		return 0
+	}
+	removed := 0
+	for {
+		var targetID string
+		for _, f := range s.Folders {
+			if ancestry := s.FolderAncestry(&f.ID); len(ancestry) > maxDepth {
+				targetID = ancestry[maxDepth-1]
+				break
+			}
+		}
+		if targetID == "" {
+			return removed
+		}
+		var childIDs []string
+		for _, child := range s.GetFoldersInFolder(&targetID) {
+			childIDs = append(childIDs, child.ID)
+		}
+		removed += s.MergeFolders(targetID, childIDs...)
+	}
+}
+
+// EmptyFolders returns folders whose whole subtree holds no bookmarks, in
+// store order. Pinned and locked folders are kept, and so are their
+// ancestors, so pruning the result never orphans a protected folder.
+func (s *Store) EmptyFolders() []Folder {
+	keep := make(map[string]bool)
+	for _, b := range s.Bookmarks {
+		for _, id := range s.FolderAncestry(b.FolderID) {
+			keep[id] = true
+		}
+	}
+	for _, f := range s.Folders {
+		if f.Pinned || s.IsFolderLocked(&f.ID) {
+			for _, id := range s.FolderAncestry(&f.ID) {
+				keep[id] = true
+			}
+		}
+	}
+
+	var result []Folder
+	for _, f := range s.Folders {
+		if !keep[f.ID] {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// PruneEmptyFolders removes every folder reported by EmptyFolders and
+// returns how many were removed.
+func (s *Store) PruneEmptyFolders() int {
+	empty := s.EmptyFolders()
+	for _, f := range empty {
+		s.RemoveFolderByID(f.ID)
+	}
+	return len(empty)
+}
+
 // TogglePinFolder toggles the Pinned field of a folder by ID.
 // Returns ErrMaxPinnedItems if already at limit when pinning.
 // Returns an error if the folder is not found.
@@ -395,6 +1202,25 @@ func (s *Store) SwapPinOrders(order1, order2 int) {
 	}
 }
 
+// SwapOrder swaps two folders or two bookmarks in the store's order, which is
+// their manual order. It reports false, changing nothing, unless both IDs
+// exist and are of the same kind.
+func (s *Store) SwapOrder(id1, id2 string) bool {
+	f1 := slices.IndexFunc(s.Folders, func(f Folder) bool { return f.ID == id1 })
+	f2 := slices.IndexFunc(s.Folders, func(f Folder) bool { return f.ID == id2 })
+	if f1 >= 0 && f2 >= 0 {
+		s.Folders[f1], s.Folders[f2] = s.Folders[f2], s.Folders[f1]
+		return true
+	}
+	b1 := slices.IndexFunc(s.Bookmarks, func(b Bookmark) bool { return b.ID == id1 })
+	b2 := slices.IndexFunc(s.Bookmarks, func(b Bookmark) bool { return b.ID == id2 })
+	if b1 >= 0 && b2 >= 0 {
+		s.Bookmarks[b1], s.Bookmarks[b2] = s.Bookmarks[b2], s.Bookmarks[b1]
+		return true
+	}
+	return false
+}
+
 // GetFolderByPath finds a folder by its full path (e.g., "/Dev/React").
 // Returns nil if not found.
 func (s *Store) GetFolderByPath(path string) *Folder {
@@ -420,6 +1246,36 @@ func (s *Store) GetFolderByPath(path string) *Folder {
 	return nil
 }
 
+// CheckFolderPath reports why GetOrCreateFolderByPath should not be used to
+// file something under path: the deepest existing folder on it is locked, or
+// the folders it would create nest deeper than maxDepth (0 = unlimited).
+func (s *Store) CheckFolderPath(path string, maxDepth int) error {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return fmt.Errorf("empty folder path")
+	}
+
+	parts := strings.Split(path, "/")
+	var parentID *string
+	existing := 0
+	for _, name := range parts {
+		folder := s.findFolderByNameAndParent(name, parentID)
+		if folder == nil {
+			break
+		}
+		parentID = &folder.ID
+		existing++
+	}
+
+	if s.IsFolderLocked(parentID) {
+		return fmt.Errorf("folder %s is locked", s.GetFolderPath(parentID))
+	}
+	if existing < len(parts) && maxDepth > 0 && len(parts) > maxDepth {
+		return fmt.Errorf("path is %d folders deep, max folder depth is %d", len(parts), maxDepth)
+	}
+	return nil
+}
+
 // GetOrCreateFolderByPath finds or creates a folder by its full path.
 // Creates any missing intermediate folders.
 // Returns the folder and whether any folders were created.
@@ -457,6 +1313,153 @@ func (s *Store) GetOrCreateFolderByPath(path string) (*Folder, bool) {
 	return nil, false
 }
 
+// FindSiblingNameCollisions returns groups of folders that share a name and a
+// parent, in store order. Path lookups such as GetOrCreateFolderByPath only ever
+// see the first folder of each group.
+func (s *Store) FindSiblingNameCollisions() [][]*Folder {
+	type key struct {
+		parent string
+		name   string
+	}
+	groups := make(map[key][]*Folder)
+	var order []key
+	for i := range s.Folders {
+		f := &s.Folders[i]
+		k := key{name: f.Name}
+		if f.ParentID != nil {
+			k.parent = *f.ParentID
+		}
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], f)
+	}
+
+	var collisions [][]*Folder
+	for _, k := range order {
+		if len(groups[k]) > 1 {
+			collisions = append(collisions, groups[k])
+		}
+	}
+	return collisions
+}
+
+// MergeFolders moves the subfolders and bookmarks of each source folder into
+// target and removes the sources. Subfolders that end up sharing a name with
+// one already in target are merged recursively. A pinned source passes its
+// pin to an unpinned target. Returns the number of folders removed.
+func (s *Store) MergeFolders(targetID string, sourceIDs ...string) int {
+	if s.GetFolderByID(targetID) == nil {
+		return 0
+	}
+
+	removed := 0
+	for _, srcID := range sourceIDs {
+		src := s.GetFolderByID(srcID)
+		if src == nil || srcID == targetID {
+			continue
+		}
+
+		for i := range s.Bookmarks {
+			if s.Bookmarks[i].FolderID != nil && *s.Bookmarks[i].FolderID == srcID {
+				id := targetID
+				s.Bookmarks[i].FolderID = &id
+			}
+		}
+
+		// Reparent subfolders, merging into an existing same-named child of target
+		for _, child := range s.GetFoldersInFolder(&srcID) {
+			id := targetID
+			if existing := s.findFolderByNameAndParent(child.Name, &id); existing != nil {
+				removed += s.MergeFolders(existing.ID, child.ID)
+				continue
+			}
+			s.GetFolderByID(child.ID).ParentID = &id
+		}
+
+		// Pointers may have moved after nested merges
+		src = s.GetFolderByID(srcID)
+		if src.Pinned {
+			if target := s.GetFolderByID(targetID); !target.Pinned {
+				target.Pinned = true
+				target.PinOrder = src.PinOrder
+			} else {
+				s.recompactPinOrders(src.PinOrder)
+			}
+		}
+		s.RemoveFolderByID(srcID)
+		removed++
+	}
+	return removed
+}
+
+// MergeSiblingNameCollisions merges every group of same-named siblings into
+// the first folder of the group. Returns the number of folders removed.
+func (s *Store) MergeSiblingNameCollisions() int {
+	removed := 0
+	for {
+		collisions := s.FindSiblingNameCollisions()
+		if len(collisions) == 0 {
+			return removed
+		}
+		// Merging can shift slice positions, so resolve IDs before mutating
+		group := collisions[0]
+		ids := make([]string, len(group)-1)
+		for i, f := range group[1:] {
+			ids[i] = f.ID
+		}
+		removed += s.MergeFolders(group[0].ID, ids...)
+	}
+}
+
+// NormalizeFolderPath cleans an untrusted folder path (e.g. from an AI suggestion)
+// into canonical "/A/B" form. Whitespace around segments and empty segments from
+// leading, trailing or repeated slashes are dropped. Returns false for paths that
+// are empty or contain relative segments, control characters or other characters
+// that don't belong in a folder name.
+func NormalizeFolderPath(path string) (string, bool) {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if part == "." || part == ".." {
+			return "", false
+		}
+		for _, r := range part {
+			if unicode.IsControl(r) || strings.ContainsRune(`\<>|?*"`, r) {
+				return "", false
+			}
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return "/" + strings.Join(parts, "/"), true
+}
+
+// SanitizeFolderName turns free text such as a bookmark title into a usable
+// folder name: slashes become dashes, characters rejected by NormalizeFolderPath
+// are dropped and whitespace is collapsed.
+func SanitizeFolderName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r == '/':
+			return '-'
+		case unicode.IsControl(r) || strings.ContainsRune(`\<>|?*"`, r):
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "." || name == ".." {
+		return ""
+	}
+	return name
+}
+
 // GetFolderPath returns the full path string for a folder (e.g., "/Dev/React").
 func (s *Store) GetFolderPath(folderID *string) string {
 	if folderID == nil {
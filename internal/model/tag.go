@@ -0,0 +1,41 @@
+package model
+
+import "strings"
+
+// ParseTags splits a comma-separated tag string as typed into a tags field,
+// trimming blanks and dropping empty entries.
+func ParseTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// NormalizeTags trims whitespace around each tag, drops empty tags and, with
+// lowercase set, lowercases them. Tags that only differ in case collapse into
+// the first occurrence, so "React, react" yields a single tag.
+func NormalizeTags(tags []string, lowercase bool) []string {
+	result := []string{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if lowercase {
+			tag = strings.ToLower(tag)
+		}
+		if tag == "" || hasTag(result, tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// NormalizeTags cleans the bookmark's own tags: it trims them, drops empty
+// ones and keeps the first of tags that only differ in case. Lowercasing is
+// left to where tags are entered (see Config.LowercaseTags).
+func (b *Bookmark) NormalizeTags() {
+	b.Tags = NormalizeTags(b.Tags, false)
+}
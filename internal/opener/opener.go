@@ -0,0 +1,50 @@
+// Package opener opens URLs in the default browser.
+package opener
+
+import (
+	"os/exec"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// Stagger spaces out opening several URLs so the browser keeps up.
+const Stagger = 300 * time.Millisecond
+
+// open is swapped out in tests so nothing is launched.
+var open = Open
+
+// Open starts the default browser on url without waiting for it.
+func Open(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "linux":
+		cmd = exec.Command("xdg-open", url)
+		// Detach process so it survives parent exit
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	}
+	if cmd == nil {
+		return nil
+	}
+	return cmd.Start()
+}
+
+// OpenAll opens urls one after another, Stagger apart, and returns the first
+// error. A URL that fails to open doesn't stop the rest. All multi-open paths
+// go through here; callers apply the OpenAllThreshold guard.
+func OpenAll(urls []string) error {
+	var first error
+	for i, url := range urls {
+		if i > 0 {
+			time.Sleep(Stagger)
+		}
+		if err := open(url); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
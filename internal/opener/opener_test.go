@@ -0,0 +1,36 @@
+package opener
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestOpenAll_OpensInOrderStaggered(t *testing.T) {
+	var opened []string
+	var times []time.Time
+	open = func(url string) error {
+		opened = append(opened, url)
+		times = append(times, time.Now())
+		if url == "b" {
+			return errors.New("no browser")
+		}
+		return nil
+	}
+	defer func() { open = Open }()
+
+	err := OpenAll([]string{"a", "b", "c"})
+
+	if want := []string{"a", "b", "c"}; !slices.Equal(opened, want) {
+		t.Errorf("expected %v opened in order, got %v", want, opened)
+	}
+	if err == nil || err.Error() != "no browser" {
+		t.Errorf("expected the failed open's error, got %v", err)
+	}
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < Stagger {
+			t.Errorf("expected opens %v apart, got %v", Stagger, gap)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// ResolveAlias looks up query as an exact alias name.
+// The alias target may be a bookmark ID or a URL. Returns the bookmark (nil if
+// the target is a URL that isn't bookmarked), the URL to open, and whether the
+// alias resolved. Aliases pointing at deleted bookmarks don't resolve.
+func ResolveAlias(store *model.Store, aliases map[string]string, query string) (*model.Bookmark, string, bool) {
+	target, ok := aliases[query]
+	if !ok {
+		return nil, "", false
+	}
+
+	if b := store.GetBookmarkByID(target); b != nil {
+		return b, b.URL, true
+	}
+
+	if strings.Contains(target, "://") {
+		for i := range store.Bookmarks {
+			if store.Bookmarks[i].URL == target {
+				return &store.Bookmarks[i], target, true
+			}
+		}
+		return nil, target, true
+	}
+
+	return nil, "", false
+}
@@ -0,0 +1,91 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+func aliasTestStore() *model.Store {
+	store := model.NewStore()
+	store.AddBookmark(model.Bookmark{
+		ID:        "b1",
+		Title:     "gh cli manual",
+		URL:       "https://cli.github.com/manual",
+		CreatedAt: time.Now(),
+	})
+	store.AddBookmark(model.Bookmark{
+		ID:        "b2",
+		Title:     "GitHub",
+		URL:       "https://github.com",
+		CreatedAt: time.Now(),
+	})
+	return store
+}
+
+func TestResolveAlias_TakesPrecedenceOverFuzzy(t *testing.T) {
+	store := aliasTestStore()
+	aliases := map[string]string{"gh": "b1"}
+
+	// Fuzzy search alone ranks GitHub first for "gh"
+	results := FuzzySearchBookmarks(store, "gh")
+	if len(results) == 0 || results[0].Bookmark.ID != "b2" {
+		t.Fatalf("expected fuzzy search to prefer b2, got %+v", results)
+	}
+
+	bookmark, url, ok := ResolveAlias(store, aliases, "gh")
+	if !ok {
+		t.Fatal("expected alias to resolve")
+	}
+	if bookmark == nil || bookmark.ID != "b1" {
+		t.Errorf("expected alias to resolve to b1, got %+v", bookmark)
+	}
+	if url != "https://cli.github.com/manual" {
+		t.Errorf("expected cli manual URL, got %q", url)
+	}
+}
+
+func TestResolveAlias_Cases(t *testing.T) {
+	store := aliasTestStore()
+	aliases := map[string]string{
+		"gh":    "b2",
+		"cli":   "https://cli.github.com/manual",
+		"news":  "https://news.ycombinator.com",
+		"stale": "deleted-id",
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantOK  bool
+		wantID  string // "" = no bookmark
+		wantURL string
+	}{
+		{"bookmark ID", "gh", true, "b2", "https://github.com"},
+		{"URL of bookmark", "cli", true, "b1", "https://cli.github.com/manual"},
+		{"URL not bookmarked", "news", true, "", "https://news.ycombinator.com"},
+		{"dangling ID", "stale", false, "", ""},
+		{"not an alias", "git", false, "", ""},
+		{"exact match only", "GH", false, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookmark, url, ok := ResolveAlias(store, aliases, tt.query)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			gotID := ""
+			if bookmark != nil {
+				gotID = bookmark.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("bookmark = %q, want %q", gotID, tt.wantID)
+			}
+			if url != tt.wantURL {
+				t.Errorf("url = %q, want %q", url, tt.wantURL)
+			}
+		})
+	}
+}
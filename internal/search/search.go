@@ -12,18 +12,19 @@ type SearchResult struct {
 	Score          int
 }
 
-// bookmarkTitles implements fuzzy.Source for bookmark slice.
-type bookmarkTitles []*model.Bookmark
+// bookmarkTexts implements fuzzy.Source for bookmark slice.
+type bookmarkTexts []*model.Bookmark
 
-func (bt bookmarkTitles) String(i int) string {
-	return bt[i].Title
+func (bt bookmarkTexts) String(i int) string {
+	return bt[i].SearchText()
 }
 
-func (bt bookmarkTitles) Len() int {
+func (bt bookmarkTexts) Len() int {
 	return len(bt)
 }
 
-// FuzzySearchBookmarks searches all bookmarks by title using fuzzy matching.
+// FuzzySearchBookmarks searches all bookmarks by title, URL and tags using
+// fuzzy matching (see model.Bookmark.SearchText).
 // Returns results sorted by match score (best first).
 func FuzzySearchBookmarks(store *model.Store, query string) []SearchResult {
 	if query == "" {
@@ -31,7 +32,7 @@ func FuzzySearchBookmarks(store *model.Store, query string) []SearchResult {
 	}
 
 	// Build slice of bookmark pointers
-	bookmarks := make(bookmarkTitles, len(store.Bookmarks))
+	bookmarks := make(bookmarkTexts, len(store.Bookmarks))
 	for i := range store.Bookmarks {
 		bookmarks[i] = &store.Bookmarks[i]
 	}
@@ -197,3 +197,37 @@ func TestFuzzySearchBookmarks_SpecialCharacters(t *testing.T) {
 		t.Error("expected to find Node.js bookmark")
 	}
 }
+
+func TestFuzzySearchBookmarks_MatchesURLAndTags(t *testing.T) {
+	store := model.NewStore()
+	store.AddBookmark(model.Bookmark{
+		ID:        "b1",
+		Title:     "Team handbook",
+		URL:       "https://notion.so/handbook",
+		Tags:      []string{"onboarding"},
+		CreatedAt: time.Now(),
+	})
+	store.AddBookmark(model.Bookmark{
+		ID:        "b2",
+		Title:     "Recipes",
+		URL:       "https://cooking.example",
+		Tags:      []string{"food"},
+		CreatedAt: time.Now(),
+	})
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"url only", "notion.so"},
+		{"tag only", "onboarding"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := FuzzySearchBookmarks(store, tt.query)
+			if len(results) != 1 || results[0].Bookmark.ID != "b1" {
+				t.Fatalf("expected only b1 to match %q, got %+v", tt.query, results)
+			}
+		})
+	}
+}
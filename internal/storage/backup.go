@@ -0,0 +1,235 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// backupTimeFormat is the timestamp embedded in backup file names.
+const backupTimeFormat = "20060102-150405"
+
+// Backup is a snapshot of the database in the backups directory.
+type Backup struct {
+	Path string
+	Time time.Time // from the file name, or the modification time for foreign names
+	Size int64
+}
+
+// Name returns the backup's file name.
+func (b Backup) Name() string {
+	return filepath.Base(b.Path)
+}
+
+// BackupStorage is implemented by backends that can snapshot and restore
+// their database file.
+type BackupStorage interface {
+	Path() string
+	Backup(dir string, now time.Time) (string, error)
+	Restore(backupPath string) error
+}
+
+// BackupDir returns the backups directory next to the database at dbPath,
+// e.g. ~/.config/bm/backups.
+func BackupDir(dbPath string) string {
+	return filepath.Join(filepath.Dir(dbPath), "backups")
+}
+
+// ListBackups returns the *.db files in dir, newest first.
+// A missing directory means there are no backups yet.
+func ListBackups(dir string) ([]Backup, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".db" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, Backup{
+			Path: filepath.Join(dir, entry.Name()),
+			Time: backupTime(entry.Name(), info.ModTime()),
+			Size: info.Size(),
+		})
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups, nil
+}
+
+// backupTime parses the timestamp from a name written by Backup
+// (bookmarks-20060102-150405.db), falling back to modTime.
+func backupTime(name string, modTime time.Time) time.Time {
+	stem := strings.TrimSuffix(name, ".db")
+	if len(stem) < len(backupTimeFormat) {
+		return modTime
+	}
+	t, err := time.ParseInLocation(backupTimeFormat, stem[len(stem)-len(backupTimeFormat):], time.Local)
+	if err != nil {
+		return modTime
+	}
+	return t
+}
+
+// SelectBackup picks a backup by its 1-based position in backups or by file name.
+func SelectBackup(backups []Backup, choice string) (Backup, error) {
+	choice = strings.TrimSpace(choice)
+	if n, err := strconv.Atoi(choice); err == nil {
+		if n < 1 || n > len(backups) {
+			return Backup{}, fmt.Errorf("no backup #%d (choose 1-%d)", n, len(backups))
+		}
+		return backups[n-1], nil
+	}
+	for _, b := range backups {
+		if b.Name() == choice || b.Name() == choice+".db" {
+			return b, nil
+		}
+	}
+	return Backup{}, fmt.Errorf("no backup named %q", choice)
+}
+
+// Backup writes a consistent copy of the database to dir as
+// bookmarks-<timestamp>.db and returns its path.
+func (s *SQLiteStorage) Backup(dir string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "bookmarks-"+now.Format(backupTimeFormat)+".db")
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("backup %s already exists", path)
+	}
+	if _, err := s.db.Exec("VACUUM INTO ?", path); err != nil {
+		return "", fmt.Errorf("back up database: %w", err)
+	}
+	return path, nil
+}
+
+// Restore replaces the database contents with the backup at backupPath,
+// upgrading it first if it was written by an older version. The rows are
+// copied in a single transaction, so the live file (and a concurrent bm
+// process using it) is never swapped out. The backup file itself is kept.
+func (s *SQLiteStorage) Restore(backupPath string) error {
+	if err := checkBackup(backupPath); err != nil {
+		return err
+	}
+
+	// Upgrade a copy, so the backup stays as it was
+	tmpPath := s.path + ".restoring"
+	removeTemp := func() {
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			_ = os.Remove(tmpPath + suffix)
+		}
+	}
+	removeTemp() // left over from a crash
+	defer removeTemp()
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	src, err := NewSQLiteStorage(tmpPath)
+	if err != nil {
+		return fmt.Errorf("upgrade backup: %w", err)
+	}
+	if err := src.Close(); err != nil {
+		return err
+	}
+
+	return s.copyTablesFrom(tmpPath)
+}
+
+// copyTablesFrom replaces the rows of every table with those of the database
+// at path, which must have the same schema version.
+func (s *SQLiteStorage) copyTablesFrom(path string) error {
+	// ATTACH and the foreign key PRAGMA are per connection
+	ctx := context.Background()
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS src", path); err != nil {
+		return err
+	}
+	defer func() { _, _ = conn.ExecContext(ctx, "DETACH DATABASE src") }()
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT name FROM src.sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'schema_version'
+	`)
+	if err != nil {
+		return err
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		tables = append(tables, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return err
+	}
+	defer func() { _, _ = conn.ExecContext(ctx, "PRAGMA foreign_keys = ON") }()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, table := range tables {
+		quoted := `"` + strings.ReplaceAll(table, `"`, `""`) + `"`
+		if _, err := tx.Exec("DELETE FROM main." + quoted); err != nil {
+			return fmt.Errorf("restore %s: %w", table, err)
+		}
+		if _, err := tx.Exec("INSERT INTO main." + quoted + " SELECT * FROM src." + quoted); err != nil {
+			return fmt.Errorf("restore %s: %w", table, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// checkBackup verifies that path is a bm database before it replaces the live one.
+func checkBackup(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	// Plain open: openDB's WAL pragma would write to the backup
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM bookmarks").Scan(&count); err != nil {
+		return fmt.Errorf("%s is not a bm database: %w", path, err)
+	}
+	return nil
+}
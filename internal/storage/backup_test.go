@@ -0,0 +1,115 @@
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/storage"
+)
+
+func TestListBackups_NewestFirstAndSelect(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"bookmarks-20260101-090000.db",
+		"bookmarks-20260301-120000.db",
+		"bookmarks-20260215-080000.db",
+		"notes.txt",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "old.db"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := storage.ListBackups(dir)
+	if err != nil {
+		t.Fatalf("ListBackups: %v", err)
+	}
+	var names []string
+	for _, b := range backups {
+		names = append(names, b.Name())
+	}
+	want := []string{"bookmarks-20260301-120000.db", "bookmarks-20260215-080000.db", "bookmarks-20260101-090000.db"}
+	if len(names) != len(want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, names)
+		}
+	}
+	if got := backups[0].Time; !got.Equal(time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)) {
+		t.Errorf("expected time parsed from the name, got %v", got)
+	}
+
+	if b, err := storage.SelectBackup(backups, "2"); err != nil || b.Name() != want[1] {
+		t.Errorf("expected #2 to select %s, got %v (%v)", want[1], b.Name(), err)
+	}
+	if b, err := storage.SelectBackup(backups, "bookmarks-20260101-090000"); err != nil || b.Name() != want[2] {
+		t.Errorf("expected name without extension to select %s, got %v (%v)", want[2], b.Name(), err)
+	}
+	if _, err := storage.SelectBackup(backups, "4"); err == nil {
+		t.Error("expected an error for an out-of-range choice")
+	}
+
+	missing, err := storage.ListBackups(filepath.Join(dir, "missing"))
+	if err != nil || len(missing) != 0 {
+		t.Errorf("expected no backups for a missing directory, got %v (%v)", missing, err)
+	}
+}
+
+func TestSQLiteStorage_BackupAndRestore(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "bookmarks.db")
+	s, err := storage.NewSQLiteStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	store := model.NewStore()
+	store.AddBookmark(model.NewBookmark(model.NewBookmarkParams{Title: "Kept", URL: "https://kept.dev"}))
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	dir := storage.BackupDir(dbPath)
+	backupPath, err := s.Backup(dir, time.Date(2026, 4, 1, 10, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	if filepath.Base(backupPath) != "bookmarks-20260401-100000.db" {
+		t.Errorf("unexpected backup name %s", backupPath)
+	}
+
+	store.AddBookmark(model.NewBookmark(model.NewBookmarkParams{Title: "Later", URL: "https://later.dev"}))
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	if err := s.Restore(backupPath); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	loaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if len(loaded.Bookmarks) != 1 || loaded.Bookmarks[0].Title != "Kept" {
+		t.Errorf("expected only the backed-up bookmark, got %+v", loaded.Bookmarks)
+	}
+
+	notDB := filepath.Join(t.TempDir(), "notes.db")
+	if err := os.WriteFile(notDB, []byte("not a database"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Restore(notDB); err == nil {
+		t.Error("expected restoring a non-bm file to fail")
+	}
+	if loaded, err := s.Load(); err != nil || len(loaded.Bookmarks) != 1 {
+		t.Errorf("expected the database to be unchanged after a failed restore, got %v", err)
+	}
+}
@@ -0,0 +1,382 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrNoCache is returned when no cache has been saved yet.
+var ErrNoCache = errors.New("no cache saved")
+
+// ErrCorruptCache is returned when a saved cache can't be decoded. Callers
+// treat it like ErrNoCache; the next save replaces it.
+var ErrCorruptCache = errors.New("cache is corrupt")
+
+// CullCache represents cached cull results.
+type CullCache struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Checksum  string            `json:"checksum"` // SHA256 of bookmark data to detect changes
+	Results   []CullCacheResult `json:"results"`
+}
+
+// CullCacheResult is a serializable version of culler.Result.
+type CullCacheResult struct {
+	BookmarkID string `json:"bookmarkId"`
+	Status     int    `json:"status"` // culler.Status as int
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+}
+
+// OrganizeCache represents cached organize results.
+type OrganizeCache struct {
+	Timestamp time.Time             `json:"timestamp"`
+	Checksum  string                `json:"checksum"` // SHA256 of bookmark data to detect changes
+	Results   []OrganizeCacheResult `json:"results"`
+}
+
+// OrganizeCacheResult is a serializable version of an organize suggestion.
+type OrganizeCacheResult struct {
+	ItemID        string   `json:"itemId"`
+	IsFolder      bool     `json:"isFolder"`
+	CurrentPath   string   `json:"currentPath"`
+	SuggestedPath string   `json:"suggestedPath"`
+	IsNewFolder   bool     `json:"isNewFolder"`
+	CurrentTags   []string `json:"currentTags"`
+	SuggestedTags []string `json:"suggestedTags"`
+}
+
+// CacheStorage is implemented by backends that keep the cull and organize
+// caches alongside the bookmarks. Callers fall back to JSON files otherwise.
+type CacheStorage interface {
+	SaveCullCache(cache *CullCache) error
+	LoadCullCache() (*CullCache, error)
+	SaveOrganizeCache(cache *OrganizeCache) error
+	LoadOrganizeCache() (*OrganizeCache, error)
+}
+
+const (
+	cullCacheName     = "cull"
+	organizeCacheName = "organize"
+)
+
+// SaveCullCache replaces the cached cull results.
+func (s *SQLiteStorage) SaveCullCache(cache *CullCache) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM cull_cache"); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`
+		INSERT INTO cull_cache (bookmark_id, status, status_code, error)
+		VALUES (?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range cache.Results {
+		if _, err := stmt.Exec(r.BookmarkID, r.Status, r.StatusCode, r.Error); err != nil {
+			return err
+		}
+	}
+
+	if err := saveCacheMeta(tx, cullCacheName, cache.Timestamp, cache.Checksum); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LoadCullCache returns the cached cull results, or ErrNoCache.
+func (s *SQLiteStorage) LoadCullCache() (*CullCache, error) {
+	cache := &CullCache{Results: []CullCacheResult{}}
+	var err error
+	cache.Timestamp, cache.Checksum, err = s.loadCacheMeta(cullCacheName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT bookmark_id, status, status_code, error
+		FROM cull_cache
+		ORDER BY rowid
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r CullCacheResult
+		if err := rows.Scan(&r.BookmarkID, &r.Status, &r.StatusCode, &r.Error); err != nil {
+			return nil, err
+		}
+		cache.Results = append(cache.Results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cache, nil
+}
+
+// SaveOrganizeCache replaces the cached organize suggestions.
+func (s *SQLiteStorage) SaveOrganizeCache(cache *OrganizeCache) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM organize_cache"); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`
+		INSERT INTO organize_cache (item_id, is_folder, current_path, suggested_path, is_new_folder, current_tags, suggested_tags)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range cache.Results {
+		currentTags, _ := json.Marshal(nonNilTags(r.CurrentTags))
+		suggestedTags, _ := json.Marshal(nonNilTags(r.SuggestedTags))
+		if _, err := stmt.Exec(
+			r.ItemID, boolToInt(r.IsFolder), r.CurrentPath, r.SuggestedPath,
+			boolToInt(r.IsNewFolder), string(currentTags), string(suggestedTags),
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := saveCacheMeta(tx, organizeCacheName, cache.Timestamp, cache.Checksum); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LoadOrganizeCache returns the cached organize suggestions, or ErrNoCache.
+func (s *SQLiteStorage) LoadOrganizeCache() (*OrganizeCache, error) {
+	cache := &OrganizeCache{Results: []OrganizeCacheResult{}}
+	var err error
+	cache.Timestamp, cache.Checksum, err = s.loadCacheMeta(organizeCacheName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT item_id, is_folder, current_path, suggested_path, is_new_folder, current_tags, suggested_tags
+		FROM organize_cache
+		ORDER BY rowid
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r OrganizeCacheResult
+		var isFolder, isNewFolder int
+		var currentTags, suggestedTags string
+		if err := rows.Scan(&r.ItemID, &isFolder, &r.CurrentPath, &r.SuggestedPath,
+			&isNewFolder, &currentTags, &suggestedTags); err != nil {
+			return nil, err
+		}
+		r.IsFolder = isFolder == 1
+		r.IsNewFolder = isNewFolder == 1
+		if err := json.Unmarshal([]byte(currentTags), &r.CurrentTags); err != nil {
+			return nil, ErrCorruptCache
+		}
+		if err := json.Unmarshal([]byte(suggestedTags), &r.SuggestedTags); err != nil {
+			return nil, ErrCorruptCache
+		}
+		cache.Results = append(cache.Results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cache, nil
+}
+
+// saveCacheMeta records when a cache was written and the checksum it was built against.
+func saveCacheMeta(tx *sql.Tx, name string, timestamp time.Time, checksum string) error {
+	_, err := tx.Exec(`
+		INSERT OR REPLACE INTO cache_meta (name, timestamp, checksum)
+		VALUES (?, ?, ?)
+	`, name, timestamp.Format(time.RFC3339Nano), checksum)
+	return err
+}
+
+// loadCacheMeta returns a cache's timestamp and checksum, or ErrNoCache.
+func (s *SQLiteStorage) loadCacheMeta(name string) (time.Time, string, error) {
+	var timestamp, checksum string
+	err := s.db.QueryRow("SELECT timestamp, checksum FROM cache_meta WHERE name = ?", name).
+		Scan(&timestamp, &checksum)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, "", ErrNoCache
+	}
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return time.Time{}, "", ErrCorruptCache
+	}
+	return t, checksum, nil
+}
+
+// CullCacheFilePath returns where backends without CacheStorage keep the
+// cull cache: ~/.config/bm/cull-cache.json
+func CullCacheFilePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".config", "bm", "cull-cache.json"), nil
+}
+
+// OrganizeCacheFilePath returns where backends without CacheStorage keep the
+// organize cache: ~/.config/bm/organize-cache.json
+func OrganizeCacheFilePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".config", "bm", "organize-cache.json"), nil
+}
+
+// CullIgnoreFilePath returns the path to the cull-ignore file, whose patterns
+// name URLs that cull should leave unchecked.
+func CullIgnoreFilePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".config", "bm", "cull-ignore"), nil
+}
+
+// ReadCullCache loads the last cull results from s, or from
+// CullCacheFilePath when s doesn't implement CacheStorage.
+func ReadCullCache(s Storage) (*CullCache, error) {
+	if cs, ok := s.(CacheStorage); ok {
+		return cs.LoadCullCache()
+	}
+
+	path, err := CullCacheFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	var cache CullCache
+	if err := ReadCacheFile(path, &cache); err != nil {
+		return nil, err
+	}
+	return &cache, nil
+}
+
+// ReadOrganizeCache loads the last organize suggestions from s, or from
+// OrganizeCacheFilePath when s doesn't implement CacheStorage.
+func ReadOrganizeCache(s Storage) (*OrganizeCache, error) {
+	if cs, ok := s.(CacheStorage); ok {
+		return cs.LoadOrganizeCache()
+	}
+
+	path, err := OrganizeCacheFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	var cache OrganizeCache
+	if err := ReadCacheFile(path, &cache); err != nil {
+		return nil, err
+	}
+	return &cache, nil
+}
+
+// ReadCacheFile decodes the JSON cache at path into cache, for backends
+// without CacheStorage. A file that can't be decoded is removed and
+// ErrCorruptCache returned, so the caller can carry on with a fresh run.
+func ReadCacheFile(path string, cache any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNoCache
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, cache); err != nil {
+		_ = os.Remove(path)
+		return ErrCorruptCache
+	}
+	return nil
+}
+
+// WriteCacheFile writes cache to path as JSON. It writes a temp file in the
+// same directory and renames it into place, so a crash mid-write can't leave
+// a truncated cache behind.
+func WriteCacheFile(path string, cache any) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// pruneOrphanCaches drops cache rows for items that no longer exist.
+func pruneOrphanCaches(tx *sql.Tx) error {
+	if _, err := tx.Exec(`
+		DELETE FROM cull_cache WHERE bookmark_id NOT IN (SELECT id FROM bookmarks)
+	`); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`
+		DELETE FROM organize_cache
+		WHERE (is_folder = 0 AND item_id NOT IN (SELECT id FROM bookmarks))
+		   OR (is_folder = 1 AND item_id NOT IN (SELECT id FROM folders))
+	`)
+	return err
+}
+
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
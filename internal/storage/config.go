@@ -3,21 +3,260 @@ package storage
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// Pinned pane sort modes for Config.PinnedSortMode.
+const (
+	PinnedSortOrder        = "order"         // pin order, reorderable with J/K
+	PinnedSortAlpha        = "alpha"         // by name, folders and bookmarks mixed
+	PinnedSortFoldersFirst = "folders-first" // folders, then bookmarks, each in pin order
+)
+
+// Breadcrumb truncation styles for Config.BreadcrumbTruncation.
+const (
+	BreadcrumbTruncateLeft   = "left"   // .../Components/Forms
+	BreadcrumbTruncateMiddle = "middle" // /Dev/.../Forms, keeping the root
 )
 
+// ReservedAliasNames are the bm subcommands. `bm <name>` always runs the
+// subcommand, so an alias with one of these names could never be used.
+var ReservedAliasNames = []string{
+	"help", "add", "init", "reset", "import", "export", "cull", "health", "sync",
+	"doctor", "prune-empty", "backup", "restore", "tag", "tags", "tag-domain",
+	"snoozed", "untitled", "list", "domains", "unsnooze",
+}
+
+// DefaultDateFormat is the Go time layout for dates shown in the TUI.
+const DefaultDateFormat = "2006-01-02"
+
 // Config holds application configuration.
 type Config struct {
 	QuickAddFolder     string   `json:"quickAddFolder"`
 	CullExcludeDomains []string `json:"cullExcludeDomains"`
+	// BrokenFolder is where M in the cull results moves a group of dead links.
+	BrokenFolder string `json:"brokenFolder"`
+	// RecentWindowMinutes controls how long new bookmarks are marked as recently added.
+	// Negative values disable the marker.
+	RecentWindowMinutes int `json:"recentWindowMinutes"`
+	// WrapNavigation makes j/k wrap around at the ends of a list.
+	WrapNavigation bool `json:"wrapNavigation"`
+	// ConfirmBookmarkDelete asks before deleting or cutting a bookmark even
+	// when delete confirmation is toggled off (c).
+	ConfirmBookmarkDelete bool `json:"confirmBookmarkDelete"`
+	// SkipOrganizeConfirm applies organize suggestions on Enter without a preview.
+	SkipOrganizeConfirm bool `json:"skipOrganizeConfirm"`
+	// OrganizeRecursive makes organize on a folder analyze its whole subtree
+	// instead of only the folder's direct children.
+	OrganizeRecursive bool `json:"organizeRecursive"`
+	// SaveDebounceMs coalesces bursts of mutations into one write after this quiet period.
+	// Zero saves immediately after every mutation.
+	SaveDebounceMs int `json:"saveDebounceMs"`
+	// Aliases maps a short name to a bookmark ID or URL, so `bm <alias>` opens it directly.
+	Aliases map[string]string `json:"aliases"`
+	// LargeOperationThreshold asks for confirmation before a paste or move that affects
+	// more than this many items, counting folder contents. Negative values disable the check.
+	LargeOperationThreshold int `json:"largeOperationThreshold"`
+	// OpenAllThreshold asks for confirmation before opening more than this many
+	// URLs at once. Negative values disable the check.
+	OpenAllThreshold int `json:"openAllThreshold"`
+	// ShowItemCounter shows the cursor position in the focused pane (e.g. 3/47) next to the breadcrumb.
+	ShowItemCounter bool `json:"showItemCounter"`
+	// ShowItemNumbers prefixes items in the current pane with their 1-based index.
+	// Toggled for the session with tn.
+	ShowItemNumbers bool `json:"showItemNumbers"`
+	// ShowTagsInList appends a bookmark's tags to its title in the list, as
+	// many as fit.
+	ShowTagsInList bool `json:"showTagsInList"`
+	// ShowClock shows the current time next to the breadcrumb.
+	ShowClock bool `json:"showClock"`
+	// LowercaseTags lowercases entered and imported tags so "React" and "react" are one tag.
+	LowercaseTags bool `json:"lowercaseTags"`
+	// CopyLinkFormat is the template for copying a bookmark as a link, with {url}
+	// and {title} placeholders (e.g. "[[{url}][{title}]]" for org-mode).
+	CopyLinkFormat string `json:"copyLinkFormat"`
+	// ShowArchivedInline lists archived bookmarks dimmed and struck through instead of hiding them.
+	ShowArchivedInline bool `json:"showArchivedInline"`
+	// MaxFolderDepth limits how deeply folders can be nested (0 = unlimited).
+	MaxFolderDepth int `json:"maxFolderDepth"`
+	// SyncRemote is the git remote bm sync pulls from and pushes to.
+	SyncRemote string `json:"syncRemote"`
+	// SyncBranch is the branch bm sync pushes; empty uses the current branch.
+	SyncBranch string `json:"syncBranch"`
+	// TerminalBrowserCommand opens bookmarks with gb inside the terminal, e.g. "w3m"
+	// or "lynx -accept_all_cookies {url}" (the URL is appended without {url}).
+	TerminalBrowserCommand string `json:"terminalBrowserCommand"`
+	// MainPaneWeight is the width of the current and preview panes relative to
+	// the side panes, in percent (100 = equal widths). Adjusted with < and >.
+	MainPaneWeight int `json:"mainPaneWeight"`
+	// PinnedSortMode orders the pinned pane: "order" (default), "alpha" or
+	// "folders-first". The 1-9 shortcuts follow the displayed order.
+	PinnedSortMode string `json:"pinnedSortMode"`
+	// FilterAutoSelect puts the cursor on the only match of the / filter, and
+	// Enter then opens it instead of just closing the filter.
+	FilterAutoSelect bool `json:"filterAutoSelect"`
+	// MergeSubdomains groups subdomains under their registrable domain in the
+	// domains view (docs.github.com counts as github.com).
+	MergeSubdomains bool `json:"mergeSubdomains"`
+	// DisableAI turns off every AI call: i and L capture without AI and
+	// organize is unavailable. bm --no-ai does the same for one run.
+	DisableAI bool `json:"disableAI"`
+	// VerifyBeforeOpen checks that a bookmark's link is alive before opening it
+	// and warns if it looks dead. gv does this for one open.
+	VerifyBeforeOpen bool `json:"verifyBeforeOpen"`
+	// SequenceTimeoutMs cancels a pending prefix key (the g of gg, the t of to)
+	// when the next key doesn't follow within this time. Negative values wait
+	// indefinitely.
+	SequenceTimeoutMs int `json:"sequenceTimeoutMs"`
+	// IdleQuitSeconds quits bm (saving first) after this long without a key
+	// press, e.g. to return a kiosk or launcher to its menu. 0 disables it.
+	IdleQuitSeconds int `json:"idleQuitSeconds"`
+	// SearchBookmarksOnly leaves folders out of the fuzzy finder (f) by
+	// default. Ctrl+F in the finder brings them back for that search.
+	SearchBookmarksOnly bool `json:"searchBookmarksOnly"`
+	// FinderNumberKeys numbers the first nine fuzzy finder results and makes
+	// 1-9 jump to one instead of typing the digit.
+	FinderNumberKeys bool `json:"finderNumberKeys"`
+	// DateFormat is the Go time layout for dates shown in the preview, e.g.
+	// "02.01.2006" or "2006-01-02 15:04". Exports keep their own formats.
+	DateFormat string `json:"dateFormat"`
+	// BreadcrumbTruncation shortens a breadcrumb path that doesn't fit: "left"
+	// (default) drops leading folders, "middle" keeps the first and last.
+	BreadcrumbTruncation string `json:"breadcrumbTruncation"`
+	// OnboardingShown records that the first-run key tour was dismissed, so it
+	// only appears once.
+	OnboardingShown bool `json:"onboardingShown"`
+	// Templates are named presets for recurring adds, used by
+	// bm add --template <name> and the I key.
+	Templates map[string]Template `json:"templates"`
+
+	// Warnings lists the invalid values LoadConfig replaced with their
+	// defaults, for the caller to show. Never saved.
+	Warnings []string `json:"-"`
+}
+
+// Template pre-fills a new bookmark's folder, tags and title.
+type Template struct {
+	// Folder is the full path to file bookmarks under, created if missing.
+	// Empty keeps the default folder; "/" is the root.
+	Folder      string   `json:"folder"`
+	Tags        []string `json:"tags"`
+	TitlePrefix string   `json:"titlePrefix"`
+}
+
+// PrefixTitle prepends the template's title prefix, unless title already starts with it.
+func (t Template) PrefixTitle(title string) string {
+	if strings.HasPrefix(title, t.TitlePrefix) {
+		return title
+	}
+	return t.TitlePrefix + title
+}
+
+// Apply files b under the template: it moves b to the template's folder,
+// adds the template's tags ahead of b's own and prefixes the title.
+func (t Template) Apply(store *model.Store, b *model.Bookmark, lowercaseTags bool) {
+	if t.Folder != "" {
+		b.FolderID = nil
+		if folder, _ := store.GetOrCreateFolderByPath(t.Folder); folder != nil {
+			b.FolderID = &folder.ID
+		}
+	}
+	b.Tags = model.NormalizeTags(append(slices.Clone(t.Tags), b.Tags...), lowercaseTags)
+	b.Title = t.PrefixTitle(b.Title)
+}
+
+// TemplateNames returns the configured template names in alphabetical order.
+func (c *Config) TemplateNames() []string {
+	names := make([]string, 0, len(c.Templates))
+	for name := range c.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidDateFormat reports whether layout is a Go time layout that formats a
+// date and parses back, which rules out strings like "dd.mm.yyyy".
+func ValidDateFormat(layout string) bool {
+	ref := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+	formatted := ref.Format(layout)
+	if formatted == layout {
+		return false
+	}
+	_, err := time.Parse(layout, formatted)
+	return err == nil
+}
+
+// FormatDate formats t with DateFormat, or ISO when it is unset.
+func (c *Config) FormatDate(t time.Time) string {
+	if c.DateFormat == "" {
+		return t.Format(DefaultDateFormat)
+	}
+	return t.Format(c.DateFormat)
+}
+
+// ConfirmOpenAll reports whether opening count URLs at once needs confirmation.
+func (c *Config) ConfirmOpenAll(count int) bool {
+	return c.OpenAllThreshold > 0 && count > c.OpenAllThreshold
+}
+
+// AliasFor returns the alias pointing at target, or "" if there is none.
+func (c *Config) AliasFor(target string) string {
+	for name, t := range c.Aliases {
+		if t == target {
+			return name
+		}
+	}
+	return ""
+}
+
+// SetAlias points name at target, replacing any previous alias for that target.
+// An empty name just removes the target's existing alias. Names that bm would
+// run as a subcommand or flag are refused.
+func (c *Config) SetAlias(name, target string) error {
+	if slices.Contains(ReservedAliasNames, name) || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("%q is a bm command and can't be an alias", name)
+	}
+	for existing, t := range c.Aliases {
+		if t == target {
+			delete(c.Aliases, existing)
+		}
+	}
+	if name == "" {
+		return nil
+	}
+	if c.Aliases == nil {
+		c.Aliases = make(map[string]string)
+	}
+	c.Aliases[name] = target
+	return nil
 }
 
 // DefaultConfig returns the default configuration.
 func DefaultConfig() Config {
 	return Config{
-		QuickAddFolder:     "Read Later",
-		CullExcludeDomains: []string{"github.com", "gitlab.com"},
+		QuickAddFolder:          "Read Later",
+		CullExcludeDomains:      []string{"github.com", "gitlab.com"},
+		BrokenFolder:            "Broken",
+		RecentWindowMinutes:     10,
+		LargeOperationThreshold: 50,
+		OpenAllThreshold:        10,
+		LowercaseTags:           true,
+		CopyLinkFormat:          model.MarkdownLinkFormat,
+		SyncRemote:              "origin",
+		MainPaneWeight:          100,
+		PinnedSortMode:          PinnedSortOrder,
+		BreadcrumbTruncation:    BreadcrumbTruncateLeft,
+		SequenceTimeoutMs:       1000,
+		DateFormat:              DefaultDateFormat,
 	}
 }
 
@@ -38,12 +277,14 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, err
 	}
 
-	var config Config
+	// Start from defaults so fields missing from the file (e.g. booleans that
+	// default to true) keep their default value
+	config := DefaultConfig()
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, err
 	}
 
-	// Apply defaults for missing fields
+	// Apply defaults for fields that are present but empty
 	defaults := DefaultConfig()
 	if config.QuickAddFolder == "" {
 		config.QuickAddFolder = defaults.QuickAddFolder
@@ -51,10 +292,70 @@ func LoadConfig(path string) (*Config, error) {
 	if config.CullExcludeDomains == nil {
 		config.CullExcludeDomains = defaults.CullExcludeDomains
 	}
+	if config.BrokenFolder == "" {
+		config.BrokenFolder = defaults.BrokenFolder
+	}
+	if config.RecentWindowMinutes == 0 {
+		config.RecentWindowMinutes = defaults.RecentWindowMinutes
+	}
+	if config.LargeOperationThreshold == 0 {
+		config.LargeOperationThreshold = defaults.LargeOperationThreshold
+	}
+	if config.OpenAllThreshold == 0 {
+		config.OpenAllThreshold = defaults.OpenAllThreshold
+	}
+	if config.CopyLinkFormat == "" {
+		config.CopyLinkFormat = defaults.CopyLinkFormat
+	}
+	if config.MainPaneWeight == 0 {
+		config.MainPaneWeight = defaults.MainPaneWeight
+	}
+	if config.SequenceTimeoutMs == 0 {
+		config.SequenceTimeoutMs = defaults.SequenceTimeoutMs
+	}
+	if config.SyncRemote == "" {
+		config.SyncRemote = defaults.SyncRemote
+	}
+	if config.PinnedSortMode == "" {
+		config.PinnedSortMode = defaults.PinnedSortMode
+	}
+	switch config.PinnedSortMode {
+	case PinnedSortOrder, PinnedSortAlpha, PinnedSortFoldersFirst:
+	default:
+		config.warnInvalid("pinnedSortMode", config.PinnedSortMode, "want order, alpha or folders-first", defaults.PinnedSortMode)
+		config.PinnedSortMode = defaults.PinnedSortMode
+	}
+	if config.BreadcrumbTruncation == "" {
+		config.BreadcrumbTruncation = defaults.BreadcrumbTruncation
+	}
+	switch config.BreadcrumbTruncation {
+	case BreadcrumbTruncateLeft, BreadcrumbTruncateMiddle:
+	default:
+		config.warnInvalid("breadcrumbTruncation", config.BreadcrumbTruncation, "want left or middle", defaults.BreadcrumbTruncation)
+		config.BreadcrumbTruncation = defaults.BreadcrumbTruncation
+	}
+	if config.DateFormat == "" {
+		config.DateFormat = defaults.DateFormat
+	}
+	if !ValidDateFormat(config.DateFormat) {
+		// A layout without date fields would print itself; show ISO dates instead
+		config.warnInvalid("dateFormat", config.DateFormat, "not a Go time layout", defaults.DateFormat)
+		config.DateFormat = defaults.DateFormat
+	}
+	if err := model.ValidateLinkFormat(config.CopyLinkFormat); err != nil {
+		config.warnInvalid("copyLinkFormat", config.CopyLinkFormat, err.Error(), defaults.CopyLinkFormat)
+		config.CopyLinkFormat = defaults.CopyLinkFormat
+	}
 
 	return &config, nil
 }
 
+// warnInvalid records that field held an invalid value and was replaced with
+// fallback, so one typo doesn't keep bm from starting.
+func (c *Config) warnInvalid(field, value, reason, fallback string) {
+	c.Warnings = append(c.Warnings, fmt.Sprintf("%s %q: %s; using %q", field, value, reason, fallback))
+}
+
 // SaveConfig writes config to the JSON file.
 // Creates the directory if it doesn't exist.
 func SaveConfig(path string, config *Config) error {
@@ -0,0 +1,83 @@
+package storage
+
+import "time"
+
+// maxCullHistory is how many cull runs are kept; older runs are dropped on append.
+const maxCullHistory = 100
+
+// CullRun summarizes one cull run for the link rot trend report.
+type CullRun struct {
+	Timestamp   time.Time
+	Total       int // bookmarks checked
+	Dead        int
+	Unreachable int
+}
+
+// Healthy returns the number of bookmarks that were neither dead nor unreachable.
+func (r CullRun) Healthy() int {
+	return r.Total - r.Dead - r.Unreachable
+}
+
+// CullHistoryStorage is implemented by backends that keep a history of cull runs
+// alongside the cull cache.
+type CullHistoryStorage interface {
+	AppendCullRun(run CullRun) error
+	LoadCullHistory(limit int) ([]CullRun, error)
+}
+
+// AppendCullRun records a cull run, keeping only the most recent maxCullHistory runs.
+func (s *SQLiteStorage) AppendCullRun(run CullRun) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT INTO cull_history (timestamp, total, dead, unreachable)
+		VALUES (?, ?, ?, ?)
+	`, run.Timestamp.Format(time.RFC3339Nano), run.Total, run.Dead, run.Unreachable); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`
+		DELETE FROM cull_history
+		WHERE rowid NOT IN (SELECT rowid FROM cull_history ORDER BY rowid DESC LIMIT ?)
+	`, maxCullHistory); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LoadCullHistory returns the last limit cull runs, oldest first.
+// A limit of zero or less returns every stored run.
+func (s *SQLiteStorage) LoadCullHistory(limit int) ([]CullRun, error) {
+	if limit <= 0 {
+		limit = maxCullHistory
+	}
+	rows, err := s.db.Query(`
+		SELECT timestamp, total, dead, unreachable FROM (
+			SELECT rowid, timestamp, total, dead, unreachable
+			FROM cull_history
+			ORDER BY rowid DESC
+			LIMIT ?
+		) ORDER BY rowid
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []CullRun{}
+	for rows.Next() {
+		var run CullRun
+		var timestamp string
+		if err := rows.Scan(&timestamp, &run.Total, &run.Dead, &run.Unreachable); err != nil {
+			return nil, err
+		}
+		if run.Timestamp, err = time.Parse(time.RFC3339Nano, timestamp); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
@@ -0,0 +1,262 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// CurrentSchemaVersion is the schema version written by this build of bm.
+// Bump it together with a new entry in migrations whenever a persisted field is added.
+const CurrentSchemaVersion = 13
+
+// migration upgrades persisted data from version-1 to version.
+// Every new persisted field ships with one of these.
+type migration struct {
+	version  int
+	sql      string             // DDL applied to SQLite databases
+	backfill func(*model.Store) // default values for data written by older versions
+}
+
+// migrations lists all schema upgrades in ascending version order.
+var migrations = []migration{
+	{
+		version: 1,
+		sql: `
+			CREATE TABLE IF NOT EXISTS schema_version (
+				version INTEGER PRIMARY KEY
+			);
+
+			CREATE TABLE IF NOT EXISTS folders (
+				id TEXT PRIMARY KEY NOT NULL,
+				name TEXT NOT NULL,
+				parent_id TEXT,
+				pinned INTEGER NOT NULL DEFAULT 0,
+				FOREIGN KEY (parent_id) REFERENCES folders(id) ON DELETE SET NULL
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_folders_parent_id ON folders(parent_id);
+			CREATE INDEX IF NOT EXISTS idx_folders_pinned ON folders(pinned) WHERE pinned = 1;
+
+			CREATE TABLE IF NOT EXISTS bookmarks (
+				id TEXT PRIMARY KEY NOT NULL,
+				title TEXT NOT NULL,
+				url TEXT NOT NULL,
+				folder_id TEXT,
+				tags TEXT NOT NULL DEFAULT '[]',
+				created_at TEXT NOT NULL,
+				visited_at TEXT,
+				pinned INTEGER NOT NULL DEFAULT 0,
+				FOREIGN KEY (folder_id) REFERENCES folders(id) ON DELETE SET NULL
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_bookmarks_folder_id ON bookmarks(folder_id);
+			CREATE INDEX IF NOT EXISTS idx_bookmarks_url ON bookmarks(url);
+			CREATE INDEX IF NOT EXISTS idx_bookmarks_pinned ON bookmarks(pinned) WHERE pinned = 1;
+		`,
+		backfill: backfillTags,
+	},
+	{
+		// v2 adds pin_order for pinned item ordering.
+		version: 2,
+		sql: `
+			ALTER TABLE folders ADD COLUMN pin_order INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE bookmarks ADD COLUMN pin_order INTEGER NOT NULL DEFAULT 0;
+		`,
+		backfill: backfillPinOrder,
+	},
+	{
+		// v3 adds snooze_until for deferred bookmarks (NULL = not snoozed).
+		version: 3,
+		sql: `
+			ALTER TABLE bookmarks ADD COLUMN snooze_until TEXT;
+		`,
+	},
+	{
+		// v4 adds visit_count for visit analytics.
+		version: 4,
+		sql: `
+			ALTER TABLE bookmarks ADD COLUMN visit_count INTEGER NOT NULL DEFAULT 0;
+		`,
+		backfill: backfillVisitCount,
+	},
+	{
+		// v5 moves the cull/organize caches into the database (see cache.go).
+		version: 5,
+		sql: `
+			CREATE TABLE IF NOT EXISTS cache_meta (
+				name TEXT PRIMARY KEY NOT NULL,
+				timestamp TEXT NOT NULL,
+				checksum TEXT NOT NULL DEFAULT ''
+			);
+
+			CREATE TABLE IF NOT EXISTS cull_cache (
+				bookmark_id TEXT PRIMARY KEY NOT NULL,
+				status INTEGER NOT NULL,
+				status_code INTEGER NOT NULL DEFAULT 0,
+				error TEXT NOT NULL DEFAULT ''
+			);
+
+			CREATE TABLE IF NOT EXISTS organize_cache (
+				item_id TEXT PRIMARY KEY NOT NULL,
+				is_folder INTEGER NOT NULL DEFAULT 0,
+				current_path TEXT NOT NULL,
+				suggested_path TEXT NOT NULL,
+				is_new_folder INTEGER NOT NULL DEFAULT 0,
+				current_tags TEXT NOT NULL DEFAULT '[]',
+				suggested_tags TEXT NOT NULL DEFAULT '[]'
+			);
+		`,
+	},
+	{
+		// v6 adds cull_history for the link rot trend report (see history.go).
+		version: 6,
+		sql: `
+			CREATE TABLE IF NOT EXISTS cull_history (
+				timestamp TEXT NOT NULL,
+				total INTEGER NOT NULL,
+				dead INTEGER NOT NULL,
+				unreachable INTEGER NOT NULL
+			);
+		`,
+	},
+	{
+		// v7 adds archived for bookmarks hidden from the normal view.
+		version: 7,
+		sql: `
+			ALTER TABLE bookmarks ADD COLUMN archived INTEGER NOT NULL DEFAULT 0;
+		`,
+	},
+	{
+		// v8 adds locked for read-only folders.
+		version: 8,
+		sql: `
+			ALTER TABLE folders ADD COLUMN locked INTEGER NOT NULL DEFAULT 0;
+		`,
+	},
+	{
+		// v9 adds remind_at for follow-up reminders (NULL = no reminder).
+		version: 9,
+		sql: `
+			ALTER TABLE bookmarks ADD COLUMN remind_at TEXT;
+		`,
+	},
+	{
+		// v10 adds modified_at for edit tracking, starting out equal to created_at.
+		version: 10,
+		sql: `
+			ALTER TABLE bookmarks ADD COLUMN modified_at TEXT;
+			UPDATE bookmarks SET modified_at = created_at WHERE modified_at IS NULL;
+		`,
+		backfill: backfillModifiedAt,
+	},
+	{
+		// v11 adds priority for triaging, defaulting to none.
+		version: 11,
+		sql: `
+			ALTER TABLE bookmarks ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+		`,
+	},
+	{
+		// v12 adds position so manual order survives a reload. Existing rows
+		// are numbered in the order older versions loaded them.
+		version: 12,
+		sql: `
+			ALTER TABLE folders ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE bookmarks ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+			UPDATE folders SET position = (
+				SELECT COUNT(*) FROM folders f
+				WHERE f.name < folders.name OR (f.name = folders.name AND f.rowid < folders.rowid)
+			);
+			UPDATE bookmarks SET position = (
+				SELECT COUNT(*) FROM bookmarks b
+				WHERE b.created_at < bookmarks.created_at
+					OR (b.created_at = bookmarks.created_at AND b.rowid < bookmarks.rowid)
+			);
+		`,
+	},
+	{
+		// v13 adds view_mode for per-folder view modes ('' = columns).
+		version: 13,
+		sql: `
+			ALTER TABLE folders ADD COLUMN view_mode TEXT NOT NULL DEFAULT '';
+		`,
+	},
+}
+
+// Migrate upgrades a store written by an older schema version in place,
+// backfilling defaults for every field added since, and stamps it with
+// CurrentSchemaVersion. A zero SchemaVersion marks data that predates
+// versioning, so every backfill runs.
+func Migrate(store *model.Store) error {
+	from := store.SchemaVersion
+	if from > CurrentSchemaVersion {
+		return fmt.Errorf("schema version %d is newer than supported version %d", from, CurrentSchemaVersion)
+	}
+
+	for _, m := range migrations {
+		if m.version <= from || m.backfill == nil {
+			continue
+		}
+		m.backfill(store)
+	}
+
+	store.SchemaVersion = CurrentSchemaVersion
+	return nil
+}
+
+// backfillTags replaces nil tag slices with empty ones.
+func backfillTags(store *model.Store) {
+	for i := range store.Bookmarks {
+		if store.Bookmarks[i].Tags == nil {
+			store.Bookmarks[i].Tags = []string{}
+		}
+	}
+}
+
+// backfillPinOrder assigns sequential pin orders to pinned items that predate pin_order.
+func backfillPinOrder(store *model.Store) {
+	maxOrder := 0
+	for _, f := range store.Folders {
+		if f.Pinned && f.PinOrder > maxOrder {
+			maxOrder = f.PinOrder
+		}
+	}
+	for _, b := range store.Bookmarks {
+		if b.Pinned && b.PinOrder > maxOrder {
+			maxOrder = b.PinOrder
+		}
+	}
+
+	for i := range store.Folders {
+		if store.Folders[i].Pinned && store.Folders[i].PinOrder == 0 {
+			maxOrder++
+			store.Folders[i].PinOrder = maxOrder
+		}
+	}
+	for i := range store.Bookmarks {
+		if store.Bookmarks[i].Pinned && store.Bookmarks[i].PinOrder == 0 {
+			maxOrder++
+			store.Bookmarks[i].PinOrder = maxOrder
+		}
+	}
+}
+
+// backfillVisitCount counts one visit for bookmarks visited before visit_count existed.
+func backfillVisitCount(store *model.Store) {
+	for i := range store.Bookmarks {
+		if store.Bookmarks[i].VisitedAt != nil && store.Bookmarks[i].VisitCount == 0 {
+			store.Bookmarks[i].VisitCount = 1
+		}
+	}
+}
+
+// backfillModifiedAt treats bookmarks edited before modified_at existed as
+// unmodified since creation.
+func backfillModifiedAt(store *model.Store) {
+	for i := range store.Bookmarks {
+		if store.Bookmarks[i].ModifiedAt.IsZero() {
+			store.Bookmarks[i].ModifiedAt = store.Bookmarks[i].CreatedAt
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
@@ -12,8 +14,6 @@ import (
 	"github.com/nikbrunner/bm/internal/model"
 )
 
-const currentSchemaVersion = 2
-
 // SQLiteStorage implements Storage using a SQLite database.
 type SQLiteStorage struct {
 	db   *sql.DB
@@ -28,12 +28,28 @@ func NewSQLiteStorage(path string) (*SQLiteStorage, error) {
 		return nil, err
 	}
 
+	db, err := openDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SQLiteStorage{db: db, path: path}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// openDB opens the database at path with foreign keys on and pragmas set
+// for performance.
+func openDB(path string) (*sql.DB, error) {
 	db, err := sql.Open("sqlite", path)
 	if err != nil {
 		return nil, err
 	}
 
-	// Enable foreign keys and set pragmas for performance
 	pragmas := []string{
 		"PRAGMA foreign_keys = ON",
 		"PRAGMA journal_mode = WAL",
@@ -46,14 +62,7 @@ func NewSQLiteStorage(path string) (*SQLiteStorage, error) {
 			return nil, err
 		}
 	}
-
-	s := &SQLiteStorage{db: db, path: path}
-	if err := s.migrate(); err != nil {
-		db.Close()
-		return nil, err
-	}
-
-	return s, nil
+	return db, nil
 }
 
 // Path returns the database file path.
@@ -66,94 +75,95 @@ func (s *SQLiteStorage) Close() error {
 	return s.db.Close()
 }
 
-// migrate runs database migrations.
+// migrate brings the database up to CurrentSchemaVersion.
+// DDL from every pending migration runs first; existing rows are then
+// loaded, backfilled via Migrate, and saved back.
 func (s *SQLiteStorage) migrate() error {
-	// Check current schema version
-	var version int
-	err := s.db.QueryRow("SELECT version FROM schema_version LIMIT 1").Scan(&version)
+	version, legacy, err := s.schemaVersion()
 	if err != nil {
-		// Table doesn't exist or is empty, start fresh
-		version = 0
+		return err
+	}
+	if version > CurrentSchemaVersion {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, CurrentSchemaVersion)
+	}
+	if version == CurrentSchemaVersion {
+		if legacy {
+			return s.setSchemaVersion(version)
+		}
+		return nil
 	}
 
-	if version < 1 {
-		if err := s.migrateV1(); err != nil {
-			return err
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+		if _, err := s.db.Exec(m.sql); err != nil {
+			return fmt.Errorf("migrate to v%d: %w", m.version, err)
 		}
 	}
 
-	if version < 2 {
-		if err := s.migrateV2(); err != nil {
+	// Backfill rows written by an older version (fresh databases have none)
+	if version > 0 {
+		store, err := s.Load()
+		if err != nil {
+			return err
+		}
+		store.SchemaVersion = version
+		if err := Migrate(store); err != nil {
+			return err
+		}
+		if err := s.Save(store); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return s.setSchemaVersion(CurrentSchemaVersion)
 }
 
-// migrateV1 creates the initial schema.
-func (s *SQLiteStorage) migrateV1() error {
-	schema := `
-		CREATE TABLE IF NOT EXISTS schema_version (
-			version INTEGER PRIMARY KEY
-		);
-
-		CREATE TABLE IF NOT EXISTS folders (
-			id TEXT PRIMARY KEY NOT NULL,
-			name TEXT NOT NULL,
-			parent_id TEXT,
-			pinned INTEGER NOT NULL DEFAULT 0,
-			FOREIGN KEY (parent_id) REFERENCES folders(id) ON DELETE SET NULL
-		);
-
-		CREATE INDEX IF NOT EXISTS idx_folders_parent_id ON folders(parent_id);
-		CREATE INDEX IF NOT EXISTS idx_folders_pinned ON folders(pinned) WHERE pinned = 1;
-
-		CREATE TABLE IF NOT EXISTS bookmarks (
-			id TEXT PRIMARY KEY NOT NULL,
-			title TEXT NOT NULL,
-			url TEXT NOT NULL,
-			folder_id TEXT,
-			tags TEXT NOT NULL DEFAULT '[]',
-			created_at TEXT NOT NULL,
-			visited_at TEXT,
-			pinned INTEGER NOT NULL DEFAULT 0,
-			FOREIGN KEY (folder_id) REFERENCES folders(id) ON DELETE SET NULL
-		);
-
-		CREATE INDEX IF NOT EXISTS idx_bookmarks_folder_id ON bookmarks(folder_id);
-		CREATE INDEX IF NOT EXISTS idx_bookmarks_url ON bookmarks(url);
-		CREATE INDEX IF NOT EXISTS idx_bookmarks_pinned ON bookmarks(pinned) WHERE pinned = 1;
-
-		INSERT OR REPLACE INTO schema_version (version) VALUES (1);
-	`
-	_, err := s.db.Exec(schema)
-	return err
+// schemaVersion returns the database schema version.
+// Reads PRAGMA user_version, falling back to the schema_version table used by
+// databases created before user_version was set (legacy = true).
+func (s *SQLiteStorage) schemaVersion() (version int, legacy bool, err error) {
+	if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return 0, false, err
+	}
+	if version > 0 {
+		return version, false, nil
+	}
+
+	var tableVersion sql.NullInt64
+	if err := s.db.QueryRow("SELECT MAX(version) FROM schema_version").Scan(&tableVersion); err != nil || !tableVersion.Valid {
+		// Table doesn't exist or is empty, start fresh
+		return 0, false, nil
+	}
+	return int(tableVersion.Int64), true, nil
 }
 
-// migrateV2 adds pin_order column for pinned item ordering.
-func (s *SQLiteStorage) migrateV2() error {
-	migration := `
-		ALTER TABLE folders ADD COLUMN pin_order INTEGER NOT NULL DEFAULT 0;
-		ALTER TABLE bookmarks ADD COLUMN pin_order INTEGER NOT NULL DEFAULT 0;
-		UPDATE schema_version SET version = 2;
-	`
-	_, err := s.db.Exec(migration)
+// setSchemaVersion records the schema version in PRAGMA user_version and the schema_version table.
+func (s *SQLiteStorage) setSchemaVersion(version int) error {
+	if _, err := s.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", version)); err != nil {
+		return err
+	}
+	if _, err := s.db.Exec("DELETE FROM schema_version"); err != nil {
+		return err
+	}
+	_, err := s.db.Exec("INSERT INTO schema_version (version) VALUES (?)", version)
 	return err
 }
 
 // Load reads the store from the SQLite database.
 func (s *SQLiteStorage) Load() (*model.Store, error) {
 	store := &model.Store{
-		Folders:   []model.Folder{},
-		Bookmarks: []model.Bookmark{},
+		SchemaVersion: CurrentSchemaVersion,
+		Folders:       []model.Folder{},
+		Bookmarks:     []model.Bookmark{},
 	}
 
 	// Load folders
 	rows, err := s.db.Query(`
-		SELECT id, name, parent_id, pinned, pin_order
+		SELECT id, name, parent_id, pinned, pin_order, locked, view_mode
 		FROM folders
-		ORDER BY name
+		ORDER BY position
 	`)
 	if err != nil {
 		return nil, err
@@ -163,9 +173,9 @@ func (s *SQLiteStorage) Load() (*model.Store, error) {
 	for rows.Next() {
 		var f model.Folder
 		var parentID sql.NullString
-		var pinned int
+		var pinned, locked int
 
-		if err := rows.Scan(&f.ID, &f.Name, &parentID, &pinned, &f.PinOrder); err != nil {
+		if err := rows.Scan(&f.ID, &f.Name, &parentID, &pinned, &f.PinOrder, &locked, &f.ViewMode); err != nil {
 			return nil, err
 		}
 
@@ -173,6 +183,7 @@ func (s *SQLiteStorage) Load() (*model.Store, error) {
 			f.ParentID = &parentID.String
 		}
 		f.Pinned = pinned == 1
+		f.Locked = locked == 1
 
 		store.Folders = append(store.Folders, f)
 	}
@@ -183,9 +194,9 @@ func (s *SQLiteStorage) Load() (*model.Store, error) {
 
 	// Load bookmarks
 	rows, err = s.db.Query(`
-		SELECT id, title, url, folder_id, tags, created_at, visited_at, pinned, pin_order
+		SELECT id, title, url, folder_id, tags, created_at, visited_at, pinned, pin_order, snooze_until, visit_count, archived, remind_at, modified_at, priority
 		FROM bookmarks
-		ORDER BY created_at
+		ORDER BY position
 	`)
 	if err != nil {
 		return nil, err
@@ -199,10 +210,15 @@ func (s *SQLiteStorage) Load() (*model.Store, error) {
 		var createdAtStr string
 		var visitedAtStr sql.NullString
 		var pinned int
+		var snoozeUntilStr sql.NullString
+		var archived int
+		var remindAtStr sql.NullString
+		var modifiedAtStr sql.NullString
 
 		if err := rows.Scan(
 			&b.ID, &b.Title, &b.URL, &folderID,
 			&tagsJSON, &createdAtStr, &visitedAtStr, &pinned, &b.PinOrder,
+			&snoozeUntilStr, &b.VisitCount, &archived, &remindAtStr, &modifiedAtStr, &b.Priority,
 		); err != nil {
 			return nil, err
 		}
@@ -216,6 +232,13 @@ func (s *SQLiteStorage) Load() (*model.Store, error) {
 		}
 
 		b.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
+		b.ModifiedAt = b.CreatedAt
+		if modifiedAtStr.Valid {
+			t, err := time.Parse(time.RFC3339, modifiedAtStr.String)
+			if err == nil {
+				b.ModifiedAt = t
+			}
+		}
 
 		if visitedAtStr.Valid {
 			t, err := time.Parse(time.RFC3339, visitedAtStr.String)
@@ -225,6 +248,21 @@ func (s *SQLiteStorage) Load() (*model.Store, error) {
 		}
 
 		b.Pinned = pinned == 1
+		b.Archived = archived == 1
+
+		if snoozeUntilStr.Valid {
+			t, err := time.Parse(time.RFC3339, snoozeUntilStr.String)
+			if err == nil {
+				b.SnoozeUntil = &t
+			}
+		}
+
+		if remindAtStr.Valid {
+			t, err := time.Parse(time.RFC3339, remindAtStr.String)
+			if err == nil {
+				b.RemindAt = &t
+			}
+		}
 
 		store.Bookmarks = append(store.Bookmarks, b)
 	}
@@ -237,22 +275,39 @@ func (s *SQLiteStorage) Load() (*model.Store, error) {
 }
 
 // Save writes the store to the SQLite database.
-// Uses a transaction for atomicity - all or nothing.
+// The rewrite runs in a single transaction, so a failed save (e.g. disk full)
+// rolls back and leaves the existing data untouched.
 func (s *SQLiteStorage) Save(store *model.Store) error {
+	// PRAGMAs are per connection, so keep the transaction on the same one
+	ctx := context.Background()
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
 	// Temporarily disable foreign key checks for bulk insert
 	// (folders may reference parents that haven't been inserted yet)
 	// Note: PRAGMA foreign_keys cannot be changed inside a transaction
-	if _, err := s.db.Exec("PRAGMA foreign_keys = OFF"); err != nil {
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
 		return err
 	}
+	defer func() { _, _ = conn.ExecContext(ctx, "PRAGMA foreign_keys = ON") }()
 
-	tx, err := s.db.Begin()
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
-		s.db.Exec("PRAGMA foreign_keys = ON")
 		return err
 	}
 	defer tx.Rollback()
 
+	if err := writeStore(tx, store); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// writeStore clears the folders and bookmarks tables and inserts the store.
+func writeStore(tx *sql.Tx, store *model.Store) error {
 	// Clear existing data
 	if _, err := tx.Exec("DELETE FROM bookmarks"); err != nil {
 		return err
@@ -263,40 +318,46 @@ func (s *SQLiteStorage) Save(store *model.Store) error {
 
 	// Insert folders
 	folderStmt, err := tx.Prepare(`
-		INSERT INTO folders (id, name, parent_id, pinned, pin_order)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO folders (id, name, parent_id, pinned, pin_order, locked, view_mode, position)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
 	}
 	defer folderStmt.Close()
 
-	for _, f := range store.Folders {
+	// position keeps the slice order, which is the manual order
+	for i, f := range store.Folders {
 		pinned := 0
 		if f.Pinned {
 			pinned = 1
 		}
-		if _, err := folderStmt.Exec(f.ID, f.Name, f.ParentID, pinned, f.PinOrder); err != nil {
+		locked := 0
+		if f.Locked {
+			locked = 1
+		}
+		if _, err := folderStmt.Exec(f.ID, f.Name, f.ParentID, pinned, f.PinOrder, locked, f.ViewMode, i); err != nil {
 			return err
 		}
 	}
 
 	// Insert bookmarks
 	bookmarkStmt, err := tx.Prepare(`
-		INSERT INTO bookmarks (id, title, url, folder_id, tags, created_at, visited_at, pinned, pin_order)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO bookmarks (id, title, url, folder_id, tags, created_at, visited_at, pinned, pin_order, snooze_until, visit_count, archived, remind_at, modified_at, priority, position)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
 	}
 	defer bookmarkStmt.Close()
 
-	for _, b := range store.Bookmarks {
+	for i, b := range store.Bookmarks {
 		tagsJSON, _ := json.Marshal(b.Tags)
 		if b.Tags == nil {
 			tagsJSON = []byte("[]")
 		}
 		createdAt := b.CreatedAt.Format(time.RFC3339)
+		modifiedAt := b.LastModified().Format(time.RFC3339)
 
 		var visitedAt *string
 		if b.VisitedAt != nil {
@@ -309,22 +370,33 @@ func (s *SQLiteStorage) Save(store *model.Store) error {
 			pinned = 1
 		}
 
+		var snoozeUntil *string
+		if b.SnoozeUntil != nil {
+			v := b.SnoozeUntil.Format(time.RFC3339)
+			snoozeUntil = &v
+		}
+
+		archived := 0
+		if b.Archived {
+			archived = 1
+		}
+
+		var remindAt *string
+		if b.RemindAt != nil {
+			v := b.RemindAt.Format(time.RFC3339)
+			remindAt = &v
+		}
+
 		if _, err := bookmarkStmt.Exec(
 			b.ID, b.Title, b.URL, b.FolderID,
 			string(tagsJSON), createdAt, visitedAt, pinned, b.PinOrder,
+			snoozeUntil, b.VisitCount, archived, remindAt, modifiedAt, b.Priority, i,
 		); err != nil {
 			return err
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
-	// Re-enable foreign key checks
-	_, _ = s.db.Exec("PRAGMA foreign_keys = ON")
-
-	return nil
+	return pruneOrphanCaches(tx)
 }
 
 // DefaultSQLitePath returns the default SQLite database path: ~/.config/bm/bookmarks.db
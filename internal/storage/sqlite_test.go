@@ -1,6 +1,8 @@
 package storage_test
 
 import (
+	"database/sql"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -202,6 +204,102 @@ func TestSQLiteStorage_TransactionRollback(t *testing.T) {
 	}
 }
 
+func TestSQLiteStorage_FailedSaveKeepsOriginal(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "bookmarks.db")
+
+	s, err := storage.NewSQLiteStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	original := &model.Store{
+		Folders:   []model.Folder{{ID: "f1", Name: "Original"}},
+		Bookmarks: []model.Bookmark{{ID: "b1", Title: "Kept", URL: "https://kept.dev", Tags: []string{}}},
+	}
+	if err := s.Save(original); err != nil {
+		t.Fatalf("failed to save initial: %v", err)
+	}
+	if err := s.AppendCullRun(storage.CullRun{Timestamp: time.Now(), Total: 1}); err != nil {
+		t.Fatalf("failed to append cull run: %v", err)
+	}
+
+	// A duplicate primary key fails the write after the tables were cleared
+	broken := &model.Store{
+		Folders: []model.Folder{{ID: "f2", Name: "Replaced"}},
+		Bookmarks: []model.Bookmark{
+			{ID: "dup", Title: "One", URL: "https://one.dev"},
+			{ID: "dup", Title: "Two", URL: "https://two.dev"},
+		},
+	}
+	if err := s.Save(broken); err == nil {
+		t.Fatal("expected save with duplicate IDs to fail")
+	}
+
+	assertOriginal := func(s *storage.SQLiteStorage) {
+		t.Helper()
+		loaded, err := s.Load()
+		if err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+		if len(loaded.Folders) != 1 || loaded.Folders[0].Name != "Original" ||
+			len(loaded.Bookmarks) != 1 || loaded.Bookmarks[0].Title != "Kept" {
+			t.Errorf("expected original data intact, got %+v", loaded)
+		}
+		if runs, err := s.LoadCullHistory(10); err != nil || len(runs) != 1 {
+			t.Errorf("expected cull history intact, got %v (err %v)", runs, err)
+		}
+	}
+	assertOriginal(s)
+
+	// The file on disk is intact too, and later saves still work
+	s.Close()
+	reopened, err := storage.NewSQLiteStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to reopen: %v", err)
+	}
+	defer reopened.Close()
+	assertOriginal(reopened)
+
+	if err := reopened.Save(&model.Store{Folders: []model.Folder{{ID: "f3", Name: "Next"}}}); err != nil {
+		t.Fatalf("save after failure: %v", err)
+	}
+	if loaded, _ := reopened.Load(); len(loaded.Folders) != 1 || loaded.Folders[0].Name != "Next" {
+		t.Errorf("expected later save to apply, got %+v", loaded.Folders)
+	}
+}
+
+func TestSQLiteStorage_SaveKeepsSymlinkedDatabase(t *testing.T) {
+	tmpDir := t.TempDir()
+	target := filepath.Join(tmpDir, "dotfiles.db")
+	link := filepath.Join(tmpDir, "bookmarks.db")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	s, err := storage.NewSQLiteStorage(link)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+	if err := s.Save(&model.Store{Folders: []model.Folder{{ID: "f1", Name: "Kept"}}}); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	info, err := os.Lstat(link)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("expected %s to stay a symlink, got mode %v (err %v)", link, info.Mode(), err)
+	}
+	direct, err := storage.NewSQLiteStorage(target)
+	if err != nil {
+		t.Fatalf("failed to open target: %v", err)
+	}
+	defer direct.Close()
+	if loaded, err := direct.Load(); err != nil || len(loaded.Folders) != 1 {
+		t.Errorf("expected the save to land in the link target, got %+v (err %v)", loaded, err)
+	}
+}
+
 func TestSQLiteStorage_NestedFolders(t *testing.T) {
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, "nested.db")
@@ -234,7 +332,7 @@ func TestSQLiteStorage_NestedFolders(t *testing.T) {
 		t.Fatalf("expected 2 folders, got %d", len(loaded.Folders))
 	}
 
-	// Find child folder (order may vary due to ORDER BY name)
+	// Find child folder (by ID, independent of order)
 	var childFolder *model.Folder
 	for i := range loaded.Folders {
 		if loaded.Folders[i].ID == "f2" {
@@ -251,6 +349,57 @@ func TestSQLiteStorage_NestedFolders(t *testing.T) {
 	}
 }
 
+func TestSQLiteStorage_ManualOrderSurvivesRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "bookmarks.db")
+
+	s, err := storage.NewSQLiteStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	// Neither name nor creation order matches the manual order
+	now := time.Now().Truncate(time.Second)
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Zeta"},
+			{ID: "f2", Name: "Alpha"},
+			{ID: "f3", Name: "Mu"},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Newest", URL: "https://c.com", Tags: []string{}, CreatedAt: now},
+			{ID: "b2", Title: "Oldest", URL: "https://a.com", Tags: []string{}, CreatedAt: now.Add(-2 * time.Hour)},
+			{ID: "b3", Title: "Middle", URL: "https://b.com", Tags: []string{}, CreatedAt: now.Add(-time.Hour)},
+		},
+	}
+
+	// Save and load twice so the order is read back from stored positions
+	for range 2 {
+		if err := s.Save(store); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+		store, err = s.Load()
+		if err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+	}
+
+	var folderIDs, bookmarkIDs []string
+	for _, f := range store.GetFoldersInFolder(nil) {
+		folderIDs = append(folderIDs, f.ID)
+	}
+	for _, b := range store.GetBookmarksInFolder(nil, false) {
+		bookmarkIDs = append(bookmarkIDs, b.ID)
+	}
+	if strings.Join(folderIDs, ",") != "f1,f2,f3" {
+		t.Errorf("expected folder order f1,f2,f3, got %v", folderIDs)
+	}
+	if strings.Join(bookmarkIDs, ",") != "b1,b2,b3" {
+		t.Errorf("expected bookmark order b1,b2,b3, got %v", bookmarkIDs)
+	}
+}
+
 // Integration tests for import/export with SQLite storage
 
 func TestSQLiteStorage_ImportHTML(t *testing.T) {
@@ -516,3 +665,464 @@ func TestSQLiteStorage_ImportExportRoundtrip(t *testing.T) {
 		t.Error("React folder should have a parent")
 	}
 }
+
+func TestSQLiteStorage_MigratesV1Database(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "v1.db")
+
+	// Build a v1 fixture: original schema, no pin_order, no user_version
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open fixture db: %v", err)
+	}
+	fixture := `
+		CREATE TABLE schema_version (version INTEGER PRIMARY KEY);
+		CREATE TABLE folders (
+			id TEXT PRIMARY KEY NOT NULL,
+			name TEXT NOT NULL,
+			parent_id TEXT,
+			pinned INTEGER NOT NULL DEFAULT 0
+		);
+		CREATE TABLE bookmarks (
+			id TEXT PRIMARY KEY NOT NULL,
+			title TEXT NOT NULL,
+			url TEXT NOT NULL,
+			folder_id TEXT,
+			tags TEXT NOT NULL DEFAULT '[]',
+			created_at TEXT NOT NULL,
+			visited_at TEXT,
+			pinned INTEGER NOT NULL DEFAULT 0
+		);
+		INSERT INTO schema_version (version) VALUES (1);
+		INSERT INTO folders (id, name, parent_id, pinned) VALUES ('f1', 'Dev', NULL, 1);
+		INSERT INTO bookmarks (id, title, url, folder_id, tags, created_at, pinned)
+			VALUES ('b1', 'Go', 'https://go.dev', 'f1', '["go"]', '2025-01-01T00:00:00Z', 1);
+		INSERT INTO bookmarks (id, title, url, folder_id, tags, created_at, pinned)
+			VALUES ('b2', 'HN', 'https://news.ycombinator.com', NULL, '[]', '2025-01-02T00:00:00Z', 0);
+	`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+	db.Close()
+
+	s, err := storage.NewSQLiteStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open v1 database: %v", err)
+	}
+	defer s.Close()
+
+	loaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+
+	if loaded.SchemaVersion != storage.CurrentSchemaVersion {
+		t.Errorf("expected schema version %d, got %d", storage.CurrentSchemaVersion, loaded.SchemaVersion)
+	}
+	if len(loaded.Folders) != 1 || len(loaded.Bookmarks) != 2 {
+		t.Fatalf("expected 1 folder and 2 bookmarks, got %d and %d", len(loaded.Folders), len(loaded.Bookmarks))
+	}
+
+	// Pinned items get sequential pin orders, unpinned stay at 0
+	if loaded.Folders[0].PinOrder != 1 {
+		t.Errorf("expected folder pin order 1, got %d", loaded.Folders[0].PinOrder)
+	}
+	for _, b := range loaded.Bookmarks {
+		switch b.ID {
+		case "b1":
+			if b.PinOrder != 2 {
+				t.Errorf("expected pinned bookmark pin order 2, got %d", b.PinOrder)
+			}
+		case "b2":
+			if b.PinOrder != 0 {
+				t.Errorf("expected unpinned bookmark pin order 0, got %d", b.PinOrder)
+			}
+		}
+	}
+}
+
+func TestSQLiteStorage_RejectsNewerSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "future.db")
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open fixture db: %v", err)
+	}
+	if _, err := db.Exec("PRAGMA user_version = 999"); err != nil {
+		t.Fatalf("failed to set user_version: %v", err)
+	}
+	db.Close()
+
+	if _, err := storage.NewSQLiteStorage(dbPath); err == nil {
+		t.Error("expected error opening database from a newer schema version")
+	}
+}
+
+func TestMigrate_UnversionedStore(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Dev", Pinned: true},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev", Tags: nil, Pinned: true, PinOrder: 1},
+		},
+	}
+
+	if err := storage.Migrate(store); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+
+	if store.SchemaVersion != storage.CurrentSchemaVersion {
+		t.Errorf("expected schema version %d, got %d", storage.CurrentSchemaVersion, store.SchemaVersion)
+	}
+	if store.Bookmarks[0].Tags == nil {
+		t.Error("expected nil tags to be backfilled with empty slice")
+	}
+	if store.Folders[0].PinOrder != 2 {
+		t.Errorf("expected folder pin order 2 (after existing order 1), got %d", store.Folders[0].PinOrder)
+	}
+}
+
+func TestSQLiteStorage_SnoozeUntilRoundtrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "bookmarks.db")
+
+	s, err := storage.NewSQLiteStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	until := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Later", URL: "https://later.dev", Tags: []string{}, CreatedAt: time.Now(), SnoozeUntil: &until},
+			{ID: "b2", Title: "Now", URL: "https://now.dev", Tags: []string{}, CreatedAt: time.Now()},
+		},
+	}
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	loaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+
+	b1 := loaded.GetBookmarkByID("b1")
+	if b1 == nil || b1.SnoozeUntil == nil || !b1.SnoozeUntil.Equal(until) {
+		t.Errorf("expected snooze_until %v to be preserved, got %+v", until, b1)
+	}
+	if b2 := loaded.GetBookmarkByID("b2"); b2 == nil || b2.SnoozeUntil != nil {
+		t.Errorf("expected b2 to have no snooze, got %+v", b2)
+	}
+}
+
+func TestSQLiteStorage_ArchivedRoundtrip(t *testing.T) {
+	s, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "bookmarks.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Old", URL: "https://old.dev", Tags: []string{}, CreatedAt: time.Now(), Archived: true},
+			{ID: "b2", Title: "New", URL: "https://new.dev", Tags: []string{}, CreatedAt: time.Now()},
+		},
+	}
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	loaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if b1 := loaded.GetBookmarkByID("b1"); b1 == nil || !b1.Archived {
+		t.Errorf("expected b1 to stay archived, got %+v", b1)
+	}
+	if b2 := loaded.GetBookmarkByID("b2"); b2 == nil || b2.Archived {
+		t.Errorf("expected b2 not archived, got %+v", b2)
+	}
+}
+
+func TestSQLiteStorage_RemindAtRoundtrip(t *testing.T) {
+	s, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "bookmarks.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	remindAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Follow up", URL: "https://follow.dev", Tags: []string{}, CreatedAt: time.Now(), RemindAt: &remindAt},
+			{ID: "b2", Title: "Plain", URL: "https://plain.dev", Tags: []string{}, CreatedAt: time.Now()},
+		},
+	}
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	loaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if b1 := loaded.GetBookmarkByID("b1"); b1 == nil || b1.RemindAt == nil || !b1.RemindAt.Equal(remindAt) {
+		t.Errorf("expected b1 reminder at %v, got %+v", remindAt, b1)
+	}
+	if b2 := loaded.GetBookmarkByID("b2"); b2 == nil || b2.RemindAt != nil {
+		t.Errorf("expected b2 without reminder, got %+v", b2)
+	}
+}
+
+func TestSQLiteStorage_ModifiedAtRoundtrip(t *testing.T) {
+	s, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "bookmarks.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	modified := time.Date(2026, 2, 3, 8, 30, 0, 0, time.UTC)
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Edited", URL: "https://edited.dev", Tags: []string{}, CreatedAt: created, ModifiedAt: modified},
+			{ID: "b2", Title: "Untouched", URL: "https://untouched.dev", Tags: []string{}, CreatedAt: created},
+		},
+	}
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	loaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if b1 := loaded.GetBookmarkByID("b1"); b1 == nil || !b1.ModifiedAt.Equal(modified) {
+		t.Errorf("expected b1 modified at %v, got %+v", modified, b1)
+	}
+	if b2 := loaded.GetBookmarkByID("b2"); b2 == nil || !b2.ModifiedAt.Equal(created) {
+		t.Errorf("expected b2 modified at creation time %v, got %+v", created, b2)
+	}
+}
+
+func TestSQLiteStorage_LockedRoundtrip(t *testing.T) {
+	s, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "bookmarks.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Reference", Locked: true},
+			{ID: "f2", Name: "Inbox"},
+		},
+		Bookmarks: []model.Bookmark{},
+	}
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	loaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if f1 := loaded.GetFolderByID("f1"); f1 == nil || !f1.Locked {
+		t.Errorf("expected f1 to stay locked, got %+v", f1)
+	}
+	if f2 := loaded.GetFolderByID("f2"); f2 == nil || f2.Locked {
+		t.Errorf("expected f2 unlocked, got %+v", f2)
+	}
+}
+
+func TestSQLiteStorage_ViewModeRoundtrip(t *testing.T) {
+	s, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "bookmarks.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Read Later", ViewMode: model.ViewReading},
+			{ID: "f2", Name: "Dev"},
+		},
+		Bookmarks: []model.Bookmark{},
+	}
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	loaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if f1 := loaded.GetFolderByID("f1"); f1 == nil || f1.ViewMode != model.ViewReading {
+		t.Errorf("expected f1 in reading view, got %+v", f1)
+	}
+	if f2 := loaded.GetFolderByID("f2"); f2 == nil || f2.ViewMode != model.ViewColumns {
+		t.Errorf("expected f2 in the default view, got %+v", f2)
+	}
+}
+
+func TestSQLiteStorage_CullCacheRoundtrip(t *testing.T) {
+	s, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "bookmarks.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	if _, err := s.LoadCullCache(); err != storage.ErrNoCache {
+		t.Fatalf("expected ErrNoCache before saving, got %v", err)
+	}
+
+	store := &model.Store{
+		Folders: []model.Folder{},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Dead", URL: "https://dead.example", Tags: []string{}},
+			{ID: "b2", Title: "Moved", URL: "https://moved.example", Tags: []string{}},
+		},
+	}
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save store: %v", err)
+	}
+
+	saved := &storage.CullCache{
+		Timestamp: time.Date(2025, 5, 1, 12, 0, 0, 123, time.UTC),
+		Checksum:  "abc123",
+		Results: []storage.CullCacheResult{
+			{BookmarkID: "b1", Status: 2, Error: "no such host"},
+			{BookmarkID: "b2", Status: 1, StatusCode: 404},
+		},
+	}
+	if err := s.SaveCullCache(saved); err != nil {
+		t.Fatalf("failed to save cull cache: %v", err)
+	}
+
+	loaded, err := s.LoadCullCache()
+	if err != nil {
+		t.Fatalf("failed to load cull cache: %v", err)
+	}
+	if !loaded.Timestamp.Equal(saved.Timestamp) || loaded.Checksum != saved.Checksum {
+		t.Errorf("meta mismatch: got %v/%q", loaded.Timestamp, loaded.Checksum)
+	}
+	if len(loaded.Results) != 2 || loaded.Results[0] != saved.Results[0] || loaded.Results[1] != saved.Results[1] {
+		t.Errorf("results mismatch: got %+v", loaded.Results)
+	}
+
+	// Deleting a bookmark drops its cache row on the next save
+	store.RemoveBookmarkByID("b1")
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save store: %v", err)
+	}
+	loaded, err = s.LoadCullCache()
+	if err != nil {
+		t.Fatalf("failed to load cull cache: %v", err)
+	}
+	if len(loaded.Results) != 1 || loaded.Results[0].BookmarkID != "b2" {
+		t.Errorf("expected orphaned cache row to be pruned, got %+v", loaded.Results)
+	}
+}
+
+func TestSQLiteStorage_OrganizeCacheRoundtrip(t *testing.T) {
+	s, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "bookmarks.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	folderID := "f1"
+	store := &model.Store{
+		Folders: []model.Folder{{ID: folderID, Name: "Dev"}},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev", Tags: []string{"go"}},
+		},
+	}
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save store: %v", err)
+	}
+
+	saved := &storage.OrganizeCache{
+		Timestamp: time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC),
+		Checksum:  "def456",
+		Results: []storage.OrganizeCacheResult{
+			{ItemID: "b1", CurrentPath: "/", SuggestedPath: "/Dev/Go", IsNewFolder: true,
+				CurrentTags: []string{"go"}, SuggestedTags: []string{"go", "docs"}},
+			{ItemID: folderID, IsFolder: true, CurrentPath: "/", SuggestedPath: "/Code",
+				CurrentTags: []string{}, SuggestedTags: []string{}},
+		},
+	}
+	if err := s.SaveOrganizeCache(saved); err != nil {
+		t.Fatalf("failed to save organize cache: %v", err)
+	}
+
+	loaded, err := s.LoadOrganizeCache()
+	if err != nil {
+		t.Fatalf("failed to load organize cache: %v", err)
+	}
+	if !loaded.Timestamp.Equal(saved.Timestamp) || loaded.Checksum != saved.Checksum {
+		t.Errorf("meta mismatch: got %v/%q", loaded.Timestamp, loaded.Checksum)
+	}
+	if len(loaded.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(loaded.Results))
+	}
+	got := loaded.Results[0]
+	if got.ItemID != "b1" || got.SuggestedPath != "/Dev/Go" || !got.IsNewFolder || got.IsFolder ||
+		strings.Join(got.SuggestedTags, ",") != "go,docs" {
+		t.Errorf("bookmark suggestion mismatch: %+v", got)
+	}
+	if !loaded.Results[1].IsFolder || loaded.Results[1].ItemID != folderID {
+		t.Errorf("folder suggestion mismatch: %+v", loaded.Results[1])
+	}
+}
+
+func TestSQLiteStorage_CullHistoryAppends(t *testing.T) {
+	s, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "bookmarks.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	runs, err := s.LoadCullHistory(0)
+	if err != nil {
+		t.Fatalf("failed to load empty history: %v", err)
+	}
+	if len(runs) != 0 {
+		t.Fatalf("expected no runs before culling, got %+v", runs)
+	}
+
+	first := storage.CullRun{Timestamp: time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC), Total: 10, Dead: 1, Unreachable: 2}
+	second := storage.CullRun{Timestamp: time.Date(2025, 5, 8, 12, 0, 0, 0, time.UTC), Total: 12, Dead: 3, Unreachable: 0}
+	for _, run := range []storage.CullRun{first, second} {
+		if err := s.AppendCullRun(run); err != nil {
+			t.Fatalf("failed to append run: %v", err)
+		}
+	}
+
+	runs, err = s.LoadCullHistory(0)
+	if err != nil {
+		t.Fatalf("failed to load history: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 distinct runs, got %d", len(runs))
+	}
+	if !runs[0].Timestamp.Equal(first.Timestamp) || runs[0].Dead != 1 || runs[0].Unreachable != 2 {
+		t.Errorf("first run mismatch: %+v", runs[0])
+	}
+	if !runs[1].Timestamp.Equal(second.Timestamp) || runs[1].Total != 12 || runs[1].Healthy() != 9 {
+		t.Errorf("second run mismatch: %+v", runs[1])
+	}
+
+	// A limit keeps only the most recent runs
+	runs, err = s.LoadCullHistory(1)
+	if err != nil {
+		t.Fatalf("failed to load limited history: %v", err)
+	}
+	if len(runs) != 1 || !runs[0].Timestamp.Equal(second.Timestamp) {
+		t.Errorf("expected only the latest run, got %+v", runs)
+	}
+}
@@ -1,20 +1,78 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/nikbrunner/bm/internal/model"
 )
 
+// Environment variables that point bm at alternate files, e.g. a scratch database.
+// They take precedence over the default locations in ~/.config/bm.
+const (
+	EnvDBPath     = "BM_DB_PATH"
+	EnvConfigPath = "BM_CONFIG_PATH"
+)
+
 // Storage defines the interface for persisting bookmarks.
 type Storage interface {
 	Load() (*model.Store, error)
 	Save(store *model.Store) error
 }
 
-// OpenStorage opens the SQLite storage backend.
+// OpenStorage opens the SQLite storage backend at SQLitePath.
 func OpenStorage() (Storage, error) {
-	sqlitePath, err := DefaultSQLitePath()
+	sqlitePath, err := SQLitePath()
 	if err != nil {
 		return nil, err
 	}
 	return NewSQLiteStorage(sqlitePath)
 }
+
+// SQLitePath returns the database path: $BM_DB_PATH if set, otherwise DefaultSQLitePath.
+func SQLitePath() (string, error) {
+	return pathFromEnv(EnvDBPath, DefaultSQLitePath)
+}
+
+// ConfigFilePath returns the config path: $BM_CONFIG_PATH if set, otherwise DefaultConfigFilePath.
+func ConfigFilePath() (string, error) {
+	return pathFromEnv(EnvConfigPath, DefaultConfigFilePath)
+}
+
+// pathFromEnv returns the path in the environment variable name, falling back to
+// fallback when it is unset or blank. The override's parent directory is created
+// up front so a bad path fails with a clear error instead of deep inside SQLite.
+func pathFromEnv(name string, fallback func() (string, error)) (string, error) {
+	path := strings.TrimSpace(os.Getenv(name))
+	if path == "" {
+		return fallback()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return "", fmt.Errorf("%s: %s is a directory", name, path)
+	}
+	return path, nil
+}
+
+// CheckWritable reports whether bm can write the file at path: the file itself
+// if it exists, and its directory, where SQLite keeps its journal. Run at
+// startup, it catches a read-only config directory before any edits are made.
+func CheckWritable(path string) error {
+	if f, err := os.OpenFile(path, os.O_WRONLY, 0); err == nil {
+		f.Close()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s is not writable: %w", path, err)
+	}
+	dir := filepath.Dir(path)
+	probe, err := os.CreateTemp(dir, ".bm-write-check-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
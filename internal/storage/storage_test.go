@@ -0,0 +1,260 @@
+package storage_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/storage"
+)
+
+func TestOpenStorage_HonorsEnvDBPath(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "scratch", "bookmarks.db")
+	t.Setenv(storage.EnvDBPath, dbPath)
+
+	s, err := storage.OpenStorage()
+	if err != nil {
+		t.Fatalf("failed to open storage: %v", err)
+	}
+	defer s.(*storage.SQLiteStorage).Close()
+
+	store := model.NewStore()
+	store.AddBookmark(model.NewBookmark(model.NewBookmarkParams{Title: "Scratch", URL: "https://example.com"}))
+	if err := s.Save(store); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	if _, err := os.Stat(dbPath); err != nil {
+		t.Fatalf("expected database at %s: %v", dbPath, err)
+	}
+	if got := s.(*storage.SQLiteStorage).Path(); got != dbPath {
+		t.Errorf("expected storage path %s, got %s", dbPath, got)
+	}
+}
+
+func TestConfigFilePath_EnvOverridesDefault(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "alt", "config.json")
+	t.Setenv(storage.EnvConfigPath, configPath)
+
+	got, err := storage.ConfigFilePath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != configPath {
+		t.Errorf("expected %s, got %s", configPath, got)
+	}
+
+	t.Setenv(storage.EnvConfigPath, "")
+	got, err = storage.ConfigFilePath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	def, _ := storage.DefaultConfigFilePath()
+	if got != def {
+		t.Errorf("expected default %s when unset, got %s", def, got)
+	}
+}
+
+func TestSQLitePath_RejectsUncreatableParent(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The parent "directory" is a regular file, so it can't be created
+	t.Setenv(storage.EnvDBPath, filepath.Join(file, "bookmarks.db"))
+
+	if _, err := storage.SQLitePath(); err == nil {
+		t.Error("expected an error for a path whose parent can't be created")
+	}
+}
+
+func TestLoadConfig_LowercaseTagsDefaultsOn(t *testing.T) {
+	dir := t.TempDir()
+
+	missing := filepath.Join(dir, "missing.json")
+	if err := os.WriteFile(missing, []byte(`{"quickAddFolder": "Inbox"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := storage.LoadConfig(missing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.LowercaseTags {
+		t.Error("expected lowercaseTags to default to true when absent")
+	}
+
+	disabled := filepath.Join(dir, "disabled.json")
+	if err := os.WriteFile(disabled, []byte(`{"lowercaseTags": false}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err = storage.LoadConfig(disabled)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.LowercaseTags {
+		t.Error("expected explicit lowercaseTags: false to be kept")
+	}
+}
+
+func TestLoadConfig_InvalidValueFallsBackWithWarning(t *testing.T) {
+	defaults := storage.DefaultConfig()
+	tests := []struct {
+		name  string
+		json  string
+		field func(*storage.Config) string
+		want  string
+	}{
+		{"copyLinkFormat", `{"copyLinkFormat": "<{url}>"}`, func(c *storage.Config) string { return c.CopyLinkFormat }, defaults.CopyLinkFormat},
+		{"pinnedSortMode", `{"pinnedSortMode": "newest"}`, func(c *storage.Config) string { return c.PinnedSortMode }, defaults.PinnedSortMode},
+		{"breadcrumbTruncation", `{"breadcrumbTruncation": "right"}`, func(c *storage.Config) string { return c.BreadcrumbTruncation }, defaults.BreadcrumbTruncation},
+		{"dateFormat", `{"dateFormat": "dd.mm.yyyy"}`, func(c *storage.Config) string { return c.DateFormat }, defaults.DateFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(path, []byte(tt.json), 0644); err != nil {
+				t.Fatal(err)
+			}
+			config, err := storage.LoadConfig(path)
+			if err != nil {
+				t.Fatalf("expected a fallback, got error: %v", err)
+			}
+			if got := tt.field(config); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			if len(config.Warnings) != 1 || !strings.Contains(config.Warnings[0], tt.name) {
+				t.Errorf("expected one warning naming %s, got %v", tt.name, config.Warnings)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_InvalidDateFormatFallsBackToISO(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"dateFormat": "dd.mm.yyyy"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := storage.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.DateFormat != storage.DefaultDateFormat {
+		t.Errorf("expected fallback to %q, got %q", storage.DefaultDateFormat, config.DateFormat)
+	}
+	if !storage.ValidDateFormat("02.01.2006 15:04") {
+		t.Error("expected a Go layout to be valid")
+	}
+}
+
+func TestConfig_SetAlias_RejectsSubcommandNames(t *testing.T) {
+	config := storage.DefaultConfig()
+	config.Aliases = map[string]string{"gh": "b1"}
+
+	for _, name := range []string{"list", "tags", "sync", "--help"} {
+		if err := config.SetAlias(name, "b1"); err == nil || !strings.Contains(err.Error(), "command") {
+			t.Errorf("SetAlias(%q) = %v, want a command clash error", name, err)
+		}
+	}
+	if config.Aliases["gh"] != "b1" || len(config.Aliases) != 1 {
+		t.Errorf("expected a refused alias to keep the old one, got %v", config.Aliases)
+	}
+
+	if err := config.SetAlias("gl", "b1"); err != nil {
+		t.Fatalf("SetAlias(gl): %v", err)
+	}
+	if config.Aliases["gl"] != "b1" || len(config.Aliases) != 1 {
+		t.Errorf("expected gl to replace gh, got %v", config.Aliases)
+	}
+}
+
+func TestReadCacheFile_CorruptFileIsRemoved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cull-cache.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var cache storage.CullCache
+	if err := storage.ReadCacheFile(path, &cache); !errors.Is(err, storage.ErrCorruptCache) {
+		t.Fatalf("expected ErrCorruptCache, got %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected corrupt cache file to be removed")
+	}
+	if err := storage.ReadCacheFile(path, &cache); !errors.Is(err, storage.ErrNoCache) {
+		t.Errorf("expected ErrNoCache once removed, got %v", err)
+	}
+
+	// A rewrite lands in place without leaving temp files behind
+	want := storage.CullCache{Checksum: "abc", Results: []storage.CullCacheResult{{BookmarkID: "b1", Status: 1}}}
+	if err := storage.WriteCacheFile(path, &want); err != nil {
+		t.Fatalf("WriteCacheFile: %v", err)
+	}
+	if err := storage.ReadCacheFile(path, &cache); err != nil {
+		t.Fatalf("ReadCacheFile: %v", err)
+	}
+	if cache.Checksum != "abc" || len(cache.Results) != 1 {
+		t.Errorf("unexpected cache after rewrite: %+v", cache)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected only the cache file, got %d entries", len(entries))
+	}
+}
+
+func TestReadOrganizeCache_FallsBackToFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := storage.ReadOrganizeCache(nil); !errors.Is(err, storage.ErrNoCache) {
+		t.Fatalf("expected ErrNoCache without a cache file, got %v", err)
+	}
+
+	path, err := storage.OrganizeCacheFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := storage.OrganizeCache{Checksum: "abc", Results: []storage.OrganizeCacheResult{{ItemID: "b1", SuggestedPath: "/Dev"}}}
+	if err := storage.WriteCacheFile(path, &want); err != nil {
+		t.Fatalf("WriteCacheFile: %v", err)
+	}
+
+	got, err := storage.ReadOrganizeCache(nil)
+	if err != nil {
+		t.Fatalf("ReadOrganizeCache: %v", err)
+	}
+	if got.Checksum != "abc" || len(got.Results) != 1 || got.Results[0].SuggestedPath != "/Dev" {
+		t.Errorf("expected the written cache back, got %+v", got)
+	}
+}
+
+func TestTemplate_ApplySetsFolderTagsAndTitle(t *testing.T) {
+	store := &model.Store{}
+	tmpl := storage.Template{Folder: "/Work/Standups", Tags: []string{"Standup", "notes"}, TitlePrefix: "Standup: "}
+
+	b := model.NewBookmark(model.NewBookmarkParams{Title: "Monday", URL: "https://notes.example.com/1", Tags: []string{"notes", "team"}})
+	tmpl.Apply(store, &b, true)
+
+	if got := store.GetFolderPath(b.FolderID); got != "/Work/Standups" {
+		t.Errorf("expected bookmark in /Work/Standups, got %q", got)
+	}
+	if want := []string{"standup", "notes", "team"}; !slices.Equal(b.Tags, want) {
+		t.Errorf("expected tags %v, got %v", want, b.Tags)
+	}
+	if b.Title != "Standup: Monday" {
+		t.Errorf("expected prefixed title, got %q", b.Title)
+	}
+
+	// Reusing the template files into the same folder and doesn't re-prefix
+	b2 := model.NewBookmark(model.NewBookmarkParams{Title: "Standup: Tuesday", URL: "https://notes.example.com/2"})
+	tmpl.Apply(store, &b2, true)
+	if *b2.FolderID != *b.FolderID || len(store.Folders) != 2 {
+		t.Errorf("expected the existing folder to be reused, got %d folders", len(store.Folders))
+	}
+	if b2.Title != "Standup: Tuesday" {
+		t.Errorf("expected title to keep a single prefix, got %q", b2.Title)
+	}
+}
@@ -1,27 +1,29 @@
 package tui
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
-	"syscall"
 	"time"
+	"unicode"
 
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/nikbrunner/bm/internal/ai"
 	"github.com/nikbrunner/bm/internal/culler"
+	"github.com/nikbrunner/bm/internal/exporter"
+	"github.com/nikbrunner/bm/internal/fetcher"
 	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/opener"
 	"github.com/nikbrunner/bm/internal/storage"
 	"github.com/nikbrunner/bm/internal/tui/layout"
 	"github.com/sahilm/fuzzy"
@@ -31,41 +33,9 @@ import (
 var cullProgressCounter int64
 var organizeProgressCounter int64
 
-// CullCache represents the cached cull results for disk persistence.
-type CullCache struct {
-	Timestamp time.Time         `json:"timestamp"`
-	Checksum  string            `json:"checksum"` // SHA256 of bookmark data to detect changes
-	Results   []CullCacheResult `json:"results"`
-}
-
-// CullCacheResult is a serializable version of culler.Result.
-type CullCacheResult struct {
-	BookmarkID string `json:"bookmarkId"`
-	Status     int    `json:"status"` // culler.Status as int
-	StatusCode int    `json:"statusCode"`
-	Error      string `json:"error"`
-}
-
-// OrganizeCache represents the cached organize results for disk persistence.
-type OrganizeCache struct {
-	Timestamp time.Time             `json:"timestamp"`
-	Checksum  string                `json:"checksum"` // SHA256 of bookmark data to detect changes
-	Results   []OrganizeCacheResult `json:"results"`
-}
-
-// OrganizeCacheResult is a serializable version of OrganizeSuggestion.
-type OrganizeCacheResult struct {
-	ItemID        string   `json:"itemId"`
-	IsFolder      bool     `json:"isFolder"`
-	CurrentPath   string   `json:"currentPath"`
-	SuggestedPath string   `json:"suggestedPath"`
-	IsNewFolder   bool     `json:"isNewFolder"`
-	CurrentTags   []string `json:"currentTags"`
-	SuggestedTags []string `json:"suggestedTags"`
-}
-
 // aiResponseMsg is sent when the AI API call completes.
 type aiResponseMsg struct {
+	gen      int // task generation, see startTask
 	response *ai.Response
 	err      error
 }
@@ -85,6 +55,7 @@ type organizeTickMsg struct{}
 
 // organizeResultsMsg carries the final suggestions.
 type organizeResultsMsg struct {
+	gen         int // task generation, see startTask
 	suggestions []OrganizeSuggestion
 }
 
@@ -114,13 +85,32 @@ const (
 	ModeOrganizeMenu         // Menu to choose fresh vs cached organize
 	ModeOrganizeLoading      // Analyzing items for organize suggestions
 	ModeOrganizeResults      // List of suggested organization changes
+	ModeSnooze               // Duration input for snoozing a bookmark
+	ModeAlias                // Alias name input for CLI shortcut
+	ModeBulkMenu             // Menu of batch operations for the selection
+	ModeBulkTag              // Tag input for tagging the selection
+	ModeBulkExport           // Path input for exporting the selection
+	ModeConfirmLargeOp       // Confirm a paste/move that affects many items
+	ModePromote              // Folder name input for promoting a bookmark
+	ModeRemind               // Duration input for a bookmark reminder
+	ModeReminders            // List of due reminders
+	ModeConfirmPruneEmpty    // Confirm removing empty folders
+	ModeMergeTags            // Tag list for merging one tag into another
+	ModeGotoIndex            // Item number input for jumping in the current pane
+	ModePickTemplate         // Template list for adding from a config template
+	ModeDomains              // Hosts by bookmark count, drilling into a host's bookmarks
+	ModeVerifyOpen           // Checking a link before opening it, then warning if it looks dead
+	ModeOnboarding           // First-run tour of the core keys
+	ModePeek                 // Fetched title, description and image of a bookmark's page
+	ModeDetails              // Every field of a bookmark, untruncated
 )
 
 // hasTextInput returns true if the mode has an active text input where 'q' shouldn't quit.
 func (m Mode) hasTextInput() bool {
 	switch m {
 	case ModeAddBookmark, ModeAddFolder, ModeEditFolder, ModeEditBookmark,
-		ModeSearch, ModeFilter, ModeQuickAdd, ModeQuickAddConfirm, ModeMove:
+		ModeSearch, ModeFilter, ModeQuickAdd, ModeQuickAddConfirm, ModeMove, ModeSnooze, ModeAlias, ModeBulkTag, ModeBulkExport, ModePromote,
+		ModeRemind, ModeGotoIndex:
 		return true
 	}
 	return false
@@ -130,7 +120,8 @@ func (m Mode) hasTextInput() bool {
 func (m Mode) isModalView() bool {
 	switch m {
 	case ModeCullMenu, ModeCullResults, ModeCullInspect,
-		ModeOrganizeMenu, ModeOrganizeResults:
+		ModeOrganizeMenu, ModeOrganizeResults, ModeBulkMenu, ModeReminders, ModeMergeTags,
+		ModePickTemplate, ModeDomains, ModeVerifyOpen, ModeOnboarding, ModePeek, ModeDetails:
 		return true
 	}
 	return false
@@ -140,10 +131,14 @@ func (m Mode) isModalView() bool {
 type SortMode int
 
 const (
-	SortManual  SortMode = iota // preserve insertion order
-	SortAlpha                   // alphabetical
-	SortCreated                 // by creation date (newest first)
-	SortVisited                 // by visit date (most recent first)
+	SortManual   SortMode = iota // preserve insertion order
+	SortAlpha                    // alphabetical
+	SortCreated                  // by creation date (newest first)
+	SortVisited                  // by visit date (most recent first)
+	SortModified                 // by last edit (most recent first)
+	SortPriority                 // by priority (highest first)
+
+	sortModeCount = iota
 )
 
 // FocusedPane represents which pane has focus.
@@ -175,7 +170,9 @@ type openURLErrorMsg struct {
 
 // clipboardErrorMsg is sent when clipboard operation fails.
 type clipboardErrorMsg struct {
-	err error
+	err   error
+	text  string // what we tried to copy, shown as a fallback
+	label string
 }
 
 // cullProgressMsg is sent periodically during URL checking.
@@ -186,12 +183,27 @@ type cullProgressMsg struct {
 
 // cullCompleteMsg is sent when URL checking is complete.
 type cullCompleteMsg struct {
+	gen     int // task generation, see startTask
 	results []culler.Result
 }
 
 // cullTickMsg is sent periodically to update the progress display.
 type cullTickMsg struct{}
 
+// saveTickMsg is sent when a debounced save's quiet period expires.
+// Only the tick matching the latest generation triggers a write.
+type saveTickMsg struct {
+	gen int
+}
+
+// clockTickMsg is sent every minute to refresh the breadcrumb clock.
+type clockTickMsg struct{}
+
+// idleTickMsg quits bm once no key was pressed for Config.IdleQuitSeconds.
+type idleTickMsg struct {
+	gen int
+}
+
 // messageDuration is how long messages are displayed before auto-clearing.
 const messageDuration = 3 * time.Second
 
@@ -199,6 +211,7 @@ const messageDuration = 3 * time.Second
 type fuzzyMatch struct {
 	Item           Item
 	MatchedIndexes []int
+	PathIndexes    []int // byte offsets into finderPath, for sources that show it
 	Score          int
 }
 
@@ -206,18 +219,37 @@ type fuzzyMatch struct {
 type itemStrings []Item
 
 func (is itemStrings) String(i int) string {
-	return is[i].Title()
+	return is[i].SearchText()
 }
 
 func (is itemStrings) Len() int {
 	return len(is)
 }
 
+// pathItemStrings implements fuzzy.Source for finder lists that show the
+// folder path column, so a query can match the path as well.
+type pathItemStrings struct {
+	items []Item
+	paths []string
+}
+
+func (ps pathItemStrings) String(i int) string {
+	if ps.paths[i] == "" {
+		return ps.items[i].SearchText()
+	}
+	return ps.items[i].SearchText() + " " + ps.paths[i]
+}
+
+func (ps pathItemStrings) Len() int {
+	return len(ps.items)
+}
+
 // App is the main bubbletea model for the bookmark manager.
 type App struct {
 	store        *model.Store
 	storage      storage.Storage // for auto-saving after mutations
 	config       *storage.Config // app settings (quick add folder, etc.)
+	configPath   string          // for persisting config changes (aliases); empty = in-memory only
 	keys         KeyMap
 	styles       Styles
 	layoutConfig layout.LayoutConfig
@@ -233,14 +265,30 @@ type App struct {
 	// Global search (s key) and local filter (/ key)
 	search SearchState
 
-	// For gg command
-	lastKeyWasG bool
+	// Multi-key sequences (gg, gb, to, 'x), see sequence.go
+	pending    pendingSequence
+	pendingGen int // bumped on every prefix; stale timeouts are ignored
+
+	// Idle auto-quit: every key press restarts the timer and bumps idleGen,
+	// so only the tick of the last press quits
+	idleTimeout time.Duration
+	idleGen     int
+
+	// Items marked with `x, by mark; see marks.go
+	marks map[rune]string
+
+	// Last change . repeats; see repeat.go
+	lastAction repeatAction
 
-	// For toggle commands (to, tc)
-	lastKeyWasT bool
+	// Debounced save state (see saveStore)
+	saveDirty     bool // store has unsaved mutations
+	saveGen       int  // bumped on every mutation; stale ticks are ignored
+	saveScheduled bool // a save tick must be scheduled after this update
 
 	// Yank buffer (supports batch yank)
 	yankedItems []Item
+	// yankedFromCut marks the buffer as cut originals, so paste moves them instead of copying
+	yankedFromCut bool
 
 	// UI mode and modal state
 	mode  Mode
@@ -259,6 +307,54 @@ type App struct {
 	// Selection state (visual mode)
 	selection SelectionState
 
+	// Bulk actions menu state (: with a selection)
+	bulk BulkState
+
+	// Paste/move waiting for confirmation because it affects many items
+	largeOp LargeOpState
+
+	// Bookmark IDs flagged dead/unreachable by the last cull, for the breadcrumb indicator
+	brokenIDs map[string]bool
+
+	// Cursor in the due reminders list (ModeReminders)
+	reminderCursor int
+
+	// Tag merge picker (ModeMergeTags)
+	tagMerge TagMergeState
+
+	// Cursor in the template picker (ModePickTemplate)
+	templateCursor int
+
+	// Domains view (ModeDomains)
+	domains DomainsState
+
+	// Bookmark waiting on a liveness check before opening (ModeVerifyOpen)
+	verifyOpen  VerifyOpenState
+	linkChecker func(model.Bookmark) culler.Result
+
+	// Bookmark whose page metadata is shown (ModePeek)
+	peek      PeekState
+	fetchPage func(context.Context, string) (fetcher.Meta, error)
+	// suggest replaces ai.Client.SuggestBookmark for quick add when set
+	suggest func(ctx context.Context, url, storeContext string) (*ai.Response, error)
+
+	// Bookmark shown in full (ModeDetails)
+	detailsID string
+
+	// Clipboard access; clipboardOK is detected once at startup and cleared
+	// on the first failure so headless/SSH sessions degrade quietly.
+	clipboard       Clipboard
+	clipboardOK     bool
+	clipboardWarned bool // one-time "clipboard unavailable" message shown
+
+	// AI client construction; never called while aiDisabled
+	aiClient   func() (*ai.Client, error)
+	aiDisabled bool
+
+	// Running cull or AI task; Esc cancels it, see startTask
+	taskGen    int
+	taskCancel context.CancelFunc
+
 	// Cull state
 	cull CullState
 
@@ -267,10 +363,16 @@ type App struct {
 
 	// Settings
 	confirmDelete bool // true = ask confirmation before delete (default true)
+	folderStats   bool // true = preview a folder's stats instead of its children
+	itemNumbers   bool // true = prefix items in the current pane with their index
+	showArchived  bool // true = list archived bookmarks inline (ta), for this session
 
 	// Message display (for user feedback)
 	messageType MessageType // type determines styling
 	messageText string      // the message content
+	saveErr     string      // last save failure; shown until a save succeeds
+	noStorage   bool        // storage was required but missing, so nothing is saved
+	unwritable  string      // why the storage location can't be written, checked at startup
 
 	// Window dimensions
 	width  int
@@ -280,11 +382,28 @@ type App struct {
 // AppParams holds parameters for creating a new App.
 type AppParams struct {
 	Store        *model.Store
-	Storage      storage.Storage      // optional, for auto-saving after mutations
-	Config       *storage.Config      // optional, uses default if nil
-	Keys         *KeyMap              // optional, uses default if nil
-	Styles       *Styles              // optional, uses default if nil
-	LayoutConfig *layout.LayoutConfig // optional, uses default if nil
+	Storage      storage.Storage                    // optional, for auto-saving after mutations
+	Config       *storage.Config                    // optional, uses default if nil
+	ConfigPath   string                             // optional, for persisting config changes
+	Keys         *KeyMap                            // optional, uses default if nil
+	Styles       *Styles                            // optional, uses default if nil
+	LayoutConfig *layout.LayoutConfig               // optional, uses default if nil
+	Clipboard    Clipboard                          // optional, uses the system clipboard if nil
+	AIClient     func() (*ai.Client, error)         // optional, uses ai.NewClient if nil
+	NoAI         bool                               // disables AI for this session (--no-ai), like Config.DisableAI
+	LinkChecker  func(model.Bookmark) culler.Result // optional, uses culler.CheckOne if nil
+	FirstRun     bool                               // no data yet: shows the key tour unless Config.OnboardingShown
+	// RequireStorage marks a real session (bm itself rather than a test or an
+	// embedding), where a nil Storage means edits are silently lost.
+	RequireStorage bool
+	// Unwritable is the result of storage.CheckWritable on the data or config
+	// location; non-nil shows a warning for the whole session.
+	Unwritable error
+	// FetchPage reads a page's metadata for gp; optional, uses fetcher.Fetch if nil.
+	FetchPage func(context.Context, string) (fetcher.Meta, error)
+	// SuggestBookmark answers quick add instead of the AI client's own call;
+	// optional, the AI client still has to be available either way.
+	SuggestBookmark func(ctx context.Context, url, storeContext string) (*ai.Response, error)
 }
 
 // NewApp creates a new App with the given parameters.
@@ -308,10 +427,37 @@ func NewApp(params AppParams) App {
 	if params.LayoutConfig != nil {
 		layoutCfg = *params.LayoutConfig
 	}
+	if cfg.MainPaneWeight != 0 {
+		layoutCfg.Pane.MainPaneWeight = layout.ClampMainPaneWeight(cfg.MainPaneWeight, layoutCfg.Pane)
+	}
+
+	clip := params.Clipboard
+	clipOK := true
+	if clip == nil {
+		clip = systemClipboard{}
+		clipOK = systemClipboardAvailable()
+	}
+
+	aiClient := params.AIClient
+	if aiClient == nil {
+		aiClient = ai.NewClient
+	}
+
+	fetchPage := params.FetchPage
+	if fetchPage == nil {
+		fetchPage = fetcher.Fetch
+	}
+	linkChecker := params.LinkChecker
+	if linkChecker == nil {
+		linkChecker = func(b model.Bookmark) culler.Result {
+			return culler.CheckOne(b, verifyOpenTimeout, cfg.CullExcludeDomains)
+		}
+	}
 
 	app := App{
 		store:         params.Store,
 		storage:       params.Storage,
+		configPath:    params.ConfigPath,
 		config:        &cfg,
 		keys:          keys,
 		styles:        styles,
@@ -325,24 +471,68 @@ func NewApp(params AppParams) App {
 		quickAdd:      NewQuickAddState(layoutCfg),
 		move:          NewMoveState(layoutCfg),
 		selection:     NewSelectionState(),
+		bulk:          NewBulkState(layoutCfg),
 		cull:          NewCullState(),
 		organize:      NewOrganizeState(),
 		confirmDelete: true,
+		idleTimeout:   time.Duration(cfg.IdleQuitSeconds) * time.Second,
+		itemNumbers:   cfg.ShowItemNumbers,
+		showArchived:  cfg.ShowArchivedInline,
 		width:         80,
 		height:        24,
+		clipboard:     clip,
+		clipboardOK:   clipOK,
+		aiClient:      aiClient,
+		aiDisabled:    cfg.DisableAI || params.NoAI,
+		linkChecker:   linkChecker,
+		fetchPage:     fetchPage,
+		suggest:       params.SuggestBookmark,
+		noStorage:     params.RequireStorage && params.Storage == nil,
+	}
+	if params.Unwritable != nil {
+		app.unwritable = params.Unwritable.Error()
+	}
+
+	if app.storage != nil {
+		app.loadBrokenIDs()
 	}
 
 	app.refreshItems()
 	app.refreshPinnedItems()
 
+	if app.store != nil {
+		if due := len(app.store.GetDueReminders(time.Now())); due > 0 {
+			app.setStatus(reminderSummary(due) + " - press N to review")
+		}
+	}
+	if len(cfg.Warnings) > 0 {
+		app.setMessage(MessageWarning, "Config "+strings.Join(cfg.Warnings, "; "))
+	}
+
 	// Start focused on pinned pane if there are pinned items
 	if len(app.pinnedItems) > 0 {
 		app.focusedPane = PanePinned
 	}
 
+	if params.FirstRun && !cfg.OnboardingShown {
+		app.mode = ModeOnboarding
+	}
+
 	return app
 }
 
+// sortedFolders returns the subfolders of parentID in display order:
+// alphabetical in SortAlpha, manual order otherwise.
+func (a *App) sortedFolders(parentID *string) []model.Folder {
+	folders := a.store.GetFoldersInFolder(parentID)
+	if a.browser.SortMode == SortAlpha {
+		sort.Slice(folders, func(i, j int) bool {
+			return strings.ToLower(folders[i].Name) < strings.ToLower(folders[j].Name)
+		})
+	}
+	return folders
+}
+
 // refreshItems rebuilds the items slice based on current folder and sort mode.
 func (a *App) refreshItems() {
 	a.browser.Items = []Item{}
@@ -353,16 +543,19 @@ func (a *App) refreshItems() {
 	a.selection.Reset()
 
 	// Get folders and bookmarks
-	folders := a.store.GetFoldersInFolder(a.browser.CurrentFolderID)
-	bookmarks := a.store.GetBookmarksInFolder(a.browser.CurrentFolderID)
+	folders := a.sortedFolders(a.browser.CurrentFolderID)
+	bookmarks := a.store.GetBookmarksInFolder(a.browser.CurrentFolderID, a.showArchived)
+
+	// Flat and reading views list the whole subtree's bookmarks, no folders
+	viewMode := a.currentViewMode()
+	if viewMode != model.ViewColumns {
+		folders = nil
+		bookmarks = a.store.GetBookmarksInSubtree(*a.browser.CurrentFolderID, a.showArchived)
+	}
 
 	// Apply sorting based on current mode
 	switch a.browser.SortMode {
 	case SortAlpha:
-		// Sort folders alphabetically
-		sort.Slice(folders, func(i, j int) bool {
-			return strings.ToLower(folders[i].Name) < strings.ToLower(folders[j].Name)
-		})
 		// Sort bookmarks alphabetically
 		sort.Slice(bookmarks, func(i, j int) bool {
 			return strings.ToLower(bookmarks[i].Title) < strings.ToLower(bookmarks[j].Title)
@@ -374,6 +567,18 @@ func (a *App) refreshItems() {
 			return bookmarks[i].CreatedAt.After(bookmarks[j].CreatedAt)
 		})
 
+	case SortModified:
+		// Sort bookmarks by last edit (most recent first)
+		sort.SliceStable(bookmarks, func(i, j int) bool {
+			return bookmarks[i].LastModified().After(bookmarks[j].LastModified())
+		})
+
+	case SortPriority:
+		// Sort bookmarks by priority (highest first), keeping manual order within a level
+		sort.SliceStable(bookmarks, func(i, j int) bool {
+			return bookmarks[i].Priority > bookmarks[j].Priority
+		})
+
 	case SortVisited:
 		// Sort bookmarks by visit date (most recent first, never visited at end)
 		sort.Slice(bookmarks, func(i, j int) bool {
@@ -391,6 +596,16 @@ func (a *App) refreshItems() {
 	}
 	// SortManual: keep insertion order (no sorting)
 
+	if viewMode == model.ViewReading {
+		// Reading queue: unvisited first, oldest first within each group
+		sort.SliceStable(bookmarks, func(i, j int) bool {
+			if (bookmarks[i].VisitedAt == nil) != (bookmarks[j].VisitedAt == nil) {
+				return bookmarks[i].VisitedAt == nil
+			}
+			return bookmarks[i].CreatedAt.Before(bookmarks[j].CreatedAt)
+		})
+	}
+
 	// Add folders first (folders always before bookmarks)
 	for i := range folders {
 		a.browser.Items = append(a.browser.Items, Item{
@@ -408,6 +623,43 @@ func (a *App) refreshItems() {
 	}
 }
 
+// currentViewMode returns the view mode of the folder shown in the browser.
+// The root always uses columns.
+func (a *App) currentViewMode() string {
+	if a.browser.CurrentFolderID == nil {
+		return model.ViewColumns
+	}
+	if f := a.store.GetFolderByID(*a.browser.CurrentFolderID); f != nil {
+		return f.ViewMode
+	}
+	return model.ViewColumns
+}
+
+// cycleViewMode moves the current folder to its next view mode (tv) and saves it.
+func (a *App) cycleViewMode() tea.Cmd {
+	if a.browser.CurrentFolderID == nil {
+		return a.setMessage(MessageInfo, "Open a folder to change its view")
+	}
+	mode, err := a.store.CycleFolderViewMode(*a.browser.CurrentFolderID)
+	if err != nil {
+		return a.setMessage(MessageError, err.Error())
+	}
+	a.saveStore()
+	a.refreshItems()
+	if a.browser.Cursor >= len(a.browser.Items) {
+		a.browser.Cursor = max(len(a.browser.Items)-1, 0)
+	}
+	return a.setMessage(MessageInfo, "View: "+viewModeLabel(mode))
+}
+
+// viewModeLabel names a folder view mode for messages and the status line.
+func viewModeLabel(mode string) string {
+	if mode == model.ViewColumns {
+		return "columns"
+	}
+	return mode
+}
+
 // refreshPinnedItems rebuilds the pinnedItems slice from the store, sorted by PinOrder.
 func (a *App) refreshPinnedItems() {
 	a.pinnedItems = []Item{}
@@ -441,18 +693,61 @@ func (a *App) refreshPinnedItems() {
 		return ordered[i].order < ordered[j].order
 	})
 
+	// Optionally regroup; stable so ties keep their pin order
+	switch a.config.PinnedSortMode {
+	case storage.PinnedSortAlpha:
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return strings.ToLower(ordered[i].item.Title()) < strings.ToLower(ordered[j].item.Title())
+		})
+	case storage.PinnedSortFoldersFirst:
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return ordered[i].item.IsFolder() && !ordered[j].item.IsFolder()
+		})
+	}
+
 	// Extract items in order
 	for _, o := range ordered {
 		a.pinnedItems = append(a.pinnedItems, o.item)
 	}
+
+	a.applyPinnedFilter()
+}
+
+// applyPinnedFilter narrows the pinned pane to items fuzzy-matching the pinned filter.
+// The underlying pin order is untouched.
+func (a *App) applyPinnedFilter() {
+	if a.search.PinnedFilterQuery == "" {
+		a.search.PinnedFilteredItems = nil
+		return
+	}
+
+	matches := fuzzy.FindFrom(a.search.PinnedFilterQuery, itemStrings(a.pinnedItems))
+	a.search.PinnedFilteredItems = make([]Item, len(matches))
+	for i, m := range matches {
+		a.search.PinnedFilteredItems[i] = a.pinnedItems[m.Index]
+	}
+
+	// Reset cursor if out of bounds
+	if a.pinnedCursor >= len(a.search.PinnedFilteredItems) {
+		a.pinnedCursor = 0
+	}
+}
+
+// getDisplayPinnedItems returns filtered pinned items if a pinned filter is active, otherwise all.
+func (a *App) getDisplayPinnedItems() []Item {
+	if a.search.PinnedFilterQuery != "" && a.search.PinnedFilteredItems != nil {
+		return a.search.PinnedFilteredItems
+	}
+	return a.pinnedItems
 }
 
 // selectedPinnedItem returns the currently selected pinned item, or nil if none.
 func (a *App) selectedPinnedItem() *Item {
-	if len(a.pinnedItems) == 0 || a.pinnedCursor >= len(a.pinnedItems) {
+	pinnedItems := a.getDisplayPinnedItems()
+	if len(pinnedItems) == 0 || a.pinnedCursor >= len(pinnedItems) {
 		return nil
 	}
-	return &a.pinnedItems[a.pinnedCursor]
+	return &pinnedItems[a.pinnedCursor]
 }
 
 // buildFolderStack builds the folder stack from root to the given parent folder.
@@ -460,32 +755,38 @@ func (a *App) buildFolderStack(parentID *string) {
 	if parentID == nil {
 		return
 	}
-	// Build path from root to parent
-	var path []string
-	currentID := parentID
-	for currentID != nil {
-		folder := a.store.GetFolderByID(*currentID)
-		if folder == nil {
-			break
-		}
-		path = append([]string{folder.ID}, path...) // prepend
-		currentID = folder.ParentID
-	}
-	a.browser.FolderStack = path
+	a.browser.FolderStack = a.store.FolderAncestry(parentID)
+}
+
+// exceedsMaxDepth reports whether placing a folder subtree of the given
+// height under parentID would nest deeper than the configured maximum.
+func (a *App) exceedsMaxDepth(parentID *string, height int) bool {
+	limit := a.config.MaxFolderDepth
+	return limit > 0 && a.store.FolderDepth(parentID)+height > limit
+}
+
+// maxDepthMessage is shown when an action is refused by MaxFolderDepth.
+func (a *App) maxDepthMessage() string {
+	return "Max folder depth (" + strconv.Itoa(a.config.MaxFolderDepth) + ") reached"
 }
 
+// maxRecentlyVisited caps the recently visited list (H).
+const maxRecentlyVisited = 100
+
 // getItemsForSource returns items based on the given list source.
 func (a *App) getItemsForSource(source ListSource) []Item {
 	var items []Item
 
 	switch source {
 	case SourceAll:
-		// All items (folders + bookmarks) for global search
-		for i := range a.store.Folders {
-			items = append(items, Item{
-				Kind:   ItemFolder,
-				Folder: &a.store.Folders[i],
-			})
+		// All items for global search, folders unless the finder leaves them out
+		if a.search.IncludeFolders {
+			for i := range a.store.Folders {
+				items = append(items, Item{
+					Kind:   ItemFolder,
+					Folder: &a.store.Folders[i],
+				})
+			}
 		}
 		for i := range a.store.Bookmarks {
 			items = append(items, Item{
@@ -496,9 +797,14 @@ func (a *App) getItemsForSource(source ListSource) []Item {
 
 	case SourceRecent:
 		// Bookmarks only, sorted by CreatedAt descending
-		// First collect all bookmarks
-		bookmarks := make([]model.Bookmark, len(a.store.Bookmarks))
-		copy(bookmarks, a.store.Bookmarks)
+		// First collect all bookmarks that aren't snoozed
+		now := time.Now()
+		var bookmarks []model.Bookmark
+		for _, b := range a.store.Bookmarks {
+			if !b.IsSnoozed(now) && (a.showArchived || !b.Archived) {
+				bookmarks = append(bookmarks, b)
+			}
+		}
 
 		// Sort by CreatedAt descending (newest first)
 		sort.Slice(bookmarks, func(i, j int) bool {
@@ -512,11 +818,57 @@ func (a *App) getItemsForSource(source ListSource) []Item {
 				Bookmark: &bookmarks[i],
 			})
 		}
+
+	case SourceUntitled:
+		// Bookmarks still titled with their URL, pointing into the store so
+		// edits (Ctrl+E) apply directly
+		for i := range a.store.Bookmarks {
+			if b := &a.store.Bookmarks[i]; b.IsUntitled() && !b.Archived {
+				items = append(items, Item{
+					Kind:     ItemBookmark,
+					Bookmark: b,
+				})
+			}
+		}
+
+	case SourceRecentlyVisited:
+		// Bookmarks actually opened, newest visit first
+		bookmarks := a.store.RecentlyVisited(maxRecentlyVisited)
+		for i := range bookmarks {
+			items = append(items, Item{
+				Kind:     ItemBookmark,
+				Bookmark: &bookmarks[i],
+			})
+		}
 	}
 
 	return items
 }
 
+// goToFuzzyMatch closes the finder and shows the highlighted result in the
+// browser: a folder is entered, a bookmark selected in its folder.
+func (a App) goToFuzzyMatch() (tea.Model, tea.Cmd) {
+	if len(a.search.FuzzyMatches) > 0 && a.search.FuzzyCursor < len(a.search.FuzzyMatches) {
+		selectedItem := a.search.FuzzyMatches[a.search.FuzzyCursor].Item
+
+		if selectedItem.IsFolder() {
+			// Navigate into the selected folder
+			folderID := selectedItem.Folder.ID
+			a.browser.FolderStack = []string{}
+			a.buildFolderStack(selectedItem.Folder.ParentID)
+			a.browser.CurrentFolderID = &folderID
+			a.browser.Cursor = 0
+			a.refreshItems()
+		} else {
+			a.revealBookmark(selectedItem.Bookmark)
+		}
+	}
+	a.mode = ModeNormal
+	a.search.FuzzyMatches = nil
+	a.search.AllItems = nil
+	return a, nil
+}
+
 // updateFuzzyMatches performs fuzzy matching on allItems with the current query.
 func (a *App) updateFuzzyMatches() {
 	query := a.search.Input.Value()
@@ -750,6 +1102,30 @@ func (a *App) bookmarkMatchesTags(b *model.Bookmark, filterTags []string, mode T
 	return true
 }
 
+// finderPath returns the folder path shown next to a bookmark in the finder,
+// without the leading slash, or "" for folders and root-level bookmarks.
+func (a *App) finderPath(item Item) string {
+	if item.IsFolder() || item.Bookmark.FolderID == nil {
+		return ""
+	}
+	return strings.TrimPrefix(a.store.GetFolderPath(item.Bookmark.FolderID), "/")
+}
+
+// splitPathMatch builds a fuzzyMatch from indexes into a pathItemStrings
+// entry, moving those past the item's own search text onto the path.
+func splitPathMatch(item Item, indexes []int, score int) fuzzyMatch {
+	match := fuzzyMatch{Item: item, Score: score}
+	pathStart := len(item.SearchText()) + 1
+	for _, idx := range indexes {
+		if idx >= pathStart {
+			match.PathIndexes = append(match.PathIndexes, idx-pathStart)
+		} else {
+			match.MatchedIndexes = append(match.MatchedIndexes, idx)
+		}
+	}
+	return match
+}
+
 // updateFuzzyMatchesWithTagFilter applies fuzzy matching and tag filtering.
 func (a *App) updateFuzzyMatchesWithTagFilter() {
 	query := a.search.Input.Value()
@@ -762,6 +1138,17 @@ func (a *App) updateFuzzyMatchesWithTagFilter() {
 		for i, item := range a.search.AllItems {
 			baseMatches[i] = fuzzyMatch{Item: item}
 		}
+	} else if a.search.Source != SourceAll {
+		// These sources show the folder path, so it is searched too
+		src := pathItemStrings{items: a.search.AllItems, paths: make([]string, len(a.search.AllItems))}
+		for i, item := range a.search.AllItems {
+			src.paths[i] = a.finderPath(item)
+		}
+		matches := fuzzy.FindFrom(query, src)
+		baseMatches = make([]fuzzyMatch, len(matches))
+		for i, m := range matches {
+			baseMatches[i] = splitPathMatch(src.items[m.Index], m.MatchedIndexes, m.Score)
+		}
 	} else {
 		matches := fuzzy.FindFrom(query, itemStrings(a.search.AllItems))
 		baseMatches = make([]fuzzyMatch, len(matches))
@@ -805,6 +1192,21 @@ func (a App) Cursor() int {
 	return a.browser.Cursor
 }
 
+// PinnedCursor returns the cursor position in the pinned pane.
+func (a App) PinnedCursor() int {
+	return a.pinnedCursor
+}
+
+// PinnedItems returns the pinned items currently shown (filtered if a pinned filter is active).
+func (a App) PinnedItems() []Item {
+	return a.getDisplayPinnedItems()
+}
+
+// FocusedPane returns which pane has focus.
+func (a App) FocusedPane() FocusedPane {
+	return a.focusedPane
+}
+
 // CurrentFolderID returns the ID of the current folder (nil for root).
 func (a App) CurrentFolderID() *string {
 	return a.browser.CurrentFolderID
@@ -833,6 +1235,12 @@ func (a App) HasYankedItems() bool {
 	return len(a.yankedItems) > 0
 }
 
+// SaveErr returns the error of the last failed save, or "" if the store was
+// saved (or nothing needed saving) since.
+func (a App) SaveErr() string {
+	return a.saveErr
+}
+
 // Mode returns the current UI mode.
 func (a App) Mode() Mode {
 	return a.mode
@@ -866,6 +1274,13 @@ func (a App) WithDimensions(width, height int) App {
 	return a
 }
 
+// WithIdleTimeout returns a copy of the App that quits after d without a
+// key press, overriding Config.IdleQuitSeconds. Used for testing.
+func (a App) WithIdleTimeout(d time.Duration) App {
+	a.idleTimeout = d
+	return a
+}
+
 // setMessage sets a status message with the given type.
 // Returns a command to auto-clear the message after messageDuration.
 func (a *App) setMessage(t MessageType, msg string) tea.Cmd {
@@ -876,6 +1291,41 @@ func (a *App) setMessage(t MessageType, msg string) tea.Cmd {
 	})
 }
 
+// flushSave writes pending debounced changes immediately.
+func (a *App) flushSave() {
+	if !a.saveDirty || a.storage == nil {
+		return
+	}
+	a.saveDirty = false
+	a.recordSaveResult(a.storage.Save(a.store))
+}
+
+// recordSaveResult keeps a failed save on screen (see saveErrorLine) until a
+// later save succeeds, since the store then only lives in memory.
+func (a *App) recordSaveResult(err error) {
+	if err != nil {
+		a.saveErr = err.Error()
+		a.setMessage(MessageError, "Save failed: "+err.Error())
+		return
+	}
+	if a.saveErr != "" {
+		a.saveErr = ""
+		a.setMessage(MessageSuccess, "Changes saved")
+	}
+}
+
+// takeSaveTick returns the command for a save requested during this update, if any.
+func (a *App) takeSaveTick() tea.Cmd {
+	if !a.saveScheduled {
+		return nil
+	}
+	a.saveScheduled = false
+	gen := a.saveGen
+	return tea.Tick(time.Duration(a.config.SaveDebounceMs)*time.Millisecond, func(time.Time) tea.Msg {
+		return saveTickMsg{gen: gen}
+	})
+}
+
 // clearMessage clears the current message.
 func (a *App) clearMessage() {
 	a.messageType = MessageNone
@@ -905,11 +1355,57 @@ func (a App) FuzzyCursor() int {
 
 // Init implements tea.Model.
 func (a App) Init() tea.Cmd {
-	return nil
+	var clock tea.Cmd
+	if a.config.ShowClock {
+		clock = clockTickCmd()
+	}
+	return tea.Batch(clock, a.idleTickCmd())
+}
+
+// idleTickCmd starts the idle timer for the current idleGen, if enabled.
+func (a App) idleTickCmd() tea.Cmd {
+	if a.idleTimeout <= 0 {
+		return nil
+	}
+	gen := a.idleGen
+	return tea.Tick(a.idleTimeout, func(time.Time) tea.Msg {
+		return idleTickMsg{gen: gen}
+	})
+}
+
+// clockTickCmd waits for the next full minute so the clock changes on time.
+func clockTickCmd() tea.Cmd {
+	return tea.Every(time.Minute, func(time.Time) tea.Msg {
+		return clockTickMsg{}
+	})
 }
 
 // Update implements tea.Model.
+// Mutations that requested a debounced save get their save tick scheduled here,
+// and key presses restart the idle timer (Config.IdleQuitSeconds).
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var idle tea.Cmd
+	if _, ok := msg.(tea.KeyMsg); ok && a.idleTimeout > 0 {
+		a.idleGen++
+		idle = a.idleTickCmd()
+	}
+	m, cmd := a.update(msg)
+	cmd = tea.Batch(cmd, idle)
+	switch app := m.(type) {
+	case App:
+		if tick := app.takeSaveTick(); tick != nil {
+			return app, tea.Batch(cmd, tick)
+		}
+		return app, cmd
+	case *App:
+		if tick := app.takeSaveTick(); tick != nil {
+			return app, tea.Batch(cmd, tick)
+		}
+	}
+	return m, cmd
+}
+
+func (a App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
@@ -921,21 +1417,70 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.clearMessage()
 		return a, nil
 
+	case clockTickMsg:
+		// Re-render happens after every message; just keep ticking
+		return a, clockTickCmd()
+
+	case idleTickMsg:
+		// A later key press restarted the timer; only its tick quits
+		if msg.gen != a.idleGen {
+			return a, nil
+		}
+		a.flushSave()
+		return a, tea.Quit
+
+	case sequenceTimeoutMsg:
+		// Only the latest prefix times out; it may have been completed already
+		if msg.gen == a.pendingGen {
+			a.pending = pendingSequence{}
+		}
+		return a, nil
+
+	case saveTickMsg:
+		// Only the latest tick writes; earlier ones were superseded by newer mutations
+		if msg.gen == a.saveGen {
+			a.flushSave()
+		}
+		return a, nil
+
 	case openURLErrorMsg:
 		// Failed to open URL in browser
 		cmd := a.setMessage(MessageError, "Failed to open URL: "+msg.err.Error())
 		return a, cmd
 
 	case clipboardErrorMsg:
-		// Failed to write to clipboard
-		cmd := a.setMessage(MessageError, "Failed to copy to clipboard: "+msg.err.Error())
+		// Failed to write to clipboard - stop trying and show the text instead
+		a.clipboardOK = false
+		a.clipboardWarned = true
+		cmd := a.setMessage(MessageInfo, "Clipboard unavailable - "+msg.label+": "+msg.text)
+		return a, cmd
+
+	case terminalBrowserFinishedMsg:
+		// Terminal browser closed - refresh visit info shown in the lists
+		a.refreshItems()
+		a.refreshPinnedItems()
+		if msg.err != nil {
+			cmd := a.setMessage(MessageError, "Terminal browser failed: "+msg.err.Error())
+			return a, cmd
+		}
+		return a, nil
+
+	case editorFinishedMsg:
+		// External editor closed - write result back into the modal
+		cmd := a.applyEditorResult(msg)
 		return a, cmd
 
 	case clipboardSuccessMsg:
 		// Successfully copied to clipboard
-		cmd := a.setMessage(MessageSuccess, "URL copied to clipboard")
+		cmd := a.setMessage(MessageSuccess, msg.label+" copied to clipboard")
 		return a, cmd
 
+	case verifyOpenMsg:
+		return a.handleVerifyOpen(msg)
+
+	case peekMsg:
+		return a.handlePeek(msg)
+
 	case cullProgressMsg:
 		// Update progress during URL checking
 		a.cull.Progress = msg.completed
@@ -962,6 +1507,10 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case organizeResultsMsg:
+		if msg.gen != a.taskGen {
+			return a, nil // from a cancelled run
+		}
+		a.finishTask()
 		a.organize.Suggestions = msg.suggestions
 		// Save cache for future use
 		if len(msg.suggestions) > 0 {
@@ -979,8 +1528,14 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case cullCompleteMsg:
+		if msg.gen != a.taskGen {
+			return a, nil // from a cancelled run
+		}
+		a.finishTask()
 		// URL checking is complete - save cache
 		_ = a.saveCullCache(msg.results)
+		_ = a.recordCullRun(msg.results)
+		a.setBrokenIDs(msg.results)
 		a.cull.HasCache = true
 		a.cull.CacheTime = time.Now()
 
@@ -991,15 +1546,29 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(a.cull.Groups) == 0 {
 			// No dead/unreachable links found
 			a.mode = ModeNormal
-			cmd := a.setMessage(MessageSuccess, "All bookmarks healthy!")
+			healthy := "All bookmarks healthy!"
+			if skipped := countCullSkipped(msg.results); skipped > 0 {
+				healthy = "All checked bookmarks healthy (" + strconv.Itoa(skipped) + " skipped by cull-ignore)"
+			}
+			cmd := a.setMessage(MessageSuccess, healthy)
 			return a, cmd
 		}
 		a.mode = ModeCullResults
 		return a, nil
 
 	case aiResponseMsg:
+		if msg.gen != a.taskGen {
+			return a, nil // from a cancelled capture
+		}
+		a.finishTask()
 		// Handle AI response for quick add
 		if a.mode == ModeQuickAddLoading {
+			if msg.err != nil && a.quickAdd.Response != nil {
+				// A regeneration failed: keep the previous suggestion
+				a.mode = ModeQuickAddConfirm
+				cmd := a.setMessage(MessageError, "Regenerate failed: "+msg.err.Error())
+				return a, cmd
+			}
 			if msg.err != nil {
 				// AI failed - save to "To Review" with URL as title
 				a.quickAdd.Error = msg.err
@@ -1033,13 +1602,18 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.modal.TagsInput.Reset()
 			a.modal.TagsInput.SetValue(strings.Join(msg.response.Tags, ", "))
 
+			// Validate the AI suggested folder, falling back to the current folder (or root)
+			aiPath, ok := model.NormalizeFolderPath(msg.response.FolderPath)
+			if !ok {
+				aiPath = a.store.GetFolderPath(a.browser.CurrentFolderID)
+			}
+
 			// Build folder picker options with smart ordering
-			a.quickAdd.Folders = a.buildOrderedFolderPaths(a.browser.CurrentFolderID, msg.response.FolderPath)
+			a.quickAdd.Folders = a.buildOrderedFolderPaths(a.browser.CurrentFolderID, aiPath)
 			a.quickAdd.FilteredFolders = a.quickAdd.Folders
 			a.quickAdd.FilterInput.Reset()
 
-			// Find the AI suggested folder (normalize path)
-			aiPath := "/" + strings.TrimPrefix(msg.response.FolderPath, "/")
+			// Preselect the AI suggested folder
 			a.quickAdd.FolderIdx = a.findFolderIndex(aiPath)
 
 			a.modal.TitleInput.Focus()
@@ -1070,7 +1644,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				// AI succeeded
 				title = msg.response.Title
-				tags = msg.response.Tags
+				tags = a.normalizeTags(msg.response.Tags)
 			}
 
 			newBookmark := model.NewBookmark(model.NewBookmarkParams{
@@ -1094,6 +1668,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// Handle q to quit globally (except when in text input mode or modal views)
 		if key.Matches(msg, a.keys.Quit) && !a.mode.hasTextInput() && !a.mode.isModalView() {
+			a.flushSave()
 			return a, tea.Quit
 		}
 
@@ -1102,23 +1677,45 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a.updateModal(msg)
 		}
 
+		// Complete a pending sequence (gg, gi, to, 'x) before other bindings claim the letter
+		if a.pending.prefix != prefixNone {
+			if model, cmd, handled := a.completeSequence(msg); handled {
+				return model, cmd
+			}
+			a.pending = pendingSequence{}
+		}
+
 		// Handle global keys (work in any pane, normal mode only)
 		if key.Matches(msg, a.keys.Help) {
 			a.mode = ModeHelp
 			return a, nil
 		}
 
+		if key.Matches(msg, a.keys.WidenPanes) {
+			return a.adjustMainPaneWeight(mainPaneWeightStep)
+		}
+		if key.Matches(msg, a.keys.NarrowPanes) {
+			return a.adjustMainPaneWeight(-mainPaneWeightStep)
+		}
+
 		// Handle 0 key globally - jump to pinned pane
 		if msg.String() == "0" && len(a.pinnedItems) > 0 {
 			a.focusedPane = PanePinned
 			return a, nil
 		}
 
+		// Tab switches panes without entering folders or opening bookmarks
+		if key.Matches(msg, a.keys.FocusPane) {
+			a.cycleFocus()
+			return a, nil
+		}
+
 		switch {
 		case key.Matches(msg, a.keys.Search):
 			// Open fuzzy finder mode with GLOBAL search (all items)
 			a.mode = ModeSearch
 			a.search.Source = SourceAll
+			a.search.IncludeFolders = !a.config.SearchBookmarksOnly
 			a.search.Input.Reset()
 			a.search.Input.Focus()
 			a.search.FuzzyCursor = 0
@@ -1137,52 +1734,92 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.updateFuzzyMatchesWithTagFilter()
 			return a, a.search.Input.Focus()
 
-		case key.Matches(msg, a.keys.Filter):
-			// Open local filter for current folder
-			a.mode = ModeFilter
-			a.search.FilterInput.Reset()
-			a.search.FilterInput.SetValue(a.search.FilterQuery) // Restore previous filter
-			a.search.FilterInput.Focus()
-			return a, a.search.FilterInput.Focus()
-
-		case key.Matches(msg, a.keys.QuickAdd):
-			// AI-powered quick add
-			a.mode = ModeQuickAdd
-			a.quickAdd.Reset()
-			// Pre-fill with clipboard contents
-			if clipContent, err := clipboard.ReadAll(); err == nil && clipContent != "" {
-				a.quickAdd.Input.SetValue(clipContent)
+		case key.Matches(msg, a.keys.PruneEmpty):
+			if len(a.store.EmptyFolders()) == 0 {
+				return a, a.setMessage(MessageInfo, "No empty folders")
 			}
-			a.quickAdd.Input.Focus()
-			return a, a.quickAdd.Input.Focus()
+			a.mode = ModeConfirmPruneEmpty
+			return a, nil
 
-		case key.Matches(msg, a.keys.ReadLater):
-			// Quick add to Read Later from clipboard
-			clipContent, err := clipboard.ReadAll()
-			if err != nil {
-				cmd := a.setMessage(MessageError, "Failed to read clipboard")
-				return a, cmd
+		case key.Matches(msg, a.keys.Untitled):
+			// Open fuzzy finder with bookmarks that still need a title
+			untitled := a.getItemsForSource(SourceUntitled)
+			if len(untitled) == 0 {
+				return a, a.setMessage(MessageInfo, "No untitled bookmarks")
+			}
+			a.mode = ModeSearch
+			a.search.Source = SourceUntitled
+			a.search.Input.Reset()
+			a.search.Input.Focus()
+			a.search.FuzzyCursor = 0
+			a.search.AllItems = untitled
+			a.updateFuzzyMatchesWithTagFilter()
+			return a, a.search.Input.Focus()
+
+		case key.Matches(msg, a.keys.History):
+			// Open fuzzy finder with recently visited bookmarks
+			visited := a.getItemsForSource(SourceRecentlyVisited)
+			if len(visited) == 0 {
+				return a, a.setMessage(MessageInfo, "No visited bookmarks yet")
+			}
+			a.mode = ModeSearch
+			a.search.Source = SourceRecentlyVisited
+			a.search.Input.Reset()
+			a.search.Input.Focus()
+			a.search.FuzzyCursor = 0
+			a.search.AllItems = visited
+			a.updateFuzzyMatchesWithTagFilter()
+			return a, a.search.Input.Focus()
+
+		case key.Matches(msg, a.keys.Filter):
+			a.mode = ModeFilter
+			if a.focusedPane == PanePinned {
+				// Filter the pinned pane instead of the current folder
+				a.search.PinnedFilterInput.Reset()
+				a.search.PinnedFilterInput.SetValue(a.search.PinnedFilterQuery)
+				return a, a.search.PinnedFilterInput.Focus()
+			}
+			// Open local filter for current folder
+			a.search.FilterInput.Reset()
+			a.search.FilterInput.SetValue(a.search.FilterQuery) // Restore previous filter
+			a.search.FilterInput.Focus()
+			return a, a.search.FilterInput.Focus()
+
+		case key.Matches(msg, a.keys.QuickAdd):
+			if a.aiDisabled {
+				return a.startInboxCapture()
+			}
+			// AI-powered quick add
+			a.mode = ModeQuickAdd
+			a.quickAdd.Reset()
+			// Pre-fill with clipboard contents
+			if clipContent := a.readClipboard(); clipContent != "" {
+				a.quickAdd.Input.SetValue(clipContent)
+			}
+			return a, tea.Batch(a.quickAdd.Input.Focus(), a.noteClipboardUnavailable())
+
+		case key.Matches(msg, a.keys.ReadLater):
+			if a.aiDisabled {
+				return a.startInboxCapture()
+			}
+			// Quick add to Read Later from clipboard
+			clipContent := strings.TrimSpace(a.readClipboard())
+			if !a.clipboardOK {
+				// No clipboard (headless/SSH) - ask for the URL instead
+				a.mode = ModeQuickAdd
+				a.quickAdd.Reset()
+				a.quickAdd.ReadLater = true
+				return a, tea.Batch(a.quickAdd.Input.Focus(), a.noteClipboardUnavailable())
 			}
-			clipContent = strings.TrimSpace(clipContent)
 			if clipContent == "" {
 				cmd := a.setMessage(MessageError, "No URL in clipboard")
 				return a, cmd
 			}
-			// Validate URL
-			parsedURL, err := url.Parse(clipContent)
-			if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
-				cmd := a.setMessage(MessageError, "Invalid URL in clipboard")
-				return a, cmd
-			}
-			// Start AI-powered quick add to Read Later
-			a.readLaterURL = clipContent
-			a.mode = ModeReadLaterLoading
-			cmd := a.setMessage(MessageInfo, "Adding to "+a.config.QuickAddFolder+"...")
-			return a, tea.Batch(cmd, a.callAICmd(clipContent))
+			return a.startReadLater(clipContent, "Invalid URL in clipboard")
 
 		case key.Matches(msg, a.keys.Cull):
 			// Check if cache exists
-			a.checkCullCache()
+			warn := a.checkCullCache()
 			if a.cull.HasCache {
 				// Show menu to choose fresh vs cached
 				a.cull.MenuCursor = 0
@@ -1191,18 +1828,21 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			// No cache - go directly to loading
 			a.cull.Reset()
-			a.cull.Total = len(a.store.Bookmarks)
+			a.cull.Total = len(a.store.GetActiveBookmarks())
 			a.mode = ModeCullLoading
-			return a, a.startCullCmd()
+			cull := a.startCullCmd()
+			return a, tea.Batch(warn, cull)
 
 		case key.Matches(msg, a.keys.Organize):
+			if a.aiDisabled {
+				return a, a.setMessage(MessageInfo, "AI disabled")
+			}
 			// Organize: analyze current item or folder contents
 			return a.startOrganize()
 
 		case key.Matches(msg, a.keys.Toggle):
-			// Start toggle sequence (to, tc)
-			a.lastKeyWasT = true
-			return a, nil
+			// Start toggle sequence (to, tc, ta, ts)
+			return a, a.startSequence(prefixToggle, msg)
 
 		case key.Matches(msg, a.keys.AddBookmark):
 			a.mode = ModeAddBookmark
@@ -1213,10 +1853,9 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.modal.TagSuggestions = nil
 			a.modal.TagSuggestionIdx = -1
 			// Pre-fill URL from clipboard if it looks like a URL
-			if clipContent, err := clipboard.ReadAll(); err == nil {
+			if clipContent := a.readClipboard(); clipContent != "" {
 				clipContent = strings.TrimSpace(clipContent)
-				if parsedURL, err := url.Parse(clipContent); err == nil &&
-					(parsedURL.Scheme == "http" || parsedURL.Scheme == "https") {
+				if isWebURL(clipContent) {
 					a.modal.URLInput.SetValue(clipContent)
 				}
 			}
@@ -1237,122 +1876,77 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a.updatePinnedPane(msg)
 		}
 
-		// Browser pane: Handle gg sequence
-		if key.Matches(msg, a.keys.Top) {
-			if a.lastKeyWasG {
-				// This is the second g - go to top
-				a.browser.Cursor = 0
-				a.lastKeyWasG = false
-				return a, nil
-			}
-			// First g - wait for second
-			a.lastKeyWasG = true
-			return a, nil
+		if key.Matches(msg, a.keys.Jump) {
+			return a, a.startSequence(prefixJump, msg)
+		}
+		if key.Matches(msg, a.keys.Mark) {
+			return a, a.startSequence(prefixMark, msg)
 		}
 
-		// Handle toggle sequences (to, tc)
-		if a.lastKeyWasT {
-			a.lastKeyWasT = false
-			switch msg.String() {
-			case "o":
-				// Toggle order mode
-				a.browser.SortMode = (a.browser.SortMode + 1) % 4
-				a.refreshItems()
-				return a, nil
-			case "c":
-				// Toggle delete confirmation
-				a.confirmDelete = !a.confirmDelete
-				if a.confirmDelete {
-					return a, a.setMessage(MessageInfo, "Delete confirmation: ON")
-				}
-				return a, a.setMessage(MessageInfo, "Delete confirmation: OFF")
-			}
-			// Any other key after t - ignore and continue
-			return a, nil
+		// Browser pane: start a g sequence (gg, gb, gd, gv)
+		if key.Matches(msg, a.keys.Top) {
+			return a, a.startSequence(prefixG, msg)
 		}
 
 		// Handle y - yank (copy)
 		if key.Matches(msg, a.keys.Yank) {
-			a.lastKeyWasG = false
 			a.yankCurrentItem()
 			return a, nil
 		}
 
 		// Handle d - delete (without buffer)
 		if key.Matches(msg, a.keys.Delete) {
-			a.lastKeyWasG = false
+			if !a.selection.HasSelection() {
+				a.lastAction = repeatDelete
+			}
 			a.deleteCurrentItem()
 			return a, nil
 		}
 
+		// Handle . - repeat the last single-item change
+		if key.Matches(msg, a.keys.Repeat) {
+			return a.repeatLast()
+		}
+
 		// Handle x - cut (delete + buffer)
 		if key.Matches(msg, a.keys.Cut) {
-			a.lastKeyWasG = false
 			a.cutCurrentItem()
 			return a, nil
 		}
 
 		// Handle m - toggle pin
 		if key.Matches(msg, a.keys.Pin) {
-			a.lastKeyWasG = false
+			if !a.selection.HasSelection() {
+				a.lastAction = repeatPin
+			}
 			cmd := a.togglePinCurrentItem()
 			return a, cmd
 		}
 
 		// Handle M - move to folder
 		if key.Matches(msg, a.keys.Move) {
-			a.lastKeyWasG = false
-			displayItems := a.getDisplayItems()
-			if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
-				return a, nil
-			}
-
-			// Capture items to move (selected or current)
-			a.move.ItemsToMove = nil
-			if a.selection.HasSelection() {
-				for _, item := range displayItems {
-					if a.selection.IsSelected(item.ID()) {
-						a.move.ItemsToMove = append(a.move.ItemsToMove, item)
-					}
-				}
-			} else {
-				a.move.ItemsToMove = []Item{displayItems[a.browser.Cursor]}
-			}
+			return a, a.startMove()
+		}
 
-			a.mode = ModeMove
-			a.move.Folders = a.buildFolderPaths()
-			a.move.FilteredFolders = a.move.Folders // Start with all folders
-			a.move.FilterInput.Reset()
-			a.move.FilterInput.Focus()
-			// Find current location in folder list
-			item := displayItems[a.browser.Cursor]
-			currentPath := "/"
-			if item.IsFolder() && item.Folder.ParentID != nil {
-				currentPath = a.store.GetFolderPath(item.Folder.ParentID)
-			} else if !item.IsFolder() && item.Bookmark.FolderID != nil {
-				currentPath = a.store.GetFolderPath(item.Bookmark.FolderID)
-			}
-			a.move.FolderIdx = a.findMoveFolderIndex(currentPath)
-			return a, a.move.FilterInput.Focus()
+		// Handle : - bulk actions menu for the selection
+		if key.Matches(msg, a.keys.Bulk) {
+			return a, a.openBulkMenu()
 		}
 
 		// Handle v - toggle selection on current item
 		if key.Matches(msg, a.keys.Select) {
-			a.lastKeyWasG = false
 			a.toggleSelectCurrentItem()
 			return a, nil
 		}
 
 		// Handle V - enter visual line mode
 		if key.Matches(msg, a.keys.SelectVisual) {
-			a.lastKeyWasG = false
 			a.enterVisualMode()
 			return a, nil
 		}
 
 		// Handle Esc - clear selection, or navigate back in browser pane
 		if key.Matches(msg, a.keys.ClearSelect) {
-			a.lastKeyWasG = false
 			// First priority: clear selection if any
 			if a.selection.HasSelection() {
 				a.clearSelection()
@@ -1382,22 +1976,26 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
-		// Reset sequence flags for any other key
-		a.lastKeyWasG = false
-
 		switch {
 		case key.Matches(msg, a.keys.Down):
 			displayItems := a.getDisplayItems()
 			if len(displayItems) > 0 && a.browser.Cursor < len(displayItems)-1 {
 				a.browser.Cursor++
 				a.updateVisualSelection()
+			} else if len(displayItems) > 1 && a.config.WrapNavigation {
+				a.browser.Cursor = 0
+				a.updateVisualSelection()
 			}
 			a.clearMessage() // Clear message on navigation
 
 		case key.Matches(msg, a.keys.Up):
+			displayItems := a.getDisplayItems()
 			if a.browser.Cursor > 0 {
 				a.browser.Cursor--
 				a.updateVisualSelection()
+			} else if len(displayItems) > 1 && a.config.WrapNavigation {
+				a.browser.Cursor = len(displayItems) - 1
+				a.updateVisualSelection()
 			}
 			a.clearMessage() // Clear message on navigation
 
@@ -1409,25 +2007,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, a.keys.Right):
-			// Enter folder or open bookmark
-			displayItems := a.getDisplayItems()
-			if len(displayItems) > 0 && a.browser.Cursor < len(displayItems) {
-				item := displayItems[a.browser.Cursor]
-				if item.IsFolder() {
-					// Push current folder to stack
-					if a.browser.CurrentFolderID != nil {
-						a.browser.FolderStack = append(a.browser.FolderStack, *a.browser.CurrentFolderID)
-					}
-					// Enter the folder
-					id := item.Folder.ID
-					a.browser.CurrentFolderID = &id
-					a.browser.Cursor = 0
-					a.refreshItems()
-				} else {
-					// Open bookmark URL
-					return a.openBookmark()
-				}
-			}
+			return a.activateBrowserItem()
 
 		case key.Matches(msg, a.keys.Open):
 			// Open bookmark in browser (only for bookmarks, no-op for folders)
@@ -1459,6 +2039,12 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.browser.Cursor = 0
 			a.refreshItems()
 
+		case key.Matches(msg, a.keys.NextSibling):
+			return a.switchSiblingFolder(1)
+
+		case key.Matches(msg, a.keys.PrevSibling):
+			return a.switchSiblingFolder(-1)
+
 		case key.Matches(msg, a.keys.PasteAfter):
 			a.pasteItem(false) // after cursor
 
@@ -1479,28 +2065,153 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.modal.TitleInput.SetValue(item.Folder.Name)
 				a.modal.TitleInput.Focus()
 				return a, a.modal.TitleInput.Focus()
-			} else {
-				a.mode = ModeEditBookmark
-				a.modal.EditItemID = item.Bookmark.ID
-				a.modal.TitleInput.Reset()
-				a.modal.TitleInput.SetValue(item.Bookmark.Title)
-				a.modal.URLInput.Reset()
-				a.modal.URLInput.SetValue(item.Bookmark.URL)
-				a.modal.TagsInput.Reset()
-				a.modal.TagsInput.SetValue(strings.Join(item.Bookmark.Tags, ", "))
-				a.collectAllTags()
-				a.modal.TagSuggestions = nil
-				a.modal.TagSuggestionIdx = -1
-				a.modal.URLInput.Blur()
-				a.modal.TagsInput.Blur()
-				a.modal.TitleInput.Focus()
-				return a, a.modal.TitleInput.Focus()
 			}
+			return a.startEditBookmark(*item.Bookmark)
 
 		case key.Matches(msg, a.keys.YankURL):
 			// Yank URL to clipboard
 			return a.yankURLToClipboard()
 
+		case key.Matches(msg, a.keys.CopyLink):
+			// Copy bookmark as a formatted link
+			return a.copyLinkToClipboard()
+
+		case key.Matches(msg, a.keys.Snooze):
+			// Snooze only applies to bookmarks
+			displayItems := a.getDisplayItems()
+			if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
+				return a, nil
+			}
+			item := displayItems[a.browser.Cursor]
+			if item.IsFolder() {
+				return a, a.setMessage(MessageError, "Only bookmarks can be snoozed")
+			}
+			a.mode = ModeSnooze
+			a.modal.EditItemID = item.Bookmark.ID
+			a.modal.SnoozeInput.Reset()
+			return a, a.modal.SnoozeInput.Focus()
+
+		case key.Matches(msg, a.keys.Remind):
+			// Reminders only apply to bookmarks
+			displayItems := a.getDisplayItems()
+			if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
+				return a, nil
+			}
+			item := displayItems[a.browser.Cursor]
+			if item.IsFolder() {
+				return a, a.setMessage(MessageError, "Only bookmarks can have reminders")
+			}
+			a.mode = ModeRemind
+			a.modal.EditItemID = item.Bookmark.ID
+			a.modal.RemindInput.Reset()
+			return a, a.modal.RemindInput.Focus()
+
+		case key.Matches(msg, a.keys.Reminders):
+			if len(a.store.GetDueReminders(time.Now())) == 0 {
+				return a, a.setMessage(MessageInfo, "No reminders due")
+			}
+			a.reminderCursor = 0
+			a.mode = ModeReminders
+			return a, nil
+
+		case key.Matches(msg, a.keys.GotoIndex):
+			if len(a.getDisplayItems()) == 0 {
+				return a, nil
+			}
+			a.mode = ModeGotoIndex
+			a.modal.GotoInput.Reset()
+			return a, a.modal.GotoInput.Focus()
+
+		case key.Matches(msg, a.keys.MergeTags):
+			return a, a.openTagMerge()
+
+		case key.Matches(msg, a.keys.AddFromTemplate):
+			return a, a.openTemplatePicker()
+
+		case key.Matches(msg, a.keys.Domains):
+			return a, a.openDomains()
+
+		case key.Matches(msg, a.keys.Alias):
+			// Aliases only apply to bookmarks
+			displayItems := a.getDisplayItems()
+			if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
+				return a, nil
+			}
+			item := displayItems[a.browser.Cursor]
+			if item.IsFolder() {
+				return a, a.setMessage(MessageError, "Only bookmarks can have aliases")
+			}
+			a.mode = ModeAlias
+			a.modal.EditItemID = item.Bookmark.ID
+			a.modal.AliasInput.SetValue(a.config.AliasFor(item.Bookmark.ID))
+			a.modal.AliasInput.CursorEnd()
+			return a, a.modal.AliasInput.Focus()
+
+		case key.Matches(msg, a.keys.Archive):
+			a.lastAction = repeatArchive
+			return a.toggleArchiveCurrentItem()
+
+		case key.Matches(msg, a.keys.Priority):
+			// Priority only applies to bookmarks
+			displayItems := a.getDisplayItems()
+			if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
+				return a, nil
+			}
+			item := displayItems[a.browser.Cursor]
+			if item.IsFolder() {
+				return a, a.setMessage(MessageError, "Only bookmarks have a priority")
+			}
+			priority, err := a.store.CycleBookmarkPriority(item.Bookmark.ID)
+			if err != nil {
+				return a, a.setMessage(MessageError, err.Error())
+			}
+			a.saveStore()
+			a.refreshItems()
+			// Follow the bookmark if the priority sort moved it
+			a.cursorToBookmark(item.Bookmark.ID)
+			return a, a.setMessage(MessageInfo, "Priority: "+model.PriorityName(priority))
+
+		case key.Matches(msg, a.keys.Lock):
+			// Lock only applies to folders
+			displayItems := a.getDisplayItems()
+			if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
+				return a, nil
+			}
+			item := displayItems[a.browser.Cursor]
+			if !item.IsFolder() {
+				return a, a.setMessage(MessageError, "Only folders can be locked")
+			}
+			locked, err := a.store.ToggleLockFolder(item.Folder.ID)
+			if err != nil {
+				return a, a.setMessage(MessageError, err.Error())
+			}
+			a.saveStore()
+			a.refreshItems()
+			if locked {
+				return a, a.setMessage(MessageSuccess, "Locked: "+item.Folder.Name)
+			}
+			return a, a.setMessage(MessageSuccess, "Unlocked: "+item.Folder.Name)
+
+		case key.Matches(msg, a.keys.Promote):
+			// Promote only applies to bookmarks
+			displayItems := a.getDisplayItems()
+			if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
+				return a, nil
+			}
+			item := displayItems[a.browser.Cursor]
+			if item.IsFolder() {
+				return a, a.setMessage(MessageError, "Only bookmarks can be promoted to a folder")
+			}
+			if a.isItemLocked(item) {
+				return a, a.setMessage(MessageError, "Folder is locked")
+			}
+			a.mode = ModePromote
+			a.modal.EditItemID = item.Bookmark.ID
+			a.modal.PromoteRelated = false
+			a.modal.PromoteInput.SetValue(model.SanitizeFolderName(item.Bookmark.Title))
+			a.modal.PromoteInput.CursorEnd()
+			return a, a.modal.PromoteInput.Focus()
+
 		}
 	}
 
@@ -1509,56 +2220,58 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // updatePinnedPane handles key events when the pinned pane is focused.
 func (a App) updatePinnedPane(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Handle gg sequence
+	// Start a g sequence (gg, gb, gd)
 	if key.Matches(msg, a.keys.Top) {
-		if a.lastKeyWasG {
-			a.pinnedCursor = 0
-			a.lastKeyWasG = false
-			return a, nil
-		}
-		a.lastKeyWasG = true
+		return a, a.startSequence(prefixG, msg)
+	}
+
+	// Handle Esc - clear an active pinned filter
+	if key.Matches(msg, a.keys.ClearSelect) && a.search.PinnedFilterQuery != "" {
+		a.search.PinnedFilterQuery = ""
+		a.applyPinnedFilter()
 		return a, nil
 	}
 
 	// Handle d - unpin (in pinned pane, d just unpins, doesn't delete)
 	if key.Matches(msg, a.keys.Delete) {
-		a.lastKeyWasG = false
 		cmd := a.unpinSelectedItem()
 		return a, cmd
 	}
 
 	// Handle x - unpin (same as d in pinned pane)
 	if key.Matches(msg, a.keys.Cut) {
-		a.lastKeyWasG = false
 		cmd := a.unpinSelectedItem()
 		return a, cmd
 	}
 
 	// Handle m - unpin
 	if key.Matches(msg, a.keys.Pin) {
-		a.lastKeyWasG = false
 		cmd := a.unpinSelectedItem()
 		return a, cmd
 	}
 
-	a.lastKeyWasG = false
+	pinnedItems := a.getDisplayPinnedItems()
 
 	switch {
 	case key.Matches(msg, a.keys.Down):
-		if len(a.pinnedItems) > 0 && a.pinnedCursor < len(a.pinnedItems)-1 {
+		if len(pinnedItems) > 0 && a.pinnedCursor < len(pinnedItems)-1 {
 			a.pinnedCursor++
+		} else if len(pinnedItems) > 1 && a.config.WrapNavigation {
+			a.pinnedCursor = 0
 		}
 		a.clearMessage()
 
 	case key.Matches(msg, a.keys.Up):
 		if a.pinnedCursor > 0 {
 			a.pinnedCursor--
+		} else if len(pinnedItems) > 1 && a.config.WrapNavigation {
+			a.pinnedCursor = len(pinnedItems) - 1
 		}
 		a.clearMessage()
 
 	case key.Matches(msg, a.keys.Bottom):
-		if len(a.pinnedItems) > 0 {
-			a.pinnedCursor = len(a.pinnedItems) - 1
+		if len(pinnedItems) > 0 {
+			a.pinnedCursor = len(pinnedItems) - 1
 		}
 
 	case key.Matches(msg, a.keys.Right):
@@ -1570,7 +2283,13 @@ func (a App) updatePinnedPane(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
-	// Handle J/K for reordering pinned items
+	// Handle J/K for reordering pinned items (neighbors are ambiguous while filtered)
+	if (msg.String() == "J" || msg.String() == "K") && a.search.PinnedFilterQuery != "" {
+		return a, a.setMessage(MessageWarning, "Clear the filter to reorder pins")
+	}
+	if (msg.String() == "J" || msg.String() == "K") && !a.pinsReorderable() {
+		return a, a.setMessage(MessageWarning, "Pins are sorted by "+a.config.PinnedSortMode+" - set pinnedSortMode to order to reorder")
+	}
 	if msg.String() == "J" {
 		return a.movePinnedItemDown()
 	}
@@ -1581,7 +2300,7 @@ func (a App) updatePinnedPane(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Handle 1-9 for quick access to pinned items
 	if len(msg.String()) == 1 && msg.String() >= "1" && msg.String() <= "9" {
 		idx := int(msg.String()[0] - '1') // "1" -> 0, "2" -> 1, etc.
-		if idx < len(a.pinnedItems) {
+		if idx < len(pinnedItems) {
 			a.pinnedCursor = idx
 			return a.activatePinnedItem()
 		}
@@ -1596,6 +2315,30 @@ func (a App) updatePinnedPane(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// pruneEmptyFolders removes folders without any bookmarks in their subtree,
+// leaving the current folder for root if it was one of them.
+func (a *App) pruneEmptyFolders() tea.Cmd {
+	removed := a.store.PruneEmptyFolders()
+	if a.browser.CurrentFolderID != nil && a.store.GetFolderByID(*a.browser.CurrentFolderID) == nil {
+		a.browser.CurrentFolderID = nil
+		a.browser.FolderStack = []string{}
+		a.browser.Cursor = 0
+	}
+	a.saveStore()
+	a.refreshItems()
+	a.refreshPinnedItems()
+	if a.browser.Cursor >= len(a.browser.Items) {
+		a.browser.Cursor = max(len(a.browser.Items)-1, 0)
+	}
+	return a.setMessage(MessageSuccess, "Removed "+strconv.Itoa(removed)+" empty folders")
+}
+
+// pinsReorderable reports whether the pinned pane shows pin order, the only
+// mode where moving an item up or down has a visible meaning.
+func (a *App) pinsReorderable() bool {
+	return a.config.PinnedSortMode == "" || a.config.PinnedSortMode == storage.PinnedSortOrder
+}
+
 // movePinnedItemUp moves the selected pinned item up (lower PinOrder).
 func (a *App) movePinnedItemUp() (tea.Model, tea.Cmd) {
 	if a.pinnedCursor <= 0 || len(a.pinnedItems) < 2 {
@@ -1679,9 +2422,9 @@ func (a *App) activatePinnedItem() (tea.Model, tea.Cmd) {
 	// Open bookmark URL
 	if item.Bookmark != nil && item.Bookmark.URL != "" {
 		// Update visited time
-		now := time.Now()
 		if b := a.store.GetBookmarkByID(item.Bookmark.ID); b != nil {
-			b.VisitedAt = &now
+			b.MarkVisited(time.Now())
+			a.saveStore()
 		}
 		a.refreshPinnedItems()
 		return a, openURLCmd(item.Bookmark.URL)
@@ -1715,13 +2458,39 @@ func (a *App) unpinSelectedItem() tea.Cmd {
 	a.refreshPinnedItems()
 
 	// Adjust cursor if needed
-	if a.pinnedCursor >= len(a.pinnedItems) && a.pinnedCursor > 0 {
+	if a.pinnedCursor >= len(a.getDisplayPinnedItems()) && a.pinnedCursor > 0 {
 		a.pinnedCursor--
 	}
 
 	return cmd
 }
 
+// toggleArchiveCurrentItem archives or unarchives the bookmark under the cursor.
+func (a App) toggleArchiveCurrentItem() (tea.Model, tea.Cmd) {
+	// Archive only applies to bookmarks
+	displayItems := a.getDisplayItems()
+	if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
+		return a, nil
+	}
+	item := displayItems[a.browser.Cursor]
+	if item.IsFolder() {
+		return a, a.setMessage(MessageError, "Only bookmarks can be archived")
+	}
+	archived, err := a.store.ToggleArchiveBookmark(item.Bookmark.ID)
+	if err != nil {
+		return a, a.setMessage(MessageError, err.Error())
+	}
+	a.saveStore()
+	a.refreshItems()
+	if a.browser.Cursor >= len(a.browser.Items) {
+		a.browser.Cursor = max(len(a.browser.Items)-1, 0)
+	}
+	if archived {
+		return a, a.setMessage(MessageSuccess, "Archived: "+item.Bookmark.Title)
+	}
+	return a, a.setMessage(MessageSuccess, "Unarchived: "+item.Bookmark.Title)
+}
+
 // togglePinCurrentItem toggles pin on the currently selected item (or selected items) in browser pane.
 // Returns a command to schedule message auto-clear.
 func (a *App) togglePinCurrentItem() tea.Cmd {
@@ -1821,6 +2590,67 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
+	// Handle bulk actions menu
+	if a.mode == ModeBulkMenu {
+		switch {
+		case msg.Type == tea.KeyEsc:
+			// Back to normal mode, keeping the selection
+			a.mode = ModeNormal
+			return a, nil
+		case msg.Type == tea.KeyEnter:
+			if a.bulk.MenuCursor < len(a.bulk.Actions) {
+				return a.executeBulkAction(a.bulk.Actions[a.bulk.MenuCursor])
+			}
+			return a, nil
+		case key.Matches(msg, a.keys.Down):
+			if a.bulk.MenuCursor < len(a.bulk.Actions)-1 {
+				a.bulk.MenuCursor++
+			}
+			return a, nil
+		case key.Matches(msg, a.keys.Up):
+			if a.bulk.MenuCursor > 0 {
+				a.bulk.MenuCursor--
+			}
+			return a, nil
+		case key.Matches(msg, a.keys.Quit):
+			a.mode = ModeNormal
+			return a, nil
+		}
+		return a, nil
+	}
+
+	// Handle bulk tag input
+	if a.mode == ModeBulkTag {
+		switch msg.Type {
+		case tea.KeyEsc:
+			a.mode = ModeNormal
+			a.bulk.TagInput.Blur()
+			return a, nil
+		case tea.KeyEnter:
+			return a.submitBulkTag()
+		}
+		// Forward to input
+		var cmd tea.Cmd
+		a.bulk.TagInput, cmd = a.bulk.TagInput.Update(msg)
+		return a, cmd
+	}
+
+	// Handle selection export path input
+	if a.mode == ModeBulkExport {
+		switch msg.Type {
+		case tea.KeyEsc:
+			a.mode = ModeNormal
+			a.bulk.PathInput.Blur()
+			return a, nil
+		case tea.KeyEnter:
+			cmd := a.exportSelection()
+			return a, cmd
+		}
+		var cmd tea.Cmd
+		a.bulk.PathInput, cmd = a.bulk.PathInput.Update(msg)
+		return a, cmd
+	}
+
 	// Handle cull menu mode (fresh vs cached)
 	if a.mode == ModeCullMenu {
 		switch msg.Type {
@@ -1831,16 +2661,21 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if a.cull.MenuCursor == 0 {
 				// Fresh check
 				a.cull.Reset()
-				a.cull.Total = len(a.store.Bookmarks)
+				a.cull.Total = len(a.store.GetActiveBookmarks())
 				a.mode = ModeCullLoading
-				return a, a.startCullCmd()
+				cull := a.startCullCmd()
+				return a, cull
 			} else {
 				// Use cached results
 				results, _, err := a.loadCullCache()
 				if err != nil {
-					a.setMessage(MessageError, "Cache load failed: "+err.Error())
-					a.mode = ModeNormal
-					return a, nil
+					// Fall back to a fresh check rather than blocking on a bad cache
+					warn := a.setMessage(MessageWarning, "Cache unreadable, running a fresh check")
+					a.cull.Reset()
+					a.cull.Total = len(a.store.GetActiveBookmarks())
+					a.mode = ModeCullLoading
+					cull := a.startCullCmd()
+					return a, tea.Batch(warn, cull)
 				}
 				a.cull.Results = results
 				a.cull.Groups = a.groupCullResults(results)
@@ -1892,9 +2727,10 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				// Use cached results
 				suggestions, _, err := a.loadOrganizeCache()
 				if err != nil {
-					a.setMessage(MessageError, "Cache load failed: "+err.Error())
-					a.mode = ModeNormal
-					return a, nil
+					// Fall back to a fresh analysis rather than blocking on a bad cache
+					warn := a.setMessage(MessageWarning, "Cache unreadable, running a fresh analysis")
+					model, cmd := a.startOrganizeAnalysis()
+					return model, tea.Batch(warn, cmd)
 				}
 				a.organize.Suggestions = suggestions
 				a.organize.Cursor = 0
@@ -1942,6 +2778,7 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if a.mode == ModeCullLoading {
 		// Only allow Esc to cancel
 		if msg.Type == tea.KeyEsc {
+			a.cancelTask()
 			a.cull.Reset()
 			a.mode = ModeNormal
 			return a, nil
@@ -1953,6 +2790,7 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if a.mode == ModeOrganizeLoading {
 		// Only allow Esc to cancel
 		if msg.Type == tea.KeyEsc {
+			a.cancelTask()
 			a.organize.Reset()
 			a.mode = ModeNormal
 			return a, nil
@@ -2001,6 +2839,9 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			case "d":
 				// Delete all in selected group
 				return a.cullDeleteGroup()
+			case "M":
+				// Move all in selected group to the broken links folder
+				return a.cullQuarantineGroup()
 			}
 			// q quits (handled globally above)
 		}
@@ -2056,6 +2897,9 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			case "m":
 				// Move bookmark
 				return a.cullMoveItem()
+			case "M":
+				// Move the whole group to the broken links folder
+				return a.cullQuarantineGroup()
 			}
 		}
 		return a, nil
@@ -2063,12 +2907,28 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Handle organize results mode
 	if a.mode == ModeOrganizeResults {
+		// Change preview: Enter applies, anything else goes back to the list
+		if a.organize.Confirming {
+			a.organize.Confirming = false
+			if msg.Type == tea.KeyEnter {
+				return a.organizeAcceptCurrent()
+			}
+			return a, nil
+		}
+
 		switch msg.Type {
 		case tea.KeyEsc:
 			a.mode = ModeNormal
 			a.organize.Reset()
 			return a, nil
 		case tea.KeyEnter:
+			if !a.config.SkipOrganizeConfirm {
+				// Preview exactly what will change before applying
+				if sug := a.organize.CurrentSuggestion(); sug != nil && !sug.Processed {
+					a.organize.Confirming = true
+				}
+				return a, nil
+			}
 			return a.organizeAcceptCurrent()
 		case tea.KeyDown:
 			a.organizeNextUnprocessed()
@@ -2117,7 +2977,45 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.mode = ModeNormal
 			return a, nil
 		}
-		return a, nil
+		if msg.String() == "K" && a.canDeleteKeepingContents() {
+			a.confirmDeleteKeepContents()
+			a.mode = ModeNormal
+		}
+		return a, nil
+	}
+
+	// Handle large paste/move confirmation (simple yes/no)
+	if a.mode == ModeConfirmLargeOp {
+		switch msg.Type {
+		case tea.KeyEsc:
+			a.mode = ModeNormal
+			a.move.ItemsToMove = nil
+			return a, a.setMessage(MessageInfo, "Cancelled")
+		case tea.KeyEnter:
+			a.mode = ModeNormal
+			switch a.largeOp.Kind {
+			case LargeOpMove:
+				a.executeMoveItem()
+			case LargeOpOpen:
+				return a, a.executeOpenSelected()
+			default:
+				a.executePaste(a.largeOp.Before)
+			}
+			return a, nil
+		}
+		return a, nil
+	}
+
+	if a.mode == ModeConfirmPruneEmpty {
+		switch msg.Type {
+		case tea.KeyEsc:
+			a.mode = ModeNormal
+			return a, a.setMessage(MessageInfo, "Cancelled")
+		case tea.KeyEnter:
+			a.mode = ModeNormal
+			return a, a.pruneEmptyFolders()
+		}
+		return a, nil
 	}
 
 	// Handle move mode (folder picker with filter)
@@ -2132,6 +3030,14 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			return a, nil
 		case tea.KeyEnter:
+			// Plain moves of many items ask for confirmation first
+			if len(a.move.FilteredFolders) > 0 && a.move.ReturnMode == 0 && a.move.OrganizeSuggestion == nil {
+				if count := a.countAffectedItems(a.move.ItemsToMove); a.isLargeOperation(count) {
+					a.largeOp = LargeOpState{Kind: LargeOpMove, Count: count}
+					a.mode = ModeConfirmLargeOp
+					return a, nil
+				}
+			}
 			// Execute move if there are filtered results
 			if len(a.move.FilteredFolders) > 0 {
 				a.executeMoveItem()
@@ -2227,50 +3133,7 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return a, nil
 			}
 
-			// Select highlighted item: navigate to folder or bookmark location
-			if len(a.search.FuzzyMatches) > 0 && a.search.FuzzyCursor < len(a.search.FuzzyMatches) {
-				selectedItem := a.search.FuzzyMatches[a.search.FuzzyCursor].Item
-
-				if selectedItem.IsFolder() {
-					// Navigate into the selected folder
-					folderID := selectedItem.Folder.ID
-					a.browser.FolderStack = []string{}
-					a.buildFolderStack(selectedItem.Folder.ParentID)
-					a.browser.CurrentFolderID = &folderID
-					a.browser.Cursor = 0
-					a.refreshItems()
-				} else {
-					// Navigate to bookmark's folder and position cursor on it
-					bookmark := selectedItem.Bookmark
-					a.browser.FolderStack = []string{}
-
-					if bookmark.FolderID != nil {
-						// Bookmark is in a folder - navigate there
-						folder := a.store.GetFolderByID(*bookmark.FolderID)
-						if folder != nil {
-							a.buildFolderStack(folder.ParentID)
-						}
-						a.browser.CurrentFolderID = bookmark.FolderID
-					} else {
-						// Bookmark is at root
-						a.browser.CurrentFolderID = nil
-					}
-
-					a.refreshItems()
-
-					// Find and position cursor on the bookmark
-					for i, item := range a.browser.Items {
-						if !item.IsFolder() && item.Bookmark.ID == bookmark.ID {
-							a.browser.Cursor = i
-							break
-						}
-					}
-				}
-			}
-			a.mode = ModeNormal
-			a.search.FuzzyMatches = nil
-			a.search.AllItems = nil
-			return a, nil
+			return a.goToFuzzyMatch()
 
 		case tea.KeyDown:
 			// If tag suggestions shown, navigate them
@@ -2315,6 +3178,14 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
+		if msg.Type == tea.KeyCtrlF && a.search.Source == SourceAll {
+			// Show or hide folders in the results
+			a.search.IncludeFolders = !a.search.IncludeFolders
+			a.search.AllItems = a.getItemsForSource(SourceAll)
+			a.updateFuzzyMatchesWithTagFilter()
+			return a, nil
+		}
+
 		if msg.Type == tea.KeyCtrlO {
 			// Open bookmark in browser
 			if len(a.search.FuzzyMatches) > 0 && a.search.FuzzyCursor < len(a.search.FuzzyMatches) {
@@ -2322,8 +3193,7 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if !selectedItem.IsFolder() {
 					bookmark := a.store.GetBookmarkByID(selectedItem.Bookmark.ID)
 					if bookmark != nil {
-						now := time.Now()
-						bookmark.VisitedAt = &now
+						bookmark.MarkVisited(time.Now())
 						a.saveStore()
 					}
 					return a, openURLCmd(selectedItem.Bookmark.URL)
@@ -2337,11 +3207,7 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if len(a.search.FuzzyMatches) > 0 && a.search.FuzzyCursor < len(a.search.FuzzyMatches) {
 				selectedItem := a.search.FuzzyMatches[a.search.FuzzyCursor].Item
 				if !selectedItem.IsFolder() {
-					if err := clipboard.WriteAll(selectedItem.Bookmark.URL); err != nil {
-						return a, func() tea.Msg { return clipboardErrorMsg{err} }
-					}
-					cmd := a.setMessage(MessageSuccess, "Copied URL to clipboard")
-					return a, cmd
+					return a, a.writeClipboardCmd(selectedItem.Bookmark.URL, "URL")
 				}
 			}
 			return a, nil
@@ -2380,7 +3246,10 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Delete item
 			if len(a.search.FuzzyMatches) > 0 && a.search.FuzzyCursor < len(a.search.FuzzyMatches) {
 				selectedItem := a.search.FuzzyMatches[a.search.FuzzyCursor].Item
-				if a.confirmDelete {
+				if a.refuseLocked([]Item{selectedItem}) {
+					return a, nil
+				}
+				if a.needsDeleteConfirm(selectedItem) {
 					a.mode = ModeConfirmDelete
 					a.modal.EditItemID = selectedItem.ID()
 					a.modal.CutMode = false
@@ -2405,12 +3274,16 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Cut item (delete + yank to buffer)
 			if len(a.search.FuzzyMatches) > 0 && a.search.FuzzyCursor < len(a.search.FuzzyMatches) {
 				selectedItem := a.search.FuzzyMatches[a.search.FuzzyCursor].Item
-				if a.confirmDelete {
+				if a.refuseLocked([]Item{selectedItem}) {
+					return a, nil
+				}
+				if a.needsDeleteConfirm(selectedItem) {
 					a.mode = ModeConfirmDelete
 					a.modal.EditItemID = selectedItem.ID()
 					a.modal.CutMode = true
 				} else {
-					a.yankedItems = []Item{selectedItem}
+					a.yankedItems = []Item{detachItem(selectedItem)}
+					a.yankedFromCut = true
 					if selectedItem.IsFolder() {
 						a.store.RemoveFolderByID(selectedItem.Folder.ID)
 					} else {
@@ -2429,6 +3302,17 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
+		// 1-9 go to that result, like the pinned pane's shortcuts
+		if a.config.FinderNumberKeys && msg.Type == tea.KeyRunes && len(msg.Runes) == 1 &&
+			msg.Runes[0] >= '1' && msg.Runes[0] <= '9' {
+			idx := int(msg.Runes[0] - '1')
+			if idx >= len(a.search.FuzzyMatches) {
+				return a, nil
+			}
+			a.search.FuzzyCursor = idx
+			return a.goToFuzzyMatch()
+		}
+
 		// Handle vim-style navigation (j/k don't conflict with typical searches)
 		if msg.Type == tea.KeyRunes {
 			switch string(msg.Runes) {
@@ -2458,6 +3342,26 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, cmd
 	}
 
+	// Handle pinned pane filter mode (/ key while pinned pane is focused)
+	if a.mode == ModeFilter && a.focusedPane == PanePinned {
+		switch msg.Type {
+		case tea.KeyEsc, tea.KeyEnter:
+			// Keep filter active, just close input
+			a.search.PinnedFilterQuery = a.search.PinnedFilterInput.Value()
+			a.applyPinnedFilter()
+			a.search.PinnedFilterInput.Blur()
+			a.mode = ModeNormal
+			return a, nil
+		}
+
+		var cmd tea.Cmd
+		a.search.PinnedFilterInput, cmd = a.search.PinnedFilterInput.Update(msg)
+		// Live filter as user types
+		a.search.PinnedFilterQuery = a.search.PinnedFilterInput.Value()
+		a.applyPinnedFilter()
+		return a, cmd
+	}
+
 	// Handle local filter mode (/ key)
 	if a.mode == ModeFilter {
 		switch msg.Type {
@@ -2472,6 +3376,10 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.search.FilterQuery = a.search.FilterInput.Value()
 			a.applyFilter()
 			a.mode = ModeNormal
+			// With filterAutoSelect, Enter on the only match opens it directly
+			if a.config.FilterAutoSelect && a.search.FilterQuery != "" && len(a.search.FilteredItems) == 1 {
+				return a.activateBrowserItem()
+			}
 			return a, nil
 		case tea.KeyBackspace:
 			// If filter is empty and backspace, clear filter entirely
@@ -2490,6 +3398,122 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, cmd
 	}
 
+	// Handle snooze duration input mode
+	if a.mode == ModeSnooze {
+		switch msg.Type {
+		case tea.KeyEsc:
+			a.mode = ModeNormal
+			a.modal.SnoozeInput.Blur()
+			return a, nil
+		case tea.KeyEnter:
+			return a.submitSnooze()
+		}
+		// Forward to input
+		var cmd tea.Cmd
+		a.modal.SnoozeInput, cmd = a.modal.SnoozeInput.Update(msg)
+		return a, cmd
+	}
+
+	// Handle item number input for # jumps
+	if a.mode == ModeGotoIndex {
+		switch msg.Type {
+		case tea.KeyEsc:
+			a.mode = ModeNormal
+			a.modal.GotoInput.Blur()
+			return a, nil
+		case tea.KeyEnter:
+			return a.submitGotoIndex()
+		}
+		// Forward to input
+		var cmd tea.Cmd
+		a.modal.GotoInput, cmd = a.modal.GotoInput.Update(msg)
+		return a, cmd
+	}
+
+	// Handle reminder duration input mode
+	if a.mode == ModeRemind {
+		switch msg.Type {
+		case tea.KeyEsc:
+			a.mode = ModeNormal
+			a.modal.RemindInput.Blur()
+			return a, nil
+		case tea.KeyEnter:
+			return a.submitRemind()
+		}
+		// Forward to input
+		var cmd tea.Cmd
+		a.modal.RemindInput, cmd = a.modal.RemindInput.Update(msg)
+		return a, cmd
+	}
+
+	// Handle due reminders list
+	if a.mode == ModeReminders {
+		return a.updateReminders(msg)
+	}
+
+	if a.mode == ModeMergeTags {
+		return a.updateTagMerge(msg)
+	}
+
+	if a.mode == ModePickTemplate {
+		return a.updateTemplatePicker(msg)
+	}
+
+	if a.mode == ModeDomains {
+		return a.updateDomains(msg)
+	}
+
+	if a.mode == ModeVerifyOpen {
+		return a.updateVerifyOpen(msg)
+	}
+
+	if a.mode == ModePeek {
+		return a.updatePeek(msg)
+	}
+
+	if a.mode == ModeDetails {
+		return a.updateDetails(msg)
+	}
+
+	if a.mode == ModeOnboarding {
+		return a.dismissOnboarding()
+	}
+
+	// Handle alias name input mode
+	if a.mode == ModeAlias {
+		switch msg.Type {
+		case tea.KeyEsc:
+			a.mode = ModeNormal
+			a.modal.AliasInput.Blur()
+			return a, nil
+		case tea.KeyEnter:
+			return a.submitAlias()
+		}
+		// Forward to input
+		var cmd tea.Cmd
+		a.modal.AliasInput, cmd = a.modal.AliasInput.Update(msg)
+		return a, cmd
+	}
+
+	// Handle promote-to-folder name input mode
+	if a.mode == ModePromote {
+		switch msg.Type {
+		case tea.KeyEsc:
+			a.mode = ModeNormal
+			a.modal.PromoteInput.Blur()
+			return a, nil
+		case tea.KeyTab:
+			a.modal.PromoteRelated = !a.modal.PromoteRelated
+			return a, nil
+		case tea.KeyEnter:
+			return a.submitPromote()
+		}
+		// Forward to input
+		var cmd tea.Cmd
+		a.modal.PromoteInput, cmd = a.modal.PromoteInput.Update(msg)
+		return a, cmd
+	}
+
 	// Handle quick add URL input mode
 	if a.mode == ModeQuickAdd {
 		switch msg.Type {
@@ -2497,13 +3521,23 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.mode = ModeNormal
 			return a, nil
 		case tea.KeyEnter:
-			url := a.quickAdd.Input.Value()
+			url := strings.TrimSpace(a.quickAdd.Input.Value())
 			if url == "" {
 				return a, nil
 			}
+			if a.quickAdd.ReadLater {
+				return a.startReadLater(url, "Invalid URL")
+			}
+			if a.quickAdd.Inbox {
+				return a.captureToInbox(url, "Invalid URL")
+			}
+			if a.quickAdd.Template != "" {
+				return a.confirmTemplateAdd(url)
+			}
 			// Start AI call
 			a.mode = ModeQuickAddLoading
-			return a, a.callAICmd(url)
+			cmd := a.callAICmd(url)
+			return a, cmd
 		}
 		// Forward to input
 		var cmd tea.Cmd
@@ -2515,7 +3549,12 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if a.mode == ModeQuickAddLoading {
 		// Only allow Esc to cancel
 		if msg.Type == tea.KeyEsc {
+			a.cancelTask()
 			a.mode = ModeNormal
+			if a.quickAdd.Response != nil {
+				// Cancelled a regeneration: keep the previous suggestion
+				a.mode = ModeQuickAddConfirm
+			}
 			return a, nil
 		}
 		return a, nil
@@ -2525,6 +3564,7 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if a.mode == ModeReadLaterLoading {
 		// Only allow Esc to cancel
 		if msg.Type == tea.KeyEsc {
+			a.cancelTask()
 			a.mode = ModeNormal
 			a.readLaterURL = ""
 			return a, nil
@@ -2541,6 +3581,8 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case tea.KeyEsc:
 			a.mode = ModeNormal
 			return a, nil
+		case tea.KeyCtrlR:
+			return a.regenerateQuickAdd()
 		case tea.KeyEnter:
 			// Check if we should create a new folder (no filtered results and filter has text)
 			if folderFilterFocused && len(a.quickAdd.FilteredFolders) == 0 && a.quickAdd.FilterInput.Value() != "" {
@@ -2646,6 +3688,15 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					newFolderPath = parentPath + "/" + a.quickAddCreateFolder.NewFolderName
 				}
 
+				var parentID *string
+				if parent := a.store.GetFolderByPath(parentPath); parent != nil {
+					parentID = &parent.ID
+				}
+				if a.exceedsMaxDepth(parentID, 1) {
+					cmd := a.setMessage(MessageError, a.maxDepthMessage())
+					return a, cmd
+				}
+
 				// Create the folder
 				a.store.GetOrCreateFolderByPath(newFolderPath)
 				a.saveStore()
@@ -2717,6 +3768,10 @@ func (a App) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		// Submit modal
 		return a.submitModal()
+
+	case tea.KeyCtrlO:
+		// Edit the focused field in $EDITOR
+		return a, a.editInEditor()
 	}
 
 	// Forward to text inputs
@@ -2787,6 +3842,11 @@ func (a App) submitModal() (tea.Model, tea.Cmd) {
 			// Don't submit with empty name
 			return a, nil
 		}
+		if a.exceedsMaxDepth(a.browser.CurrentFolderID, 1) {
+			a.mode = ModeNormal
+			cmd := a.setMessage(MessageError, a.maxDepthMessage())
+			return a, cmd
+		}
 
 		// Create and add the folder
 		newFolder := model.NewFolder(model.NewFolderParams{
@@ -2809,16 +3869,7 @@ func (a App) submitModal() (tea.Model, tea.Cmd) {
 		}
 
 		// Parse comma-separated tags
-		var tags []string
-		tagsStr := a.modal.TagsInput.Value()
-		if tagsStr != "" {
-			for _, tag := range strings.Split(tagsStr, ",") {
-				tag = strings.TrimSpace(tag)
-				if tag != "" {
-					tags = append(tags, tag)
-				}
-			}
-		}
+		tags := a.normalizeTags(model.ParseTags(a.modal.TagsInput.Value()))
 
 		// Create and add the bookmark
 		newBookmark := model.NewBookmark(model.NewBookmarkParams{
@@ -2860,20 +3911,14 @@ func (a App) submitModal() (tea.Model, tea.Cmd) {
 		}
 
 		// Parse comma-separated tags
-		var tags []string
-		tagsStr := a.modal.TagsInput.Value()
-		if tagsStr != "" {
-			for _, tag := range strings.Split(tagsStr, ",") {
-				tag = strings.TrimSpace(tag)
-				if tag != "" {
-					tags = append(tags, tag)
-				}
-			}
-		}
+		tags := a.normalizeTags(model.ParseTags(a.modal.TagsInput.Value()))
 
 		// Find and update the bookmark
 		bookmark := a.store.GetBookmarkByID(a.modal.EditItemID)
 		if bookmark != nil {
+			if bookmark.Title != title || bookmark.URL != url || !slices.Equal(bookmark.Tags, tags) {
+				bookmark.MarkModified(time.Now())
+			}
 			bookmark.Title = title
 			bookmark.URL = url
 			bookmark.Tags = tags
@@ -2887,6 +3932,11 @@ func (a App) submitModal() (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// normalizeTags cleans entered tags according to the LowercaseTags setting.
+func (a *App) normalizeTags(tags []string) []string {
+	return model.NormalizeTags(tags, a.config.LowercaseTags)
+}
+
 // submitQuickAdd saves the bookmark from AI quick add confirmation.
 func (a App) submitQuickAdd() (tea.Model, tea.Cmd) {
 	title := a.modal.TitleInput.Value()
@@ -2897,16 +3947,7 @@ func (a App) submitQuickAdd() (tea.Model, tea.Cmd) {
 	}
 
 	// Parse tags
-	var tags []string
-	tagsStr := a.modal.TagsInput.Value()
-	if tagsStr != "" {
-		for _, tag := range strings.Split(tagsStr, ",") {
-			tag = strings.TrimSpace(tag)
-			if tag != "" {
-				tags = append(tags, tag)
-			}
-		}
-	}
+	tags := a.normalizeTags(model.ParseTags(a.modal.TagsInput.Value()))
 
 	// Get or create the selected folder
 	var folderID *string
@@ -2935,6 +3976,157 @@ func (a App) submitQuickAdd() (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// submitSnooze hides the bookmark being snoozed until the entered duration has passed.
+func (a App) submitSnooze() (tea.Model, tea.Cmd) {
+	until, err := model.ParseSnoozeDuration(a.modal.SnoozeInput.Value(), time.Now())
+	if err != nil {
+		return a, a.setMessage(MessageError, err.Error())
+	}
+
+	if err := a.store.SnoozeBookmark(a.modal.EditItemID, until); err != nil {
+		a.mode = ModeNormal
+		return a, a.setMessage(MessageError, err.Error())
+	}
+
+	a.saveStore()
+	a.refreshItems()
+	a.mode = ModeNormal
+	a.modal.SnoozeInput.Blur()
+	return a, a.setMessage(MessageSuccess, "Snoozed until "+until.Format("Mon Jan 2 15:04"))
+}
+
+// submitGotoIndex moves the cursor to the 1-based item number entered after #.
+func (a App) submitGotoIndex() (tea.Model, tea.Cmd) {
+	a.mode = ModeNormal
+	a.modal.GotoInput.Blur()
+
+	count := len(a.getDisplayItems())
+	n, err := strconv.Atoi(strings.TrimSpace(a.modal.GotoInput.Value()))
+	if err != nil || n < 1 || n > count {
+		return a, a.setMessage(MessageError, "No item "+strings.TrimSpace(a.modal.GotoInput.Value())+" (1-"+strconv.Itoa(count)+")")
+	}
+	a.browser.Cursor = n - 1
+	return a, nil
+}
+
+// cycleFocus moves focus to the next pane: pinned → browser → pinned.
+// The pinned pane is skipped while it is empty.
+func (a *App) cycleFocus() {
+	if a.focusedPane == PaneBrowser && len(a.pinnedItems) > 0 {
+		a.focusedPane = PanePinned
+		return
+	}
+	a.focusedPane = PaneBrowser
+}
+
+// mainPaneWeightStep is how much < and > change the main pane weight.
+const mainPaneWeightStep = 10
+
+// adjustMainPaneWeight widens (positive delta) or narrows the current and
+// preview panes relative to the side panes and persists the new ratio.
+func (a App) adjustMainPaneWeight(delta int) (tea.Model, tea.Cmd) {
+	pane := &a.layoutConfig.Pane
+	current := layout.ClampMainPaneWeight(pane.MainPaneWeight, *pane)
+	weight := layout.ClampMainPaneWeight(current+delta, *pane)
+	if weight == current {
+		return a, a.setMessage(MessageInfo, "Pane ratio at limit ("+strconv.Itoa(weight)+"%)")
+	}
+	pane.MainPaneWeight = weight
+	a.config.MainPaneWeight = weight
+
+	if a.configPath != "" {
+		if err := storage.SaveConfig(a.configPath, a.config); err != nil {
+			return a, a.setMessage(MessageError, "Save config failed: "+err.Error())
+		}
+	}
+	return a, a.setMessage(MessageInfo, "Pane ratio: "+strconv.Itoa(weight)+"%")
+}
+
+// submitAlias points the entered alias at the bookmark being edited.
+// An empty alias removes the bookmark's existing alias.
+func (a App) submitAlias() (tea.Model, tea.Cmd) {
+	name := strings.TrimSpace(a.modal.AliasInput.Value())
+	if strings.ContainsAny(name, " \t") {
+		return a, a.setMessage(MessageError, "Alias must be a single word")
+	}
+
+	if err := a.config.SetAlias(name, a.modal.EditItemID); err != nil {
+		return a, a.setMessage(MessageError, err.Error())
+	}
+	a.mode = ModeNormal
+	a.modal.AliasInput.Blur()
+
+	if a.configPath != "" {
+		if err := storage.SaveConfig(a.configPath, a.config); err != nil {
+			return a, a.setMessage(MessageError, "Save config failed: "+err.Error())
+		}
+	}
+
+	if name == "" {
+		return a, a.setMessage(MessageSuccess, "Alias removed")
+	}
+	return a, a.setMessage(MessageSuccess, "Alias set: bm "+name)
+}
+
+// submitPromote creates a folder next to the bookmark being promoted and moves
+// the bookmark into it, together with sibling bookmarks sharing one of its tags
+// when PromoteRelated is set.
+func (a App) submitPromote() (tea.Model, tea.Cmd) {
+	name := model.SanitizeFolderName(a.modal.PromoteInput.Value())
+	if name == "" {
+		return a, a.setMessage(MessageError, "Folder name cannot be empty")
+	}
+
+	bookmark := a.store.GetBookmarkByID(a.modal.EditItemID)
+	if bookmark == nil {
+		a.mode = ModeNormal
+		return a, a.setMessage(MessageError, "Bookmark not found")
+	}
+
+	path := strings.TrimSuffix(a.store.GetFolderPath(bookmark.FolderID), "/") + "/" + name
+	if a.store.GetFolderByPath(path) == nil && a.exceedsMaxDepth(bookmark.FolderID, 1) {
+		return a, a.setMessage(MessageError, a.maxDepthMessage())
+	}
+	folder, _ := a.store.GetOrCreateFolderByPath(path)
+	if folder == nil {
+		return a, a.setMessage(MessageError, "Could not create folder "+path)
+	}
+
+	items := []Item{{Kind: ItemBookmark, Bookmark: bookmark}}
+	if a.modal.PromoteRelated {
+		for _, b := range a.store.GetAllBookmarksInFolder(bookmark.FolderID) {
+			if b.ID != bookmark.ID && sharesTag(b.Tags, bookmark.Tags) {
+				items = append(items, Item{Kind: ItemBookmark, Bookmark: a.store.GetBookmarkByID(b.ID)})
+			}
+		}
+	}
+
+	// Reuse the move picker's execution path with the new folder as target
+	a.move.ItemsToMove = items
+	a.move.FilteredFolders = []string{path}
+	a.move.FolderIdx = 0
+	a.executeMoveItem()
+
+	a.mode = ModeNormal
+	a.modal.PromoteInput.Blur()
+	if len(items) == 1 {
+		return a, a.setMessage(MessageSuccess, "Promoted to "+path)
+	}
+	return a, a.setMessage(MessageSuccess, "Promoted "+strconv.Itoa(len(items))+" bookmarks to "+path)
+}
+
+// sharesTag reports whether the two tag lists have a tag in common (case-insensitive).
+func sharesTag(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if strings.EqualFold(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // toggleSelectCurrentItem toggles selection on the current item.
 func (a *App) toggleSelectCurrentItem() {
 	displayItems := a.getDisplayItems()
@@ -2966,6 +4158,24 @@ func (a *App) enterVisualMode() {
 	a.selection.Selected[item.ID()] = true
 }
 
+// jumpToInitial moves the cursor to the next item whose title starts with r,
+// wrapping around so repeated jumps cycle through all matches.
+func (a *App) jumpToInitial(r rune) tea.Cmd {
+	displayItems := a.getDisplayItems()
+	target := unicode.ToLower(r)
+	for offset := 1; offset <= len(displayItems); offset++ {
+		idx := (a.browser.Cursor + offset) % len(displayItems)
+		title := []rune(displayItems[idx].Title())
+		if len(title) > 0 && unicode.ToLower(title[0]) == target {
+			a.browser.Cursor = idx
+			a.updateVisualSelection()
+			a.clearMessage()
+			return nil
+		}
+	}
+	return a.setMessage(MessageInfo, "No item starting with '"+string(r)+"'")
+}
+
 // updateVisualSelection updates selection when cursor moves in visual mode.
 func (a *App) updateVisualSelection() {
 	if !a.selection.VisualMode {
@@ -3007,6 +4217,7 @@ func (a *App) yankCurrentItem() {
 	// If selection exists, yank all selected items
 	if a.selection.HasSelection() {
 		a.yankedItems = nil
+		a.yankedFromCut = false
 		for _, item := range displayItems {
 			if a.selection.IsSelected(item.ID()) {
 				a.yankedItems = append(a.yankedItems, item)
@@ -3020,6 +4231,7 @@ func (a *App) yankCurrentItem() {
 	// Single item yank
 	item := displayItems[a.browser.Cursor]
 	a.yankedItems = []Item{item}
+	a.yankedFromCut = false
 	a.setStatus("Yanked: " + item.Title())
 }
 
@@ -3041,6 +4253,9 @@ func (a *App) cutCurrentItem() {
 				itemsToCut = append(itemsToCut, item)
 			}
 		}
+		if a.refuseLocked(itemsToCut) {
+			return
+		}
 		// Always confirm batch operations
 		a.modal.DeleteItems = itemsToCut
 		a.mode = ModeConfirmDelete
@@ -3049,9 +4264,12 @@ func (a *App) cutCurrentItem() {
 
 	// Single item cut
 	item := displayItems[a.browser.Cursor]
+	if a.refuseLocked([]Item{item}) {
+		return
+	}
 
 	// Show confirmation if enabled
-	if a.confirmDelete {
+	if a.needsDeleteConfirm(item) {
 		if item.IsFolder() {
 			a.modal.EditItemID = item.Folder.ID
 		} else {
@@ -3063,6 +4281,7 @@ func (a *App) cutCurrentItem() {
 
 	// No confirmation - cut immediately
 	a.yankedItems = []Item{item}
+	a.yankedFromCut = true
 	if item.IsFolder() {
 		a.store.RemoveFolderByID(item.Folder.ID)
 		a.setStatus("Cut: " + item.Folder.Name)
@@ -3096,6 +4315,9 @@ func (a *App) deleteCurrentItem() {
 				itemsToDelete = append(itemsToDelete, item)
 			}
 		}
+		if a.refuseLocked(itemsToDelete) {
+			return
+		}
 		// Always confirm batch operations
 		a.modal.DeleteItems = itemsToDelete
 		a.mode = ModeConfirmDelete
@@ -3104,9 +4326,12 @@ func (a *App) deleteCurrentItem() {
 
 	// Single item delete
 	item := displayItems[a.browser.Cursor]
+	if a.refuseLocked([]Item{item}) {
+		return
+	}
 
 	// Show confirmation if enabled
-	if a.confirmDelete {
+	if a.needsDeleteConfirm(item) {
 		if item.IsFolder() {
 			a.modal.EditItemID = item.Folder.ID
 		} else {
@@ -3132,6 +4357,32 @@ func (a *App) deleteCurrentItem() {
 	}
 }
 
+// needsDeleteConfirm reports whether deleting or cutting a single item asks
+// first: always with confirmDelete on, and for bookmarks also with
+// ConfirmBookmarkDelete set.
+func (a *App) needsDeleteConfirm(item Item) bool {
+	return a.confirmDelete || (!item.IsFolder() && a.config.ConfirmBookmarkDelete)
+}
+
+// isItemLocked reports whether an item is a locked folder or lives inside one.
+func (a *App) isItemLocked(item Item) bool {
+	if item.IsFolder() {
+		return a.store.IsFolderLocked(&item.Folder.ID)
+	}
+	return a.store.IsFolderLocked(item.Bookmark.FolderID)
+}
+
+// refuseLocked reports whether any of the items is locked, showing an error if so.
+func (a *App) refuseLocked(items []Item) bool {
+	for _, item := range items {
+		if a.isItemLocked(item) {
+			a.setMessage(MessageError, "Folder is locked")
+			return true
+		}
+	}
+	return false
+}
+
 // confirmDeleteItem performs the actual deletion after confirmation.
 // Handles both single items and batch operations.
 func (a *App) confirmDeleteItem() {
@@ -3140,6 +4391,7 @@ func (a *App) confirmDeleteItem() {
 		if a.modal.CutMode {
 			a.yankedItems = make([]Item, len(a.modal.DeleteItems))
 			copy(a.yankedItems, a.modal.DeleteItems)
+			a.yankedFromCut = true
 		}
 
 		for _, item := range a.modal.DeleteItems {
@@ -3183,6 +4435,7 @@ func (a *App) confirmDeleteItem() {
 			folderCopy := *folder
 			item := Item{Kind: ItemFolder, Folder: &folderCopy}
 			a.yankedItems = []Item{item}
+			a.yankedFromCut = true
 		}
 		a.store.RemoveFolderByID(a.modal.EditItemID)
 	} else {
@@ -3197,6 +4450,7 @@ func (a *App) confirmDeleteItem() {
 			bookmarkCopy := *bookmark
 			item := Item{Kind: ItemBookmark, Bookmark: &bookmarkCopy}
 			a.yankedItems = []Item{item}
+			a.yankedFromCut = true
 		}
 		a.store.RemoveBookmarkByID(a.modal.EditItemID)
 	}
@@ -3215,6 +4469,274 @@ func (a *App) confirmDeleteItem() {
 	}
 }
 
+// canDeleteKeepingContents reports whether the pending delete is a single
+// non-empty folder, which can be removed while keeping its contents.
+func (a App) canDeleteKeepingContents() bool {
+	if a.modal.CutMode || len(a.modal.DeleteItems) > 0 {
+		return false
+	}
+	return a.store.GetFolderByID(a.modal.EditItemID) != nil && a.store.CountItemsInFolder(a.modal.EditItemID) > 0
+}
+
+// confirmDeleteKeepContents deletes the folder awaiting confirmation and
+// moves its bookmarks and subfolders up to its parent.
+func (a *App) confirmDeleteKeepContents() {
+	folder := a.store.GetFolderByID(a.modal.EditItemID)
+	if folder == nil {
+		return
+	}
+	name := folder.Name
+	a.store.RemoveFolderKeepContents(folder.ID)
+	a.saveStore()
+
+	a.refreshItems()
+	if a.browser.Cursor >= len(a.browser.Items) && a.browser.Cursor > 0 {
+		a.browser.Cursor = len(a.browser.Items) - 1
+	}
+	a.setStatus("Deleted: " + name + " (contents kept)")
+}
+
+// selectedItems returns the selected items in display order.
+func (a App) selectedItems() []Item {
+	var items []Item
+	for _, item := range a.getDisplayItems() {
+		if a.selection.IsSelected(item.ID()) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// countSelectedBookmarks returns how many selected items are bookmarks.
+func (a App) countSelectedBookmarks() int {
+	count := 0
+	for _, item := range a.selectedItems() {
+		if !item.IsFolder() {
+			count++
+		}
+	}
+	return count
+}
+
+// openBulkMenu shows the batch operations available for the current selection.
+func (a *App) openBulkMenu() tea.Cmd {
+	if !a.selection.HasSelection() {
+		return a.setMessage(MessageInfo, "Select items with v/V first")
+	}
+
+	a.bulk.Actions = nil
+	hasBookmarks := a.countSelectedBookmarks() > 0
+	if hasBookmarks {
+		a.bulk.Actions = append(a.bulk.Actions, BulkTag)
+	}
+	a.bulk.Actions = append(a.bulk.Actions, BulkMove, BulkPin)
+	if hasBookmarks {
+		a.bulk.Actions = append(a.bulk.Actions, BulkOpen)
+	}
+	a.bulk.Actions = append(a.bulk.Actions, BulkExport, BulkYank, BulkDelete)
+	a.bulk.MenuCursor = 0
+	a.mode = ModeBulkMenu
+	return nil
+}
+
+// executeBulkAction runs the chosen batch operation via the regular selection-aware handlers.
+func (a App) executeBulkAction(action BulkAction) (tea.Model, tea.Cmd) {
+	a.mode = ModeNormal
+
+	switch action {
+	case BulkTag:
+		a.mode = ModeBulkTag
+		a.bulk.TagInput.Reset()
+		return a, a.bulk.TagInput.Focus()
+	case BulkMove:
+		return a, a.startMove()
+	case BulkPin:
+		return a, a.togglePinCurrentItem()
+	case BulkOpen:
+		return a, a.openSelectedBookmarks()
+	case BulkExport:
+		// Default to a dated HTML file; the extension picks the format
+		a.mode = ModeBulkExport
+		a.bulk.PathInput.Reset()
+		if path, err := exporter.DatedExportPath("bookmarks-selection", "html"); err == nil {
+			a.bulk.PathInput.SetValue(path)
+		}
+		return a, a.bulk.PathInput.Focus()
+	case BulkYank:
+		a.yankCurrentItem()
+	case BulkDelete:
+		// Batch deletes always ask for confirmation
+		a.deleteCurrentItem()
+	}
+	return a, nil
+}
+
+// submitBulkTag adds the entered tags to every selected bookmark.
+func (a App) submitBulkTag() (tea.Model, tea.Cmd) {
+	tags := a.normalizeTags(model.ParseTags(a.bulk.TagInput.Value()))
+	a.mode = ModeNormal
+	a.bulk.TagInput.Blur()
+	if len(tags) == 0 {
+		return a, nil
+	}
+
+	changed := 0
+	for _, item := range a.selectedItems() {
+		if item.IsFolder() {
+			continue
+		}
+		if ok, err := a.store.AddTagsToBookmark(item.Bookmark.ID, tags); err == nil && ok {
+			changed++
+		}
+	}
+
+	a.saveStore()
+	a.clearSelection()
+	a.refreshItems()
+	return a, a.setMessage(MessageSuccess, "Tagged "+strconv.Itoa(changed)+" bookmarks")
+}
+
+// openSelectedBookmarks opens every selected bookmark in the browser,
+// asking for confirmation first when that would open many URLs.
+func (a *App) openSelectedBookmarks() tea.Cmd {
+	if count := a.countSelectedBookmarks(); a.config.ConfirmOpenAll(count) {
+		a.largeOp = LargeOpState{Kind: LargeOpOpen, Count: count}
+		a.mode = ModeConfirmLargeOp
+		return nil
+	}
+	return a.executeOpenSelected()
+}
+
+// executeOpenSelected opens every selected bookmark and records the visits.
+func (a *App) executeOpenSelected() tea.Cmd {
+	var urls []string
+	now := time.Now()
+	for _, item := range a.selectedItems() {
+		if item.IsFolder() {
+			continue
+		}
+		if bookmark := a.store.GetBookmarkByID(item.Bookmark.ID); bookmark != nil {
+			bookmark.MarkVisited(now)
+		}
+		urls = append(urls, item.Bookmark.URL)
+	}
+
+	a.saveStore()
+	a.clearSelection()
+	a.refreshItems()
+	return tea.Batch(
+		openURLsCmd(urls),
+		a.setMessage(MessageSuccess, "Opened "+strconv.Itoa(len(urls))+" bookmarks"),
+	)
+}
+
+// selectionStore copies the selected items into a store of their own, with
+// selected folders bringing all their contents. Selected items become its
+// top level.
+func (a *App) selectionStore() *model.Store {
+	selection := model.NewStore()
+	var addFolder func(id string)
+	addFolder = func(id string) {
+		for _, b := range a.store.GetAllBookmarksInFolder(&id) {
+			selection.AddBookmark(b)
+		}
+		for _, f := range a.store.GetFoldersInFolder(&id) {
+			selection.AddFolder(f)
+			addFolder(f.ID)
+		}
+	}
+	for _, item := range a.selectedItems() {
+		if item.IsFolder() {
+			folder := *item.Folder
+			folder.ParentID = nil
+			selection.AddFolder(folder)
+			addFolder(folder.ID)
+		} else {
+			bookmark := *item.Bookmark
+			bookmark.FolderID = nil
+			selection.AddBookmark(bookmark)
+		}
+	}
+
+	return selection
+}
+
+// exportSelection writes the selected items to the path entered in
+// ModeBulkExport, as HTML, CSV or JSON depending on its extension.
+func (a *App) exportSelection() tea.Cmd {
+	path := strings.TrimSpace(a.bulk.PathInput.Value())
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+
+	selection := a.selectionStore()
+	var content string
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		content = exporter.ExportHTML(selection)
+	case ".csv":
+		content = exporter.ExportCSV(selection)
+	case ".json":
+		content, err = exporter.ExportJSON(selection)
+	default:
+		return a.setMessage(MessageError, "Export to a .html, .csv or .json file")
+	}
+	if err == nil {
+		err = os.WriteFile(path, []byte(content), 0644)
+	}
+	if err != nil {
+		return a.setMessage(MessageError, "Export failed: "+err.Error())
+	}
+
+	a.mode = ModeNormal
+	a.bulk.PathInput.Blur()
+	a.clearSelection()
+	return a.setMessage(MessageSuccess, "Exported "+strconv.Itoa(len(selection.Bookmarks))+" bookmarks to "+path)
+}
+
+// startMove opens the move modal for the selection, or the current item.
+func (a *App) startMove() tea.Cmd {
+	displayItems := a.getDisplayItems()
+	if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
+		return nil
+	}
+
+	// Capture items to move (selected or current)
+	a.move.ItemsToMove = nil
+	if a.selection.HasSelection() {
+		for _, item := range displayItems {
+			if a.selection.IsSelected(item.ID()) {
+				a.move.ItemsToMove = append(a.move.ItemsToMove, item)
+			}
+		}
+	} else {
+		a.move.ItemsToMove = []Item{displayItems[a.browser.Cursor]}
+	}
+	if a.refuseLocked(a.move.ItemsToMove) {
+		a.move.ItemsToMove = nil
+		return nil
+	}
+
+	a.mode = ModeMove
+	a.move.Folders = a.buildFolderPaths()
+	a.move.FilteredFolders = a.move.Folders // Start with all folders
+	a.move.FilterInput.Reset()
+	a.move.FilterInput.Focus()
+	// Find current location in folder list
+	item := displayItems[a.browser.Cursor]
+	currentPath := "/"
+	if item.IsFolder() && item.Folder.ParentID != nil {
+		currentPath = a.store.GetFolderPath(item.Folder.ParentID)
+	} else if !item.IsFolder() && item.Bookmark.FolderID != nil {
+		currentPath = a.store.GetFolderPath(item.Bookmark.FolderID)
+	}
+	a.move.FolderIdx = a.findMoveFolderIndex(currentPath)
+	return a.move.FilterInput.Focus()
+}
+
 // executeMoveItem moves the item(s) to the selected folder.
 func (a *App) executeMoveItem() {
 	if a.move.FolderIdx < 0 || a.move.FolderIdx >= len(a.move.FilteredFolders) {
@@ -3236,8 +4758,54 @@ func (a *App) executeMoveItem() {
 		}
 	}
 
+	items := a.move.ItemsToMove
+	a.move.ItemsToMove = nil
+	a.moveItemsInto(items, targetFolderID, targetPath)
+}
+
+// moveSelectionIntoFolder moves the selected items into the folder under the
+// cursor (gm), like the move picker but without choosing from a list.
+func (a App) moveSelectionIntoFolder() (tea.Model, tea.Cmd) {
+	if !a.selection.HasSelection() {
+		return a, a.setMessage(MessageInfo, "Select items first, then gm on a folder")
+	}
+	displayItems := a.getDisplayItems()
+	if a.browser.Cursor >= len(displayItems) || !displayItems[a.browser.Cursor].IsFolder() {
+		return a, a.setMessage(MessageInfo, "Move the cursor onto a folder to move the selection into")
+	}
+	items := a.selectedItems()
+	if a.refuseLocked(items) {
+		return a, nil
+	}
+
+	// Go through the move picker's execution path, including its confirmation
+	// for moves of many items
+	target := displayItems[a.browser.Cursor].Folder.ID
+	a.move.ItemsToMove = items
+	a.move.FilteredFolders = []string{a.store.GetFolderPath(&target)}
+	a.move.FolderIdx = 0
+	if count := a.countAffectedItems(items); a.isLargeOperation(count) {
+		a.largeOp = LargeOpState{Kind: LargeOpMove, Count: count}
+		a.mode = ModeConfirmLargeOp
+		return a, nil
+	}
+	a.executeMoveItem()
+	return a, nil
+}
+
+// moveItemsInto reparents items under targetFolderID (nil for root), skipping
+// folders that would end up inside themselves or beyond maxFolderDepth, and
+// reports the destination. Nothing moves into a locked folder.
+func (a *App) moveItemsInto(items []Item, targetFolderID *string, targetPath string) {
+	if a.store.IsFolderLocked(targetFolderID) {
+		a.setMessage(MessageError, "Folder is locked")
+		return
+	}
+
 	movedCount := 0
-	for _, item := range a.move.ItemsToMove {
+	tooDeep := 0
+	cyclic := 0
+	for _, item := range items {
 		if item.IsFolder() {
 			folder := a.store.GetFolderByID(item.Folder.ID)
 			if folder == nil {
@@ -3246,8 +4814,13 @@ func (a *App) executeMoveItem() {
 
 			// Prevent moving folder into itself or its descendants
 			if targetFolderID != nil && a.isFolderDescendant(item.Folder.ID, *targetFolderID) {
+				cyclic++
 				continue // Skip this one, don't abort the whole operation
 			}
+			if a.exceedsMaxDepth(targetFolderID, a.store.SubtreeHeight(folder.ID)) {
+				tooDeep++
+				continue
+			}
 
 			folder.ParentID = targetFolderID
 			movedCount++
@@ -3264,7 +4837,6 @@ func (a *App) executeMoveItem() {
 
 	a.saveStore()
 	a.clearSelection()
-	a.move.ItemsToMove = nil
 	a.refreshItems()
 	a.refreshPinnedItems()
 
@@ -3276,11 +4848,19 @@ func (a *App) executeMoveItem() {
 		a.browser.Cursor = 0
 	}
 
-	if movedCount == 1 {
+	switch {
+	case movedCount == 0 && tooDeep > 0:
+		a.setStatus(a.maxDepthMessage())
+	case movedCount == 0 && cyclic > 0:
+		a.setMessage(MessageError, "Can't move a folder into itself")
+	case movedCount == 1:
 		a.setStatus("Moved item → " + targetPath)
-	} else {
+	default:
 		a.setStatus("Moved " + strconv.Itoa(movedCount) + " items → " + targetPath)
 	}
+	if movedCount > 0 && tooDeep > 0 {
+		a.setStatus(a.messageText + " (" + strconv.Itoa(tooDeep) + " skipped: max folder depth)")
+	}
 }
 
 // isFolderDescendant checks if targetID is the same as or a descendant of folderID.
@@ -3301,12 +4881,52 @@ func (a *App) isFolderDescendant(folderID, targetID string) bool {
 	return false
 }
 
-// pasteItem pastes the yanked item(s) before or after the cursor.
+// countAffectedItems returns how many items an operation on items touches,
+// counting everything nested inside folders.
+func (a *App) countAffectedItems(items []Item) int {
+	count := 0
+	for _, item := range items {
+		count++
+		if item.IsFolder() {
+			count += a.store.CountItemsInFolder(item.Folder.ID)
+		}
+	}
+	return count
+}
+
+// isLargeOperation reports whether touching count items needs confirmation.
+func (a *App) isLargeOperation(count int) bool {
+	threshold := a.config.LargeOperationThreshold
+	return threshold > 0 && count > threshold
+}
+
+// pasteItem pastes the yanked item(s) before or after the cursor,
+// asking for confirmation first when the paste affects many items.
 func (a *App) pasteItem(before bool) {
 	if len(a.yankedItems) == 0 {
 		a.setStatus("Nothing to paste")
 		return
 	}
+	if a.store.IsFolderLocked(a.browser.CurrentFolderID) {
+		a.setMessage(MessageError, "Folder is locked")
+		return
+	}
+
+	if count := a.countAffectedItems(a.yankedItems); a.isLargeOperation(count) {
+		a.largeOp = LargeOpState{Kind: LargeOpPaste, Before: before, Count: count}
+		a.mode = ModeConfirmLargeOp
+		return
+	}
+	a.executePaste(before)
+}
+
+// executePaste inserts the yanked item(s) before or after the cursor.
+// Cut items are moved back in with their original IDs; yanked items (and
+// repeated pastes of a cut) become fresh copies, folders including their contents.
+func (a *App) executePaste(before bool) {
+	if len(a.yankedItems) == 0 {
+		return
+	}
 
 	// Calculate insert position
 	insertIdx := a.browser.Cursor
@@ -3324,13 +4944,27 @@ func (a *App) pasteItem(before bool) {
 
 	// Paste all yanked items
 	pastedCount := 0
+	tooDeep := 0
 	for _, yankedItem := range a.yankedItems {
 		if yankedItem.IsFolder() {
-			// Create a copy with new ID
-			newFolder := model.NewFolder(model.NewFolderParams{
-				Name:     yankedItem.Folder.Name,
-				ParentID: a.browser.CurrentFolderID,
-			})
+			if a.exceedsMaxDepth(a.browser.CurrentFolderID, a.store.SubtreeHeight(yankedItem.Folder.ID)) {
+				tooDeep++
+				continue
+			}
+			var newFolder model.Folder
+			deepCopy := false
+			if a.yankedFromCut && a.store.GetFolderByID(yankedItem.Folder.ID) == nil {
+				// Move the cut original back in, keeping its ID (its contents follow)
+				newFolder = *yankedItem.Folder
+				newFolder.ParentID = a.browser.CurrentFolderID
+			} else {
+				// Create a copy with new ID
+				newFolder = model.NewFolder(model.NewFolderParams{
+					Name:     yankedItem.Folder.Name,
+					ParentID: a.browser.CurrentFolderID,
+				})
+				deepCopy = true
+			}
 
 			// If pasting among folders
 			if insertIdx <= folderCount {
@@ -3340,14 +4974,24 @@ func (a *App) pasteItem(before bool) {
 			} else {
 				a.store.AddFolder(newFolder)
 			}
+			if deepCopy {
+				a.store.CopyFolderContents(yankedItem.Folder.ID, newFolder.ID)
+			}
 		} else {
-			// Create a copy with new ID
-			newBookmark := model.NewBookmark(model.NewBookmarkParams{
-				Title:    yankedItem.Bookmark.Title,
-				URL:      yankedItem.Bookmark.URL,
-				FolderID: a.browser.CurrentFolderID,
-				Tags:     yankedItem.Bookmark.Tags,
-			})
+			var newBookmark model.Bookmark
+			if a.yankedFromCut && a.store.GetBookmarkByID(yankedItem.Bookmark.ID) == nil {
+				// Move the cut original back in, keeping its ID, timestamps and visit history
+				newBookmark = *yankedItem.Bookmark
+				newBookmark.FolderID = a.browser.CurrentFolderID
+			} else {
+				// Create a copy with new ID
+				newBookmark = model.NewBookmark(model.NewBookmarkParams{
+					Title:    yankedItem.Bookmark.Title,
+					URL:      yankedItem.Bookmark.URL,
+					FolderID: a.browser.CurrentFolderID,
+					Tags:     yankedItem.Bookmark.Tags,
+				})
+			}
 
 			// Bookmarks come after folders in the view
 			bookmarkIdx := insertIdx - folderCount
@@ -3361,33 +5005,40 @@ func (a *App) pasteItem(before bool) {
 		pastedCount++
 	}
 
+	if pastedCount == 0 && tooDeep > 0 {
+		a.setStatus(a.maxDepthMessage())
+		return
+	}
+
 	a.saveStore()
 	a.refreshItems()
-	if pastedCount == 1 {
+	if pastedCount == 1 && tooDeep == 0 {
 		a.setStatus("Pasted: " + a.yankedItems[0].Title())
 	} else {
 		a.setStatus("Pasted " + strconv.Itoa(pastedCount) + " items")
 	}
+	if tooDeep > 0 {
+		a.setStatus(a.messageText + " (" + strconv.Itoa(tooDeep) + " skipped: max folder depth)")
+	}
+}
+
+// openURLsCmd opens several URLs in the default browser, opener.Stagger
+// apart. All multi-open paths go through here; callers apply the
+// OpenAllThreshold guard.
+func openURLsCmd(urls []string) tea.Cmd {
+	return func() tea.Msg {
+		if err := opener.OpenAll(urls); err != nil {
+			return openURLErrorMsg{err: err}
+		}
+		return nil
+	}
 }
 
 // openURLCmd returns a tea.Cmd that opens a URL in the default browser.
 func openURLCmd(url string) tea.Cmd {
 	return func() tea.Msg {
-		var openCmd *exec.Cmd
-		switch runtime.GOOS {
-		case "darwin":
-			openCmd = exec.Command("open", url)
-		case "linux":
-			openCmd = exec.Command("xdg-open", url)
-			// Detach process so it survives parent exit
-			openCmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
-		case "windows":
-			openCmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-		}
-		if openCmd != nil {
-			if err := openCmd.Start(); err != nil {
-				return openURLErrorMsg{err: err}
-			}
+		if err := opener.Open(url); err != nil {
+			return openURLErrorMsg{err: err}
 		}
 		return nil
 	}
@@ -3404,20 +5055,123 @@ func (a App) openBookmark() (tea.Model, tea.Cmd) {
 	if item.IsFolder() {
 		return a, nil
 	}
+	if a.config.VerifyBeforeOpen {
+		return a.startVerifyOpen(item.Bookmark)
+	}
+	return a.openBookmarkURL(*item.Bookmark)
+}
 
+// openBookmarkURL marks b visited, saves, opens its URL and quits.
+func (a App) openBookmarkURL(b model.Bookmark) (tea.Model, tea.Cmd) {
 	// Update visitedAt timestamp
-	bookmark := a.store.GetBookmarkByID(item.Bookmark.ID)
+	bookmark := a.store.GetBookmarkByID(b.ID)
 	if bookmark != nil {
-		now := time.Now()
-		bookmark.VisitedAt = &now
+		bookmark.MarkVisited(time.Now())
+		a.saveStore()
 		a.refreshItems()
 	}
+	a.flushSave() // bm quits next, so don't wait for a debounced save
 
-	return a, tea.Batch(openURLCmd(item.Bookmark.URL), tea.Quit)
+	return a, tea.Batch(openURLCmd(b.URL), tea.Quit)
 }
 
 // clipboardSuccessMsg is sent when clipboard write succeeds.
-type clipboardSuccessMsg struct{}
+type clipboardSuccessMsg struct {
+	label string // what was copied, e.g. "URL"
+}
+
+// startReadLater validates rawURL and starts the AI-powered quick add to the
+// Read Later folder. invalidMsg is shown if rawURL isn't an http(s) URL.
+func (a App) startReadLater(rawURL, invalidMsg string) (tea.Model, tea.Cmd) {
+	if !isWebURL(rawURL) {
+		cmd := a.setMessage(MessageError, invalidMsg)
+		return a, cmd
+	}
+	a.readLaterURL = rawURL
+	a.mode = ModeReadLaterLoading
+	cmd := a.setMessage(MessageInfo, "Adding to "+a.config.QuickAddFolder+"...")
+	aiCmd := a.callAICmd(rawURL)
+	return a, tea.Batch(cmd, aiCmd)
+}
+
+// isWebURL reports whether raw is an http(s) URL.
+func isWebURL(raw string) bool {
+	parsedURL, err := url.Parse(raw)
+	return err == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https")
+}
+
+// startInboxCapture saves the clipboard URL to the quick add folder right
+// away, without AI. Without a clipboard it prompts for the URL instead.
+func (a App) startInboxCapture() (tea.Model, tea.Cmd) {
+	clipContent := strings.TrimSpace(a.readClipboard())
+	if !a.clipboardOK {
+		a.mode = ModeQuickAdd
+		a.quickAdd.Reset()
+		a.quickAdd.Inbox = true
+		return a, tea.Batch(a.quickAdd.Input.Focus(), a.noteClipboardUnavailable())
+	}
+	if clipContent == "" {
+		cmd := a.setMessage(MessageError, "No URL in clipboard")
+		return a, cmd
+	}
+	return a.captureToInbox(clipContent, "Invalid URL in clipboard")
+}
+
+// captureToInbox adds rawURL to the quick add folder with the URL as title
+// and no tags. It never calls the AI, so it works offline and instantly.
+func (a App) captureToInbox(rawURL, invalidMsg string) (tea.Model, tea.Cmd) {
+	a.mode = ModeNormal
+	if !isWebURL(rawURL) {
+		cmd := a.setMessage(MessageError, invalidMsg)
+		return a, cmd
+	}
+
+	folder, _ := a.store.GetOrCreateFolderByPath(a.config.QuickAddFolder)
+	var folderID *string
+	if folder != nil {
+		folderID = &folder.ID
+	}
+	a.store.AddBookmark(model.NewBookmark(model.NewBookmarkParams{
+		Title:    rawURL,
+		URL:      rawURL,
+		FolderID: folderID,
+		Tags:     []string{},
+	}))
+	a.saveStore()
+	a.refreshItems()
+
+	cmd := a.setMessage(MessageSuccess, "Captured to "+a.config.QuickAddFolder)
+	return a, cmd
+}
+
+// requeueReadLater moves the bookmark under the cursor back to the quick add
+// folder (gL), so it shows up with the other unread links again.
+func (a App) requeueReadLater() (tea.Model, tea.Cmd) {
+	item := a.focusedItem()
+	if item == nil || item.IsFolder() || a.refuseLocked([]Item{*item}) {
+		return a, nil
+	}
+	folder, _ := a.store.GetOrCreateFolderByPath(a.config.QuickAddFolder)
+	bookmark := a.store.GetBookmarkByID(item.Bookmark.ID)
+	if folder == nil || bookmark == nil {
+		return a, nil
+	}
+	if bookmark.FolderID != nil && *bookmark.FolderID == folder.ID {
+		return a, a.setMessage(MessageInfo, "Already in "+a.config.QuickAddFolder)
+	}
+
+	folderID := folder.ID
+	bookmark.FolderID = &folderID
+	a.saveStore()
+	a.refreshItems()
+	a.refreshPinnedItems()
+	if a.browser.Cursor >= len(a.browser.Items) && a.browser.Cursor > 0 {
+		a.browser.Cursor = len(a.browser.Items) - 1
+	}
+
+	cmd := a.setMessage(MessageSuccess, "Moved to "+a.config.QuickAddFolder+": "+bookmark.Title)
+	return a, cmd
+}
 
 // yankURLToClipboard copies the selected bookmark URL to system clipboard.
 func (a App) yankURLToClipboard() (tea.Model, tea.Cmd) {
@@ -3431,15 +5185,41 @@ func (a App) yankURLToClipboard() (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
-	url := item.Bookmark.URL
-	cmd := func() tea.Msg {
-		if err := clipboard.WriteAll(url); err != nil {
-			return clipboardErrorMsg{err: err}
-		}
-		return clipboardSuccessMsg{}
+	return a, a.writeClipboardCmd(item.Bookmark.URL, "URL")
+}
+
+// yankDomainCmd copies the host of a bookmark's URL (e.g. "example.com") to
+// the clipboard. Folders are ignored.
+func (a *App) yankDomainCmd(item *Item) tea.Cmd {
+	if item == nil || item.IsFolder() {
+		return nil
+	}
+	host := model.URLHost(item.Bookmark.URL)
+	if host == "" {
+		return a.setMessage(MessageError, "No domain in URL")
 	}
+	return a.writeClipboardCmd(host, "Domain")
+}
 
-	return a, cmd
+// copyLinkToClipboard copies the selected bookmark rendered through the
+// configured link format (markdown by default) to the system clipboard.
+func (a App) copyLinkToClipboard() (tea.Model, tea.Cmd) {
+	displayItems := a.getDisplayItems()
+	if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
+		return a, nil
+	}
+
+	item := displayItems[a.browser.Cursor]
+	if item.IsFolder() {
+		return a, nil
+	}
+
+	format := a.config.CopyLinkFormat
+	if format == "" {
+		format = model.MarkdownLinkFormat
+	}
+	link := model.FormatLink(format, *item.Bookmark)
+	return a, a.writeClipboardCmd(link, "Link")
 }
 
 // View implements tea.Model.
@@ -3461,13 +5241,63 @@ func (a *App) applyFilter() {
 		a.search.FilteredItems[i] = a.browser.Items[m.Index]
 	}
 
-	// Reset cursor if out of bounds
+	// Reset cursor if out of bounds (a single match always ends up selected)
 	displayItems := a.getDisplayItems()
 	if a.browser.Cursor >= len(displayItems) {
 		a.browser.Cursor = 0
 	}
 }
 
+// activateBrowserItem enters the folder or opens the bookmark under the cursor.
+func (a App) activateBrowserItem() (tea.Model, tea.Cmd) {
+	displayItems := a.getDisplayItems()
+	if len(displayItems) == 0 || a.browser.Cursor >= len(displayItems) {
+		return a, nil
+	}
+	item := displayItems[a.browser.Cursor]
+	if !item.IsFolder() {
+		return a.openBookmark()
+	}
+	// Push current folder to stack
+	if a.browser.CurrentFolderID != nil {
+		a.browser.FolderStack = append(a.browser.FolderStack, *a.browser.CurrentFolderID)
+	}
+	// Enter the folder
+	id := item.Folder.ID
+	a.browser.CurrentFolderID = &id
+	a.browser.Cursor = 0
+	a.refreshItems()
+	return a, nil
+}
+
+// switchSiblingFolder moves from the current folder to the sibling delta
+// places away in the parent's listing (ctrl+n/ctrl+p), without going up.
+func (a App) switchSiblingFolder(delta int) (tea.Model, tea.Cmd) {
+	if a.browser.CurrentFolderID == nil {
+		return a, nil
+	}
+	current := a.store.GetFolderByID(*a.browser.CurrentFolderID)
+	if current == nil {
+		return a, nil
+	}
+	siblings := a.sortedFolders(current.ParentID)
+	idx := slices.IndexFunc(siblings, func(f model.Folder) bool { return f.ID == current.ID })
+	next := idx + delta
+	if idx < 0 || next < 0 || next >= len(siblings) {
+		if delta > 0 {
+			return a, a.setMessage(MessageInfo, "No next folder")
+		}
+		return a, a.setMessage(MessageInfo, "No previous folder")
+	}
+
+	// Same parent, so the folder stack stays as it is
+	id := siblings[next].ID
+	a.browser.CurrentFolderID = &id
+	a.browser.Cursor = 0
+	a.refreshItems()
+	return a, nil
+}
+
 // getDisplayItems returns filtered items if filter is active, otherwise all items.
 func (a *App) getDisplayItems() []Item {
 	if a.search.FilterQuery != "" && a.search.FilteredItems != nil {
@@ -3546,6 +5376,37 @@ func (a *App) buildOrderedFolderPaths(currentFolderID *string, aiSuggestion stri
 	return paths
 }
 
+// revealBookmark navigates the browser to the bookmark's folder and puts the
+// cursor on it.
+func (a *App) revealBookmark(bookmark *model.Bookmark) {
+	a.browser.FolderStack = []string{}
+	if bookmark.FolderID != nil {
+		// Bookmark is in a folder - navigate there
+		folder := a.store.GetFolderByID(*bookmark.FolderID)
+		if folder != nil {
+			a.buildFolderStack(folder.ParentID)
+		}
+		a.browser.CurrentFolderID = bookmark.FolderID
+	} else {
+		// Bookmark is at root
+		a.browser.CurrentFolderID = nil
+	}
+
+	a.refreshItems()
+	a.cursorToBookmark(bookmark.ID)
+}
+
+// cursorToBookmark puts the browser cursor on the bookmark with the given ID,
+// if it is in the current folder.
+func (a *App) cursorToBookmark(id string) {
+	for i, item := range a.browser.Items {
+		if !item.IsFolder() && item.Bookmark.ID == id {
+			a.browser.Cursor = i
+			return
+		}
+	}
+}
+
 // findFolderIndex finds the index of a folder path in quickAdd FilteredFolders.
 func (a *App) findFolderIndex(path string) int {
 	for i, p := range a.quickAdd.FilteredFolders {
@@ -3606,45 +5467,124 @@ func (a *App) updateQuickAddFilter() {
 	}
 }
 
+// newAIClient constructs the AI client, or fails with ai.ErrDisabled when AI
+// is turned off.
+func (a *App) newAIClient() (*ai.Client, error) {
+	if a.aiDisabled {
+		return nil, ai.ErrDisabled
+	}
+	return a.aiClient()
+}
+
+// startTask cancels any running cull or AI task and returns the context and
+// generation for a new one. Its result message carries the generation, so a
+// result that arrives after Esc (or after a newer task started) is dropped.
+func (a *App) startTask() (context.Context, int) {
+	a.cancelTask()
+	ctx, cancel := context.WithCancel(context.Background())
+	a.taskCancel = cancel
+	return ctx, a.taskGen
+}
+
+// cancelTask aborts the running task, if any, and invalidates its result.
+func (a *App) cancelTask() {
+	if a.taskCancel != nil {
+		a.taskCancel()
+		a.taskCancel = nil
+	}
+	a.taskGen++
+}
+
+// finishTask releases the context of a task whose result has arrived.
+func (a *App) finishTask() {
+	if a.taskCancel != nil {
+		a.taskCancel()
+		a.taskCancel = nil
+	}
+}
+
+// maxQuickAddRegenerations caps how often Ctrl+r may ask the AI again for
+// one quick add.
+const maxQuickAddRegenerations = 3
+
+// regenerateQuickAdd asks the AI again for the quick add URL; the new
+// suggestion replaces the confirm inputs once it arrives.
+func (a App) regenerateQuickAdd() (tea.Model, tea.Cmd) {
+	if a.quickAdd.Response == nil {
+		return a, nil // template add, there is no suggestion to redo
+	}
+	if a.quickAdd.Regenerations >= maxQuickAddRegenerations {
+		cmd := a.setMessage(MessageError, "Already regenerated "+strconv.Itoa(maxQuickAddRegenerations)+" times")
+		return a, cmd
+	}
+	a.quickAdd.Regenerations++
+	a.mode = ModeQuickAddLoading
+	cmd := a.callAICmd(strings.TrimSpace(a.quickAdd.Input.Value()))
+	return a, cmd
+}
+
 // callAICmd returns a tea.Cmd that calls the AI API.
 func (a *App) callAICmd(url string) tea.Cmd {
+	ctx, gen := a.startTask()
 	return func() tea.Msg {
-		client, err := ai.NewClient()
+		client, err := a.newAIClient()
 		if err != nil {
-			return aiResponseMsg{err: err}
+			return aiResponseMsg{gen: gen, err: err}
 		}
 
-		context := ai.BuildContext(a.store)
-		response, err := client.SuggestBookmark(url, context)
-		return aiResponseMsg{response: response, err: err}
+		suggest := client.SuggestBookmark
+		if a.suggest != nil {
+			suggest = a.suggest
+		}
+		storeContext := ai.BuildContext(a.store)
+		response, err := suggest(ctx, url, storeContext)
+		return aiResponseMsg{gen: gen, response: response, err: err}
 	}
 }
 
 // startCullCmd returns a tea.Cmd that starts the URL cull check.
 func (a *App) startCullCmd() tea.Cmd {
-	bookmarks := make([]model.Bookmark, len(a.store.Bookmarks))
-	copy(bookmarks, a.store.Bookmarks)
+	// Archived bookmarks are never checked, whatever the display toggle says
+	bookmarks := a.store.GetActiveBookmarks()
 
 	excludeDomains := a.config.CullExcludeDomains
 
+	// An unreadable ignore file is reported, and the cull checks everything
+	var warn tea.Cmd
+	ignore, err := loadCullIgnore()
+	if err != nil {
+		warn = a.setMessage(MessageWarning, "Ignoring unreadable cull-ignore file: "+err.Error())
+	}
+
 	// Reset the atomic progress counter
 	atomic.StoreInt64(&cullProgressCounter, 0)
+	ctx, gen := a.startTask()
 
 	// Start both the cull operation and the ticker
 	return tea.Batch(
+		warn,
 		// Cull operation
 		func() tea.Msg {
 			onProgress := func(completed, total int) {
 				atomic.StoreInt64(&cullProgressCounter, int64(completed))
 			}
-			results := culler.CheckURLs(bookmarks, 10, 10*time.Second, excludeDomains, onProgress)
-			return cullCompleteMsg{results: results}
+			results := culler.CheckURLs(ctx, bookmarks, 10, 10*time.Second, excludeDomains, ignore, onProgress)
+			return cullCompleteMsg{gen: gen, results: results}
 		},
 		// Start the ticker to update UI
 		cullTickCmd(),
 	)
 }
 
+// loadCullIgnore reads the patterns of the cull-ignore file, if there is one.
+func loadCullIgnore() ([]string, error) {
+	path, err := storage.CullIgnoreFilePath()
+	if err != nil {
+		return nil, err
+	}
+	return culler.LoadIgnoreFile(path)
+}
+
 // cullTickCmd returns a command that ticks every 100ms to update progress.
 func cullTickCmd() tea.Cmd {
 	return tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
@@ -3684,29 +5624,46 @@ func (a *App) computeBookmarkChecksum() string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// cullCachePath returns the path to the cull cache file.
-func cullCachePath() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+// recordCullRun appends a summary of the run to the cull history, if the
+// storage backend keeps one.
+func (a *App) recordCullRun(results []culler.Result) error {
+	hs, ok := a.storage.(storage.CullHistoryStorage)
+	if !ok {
+		return nil
+	}
+	// Skipped bookmarks weren't checked, so they don't count towards the trend
+	run := storage.CullRun{Timestamp: time.Now(), Total: len(results) - countCullSkipped(results)}
+	for _, r := range results {
+		switch r.Status {
+		case culler.Dead:
+			run.Dead++
+		case culler.Unreachable:
+			run.Unreachable++
+		}
 	}
-	return filepath.Join(homeDir, ".config", "bm", "cull-cache.json"), nil
+	return hs.AppendCullRun(run)
 }
 
-// saveCullCache saves cull results to disk.
-func (a *App) saveCullCache(results []culler.Result) error {
-	path, err := cullCachePath()
-	if err != nil {
-		return err
+// countCullSkipped returns how many results matched the cull-ignore file.
+func countCullSkipped(results []culler.Result) int {
+	n := 0
+	for _, r := range results {
+		if r.Status == culler.Skipped {
+			n++
+		}
 	}
+	return n
+}
 
+// saveCullCache saves cull results to the database, or to disk for backends without cache support.
+func (a *App) saveCullCache(results []culler.Result) error {
 	// Convert to serializable format
-	cacheResults := make([]CullCacheResult, 0, len(results))
+	cacheResults := make([]storage.CullCacheResult, 0, len(results))
 	for _, r := range results {
-		if r.Status == culler.Healthy {
+		if r.Status == culler.Healthy || r.Status == culler.Skipped {
 			continue // Only cache problematic results
 		}
-		cacheResults = append(cacheResults, CullCacheResult{
+		cacheResults = append(cacheResults, storage.CullCacheResult{
 			BookmarkID: r.Bookmark.ID,
 			Status:     int(r.Status),
 			StatusCode: r.StatusCode,
@@ -3714,37 +5671,30 @@ func (a *App) saveCullCache(results []culler.Result) error {
 		})
 	}
 
-	cache := CullCache{
+	cache := &storage.CullCache{
 		Timestamp: time.Now(),
 		Checksum:  a.computeBookmarkChecksum(),
 		Results:   cacheResults,
 	}
 
-	data, err := json.MarshalIndent(cache, "", "  ")
+	if cs, ok := a.storage.(storage.CacheStorage); ok {
+		return cs.SaveCullCache(cache)
+	}
+
+	path, err := storage.CullCacheFilePath()
 	if err != nil {
 		return err
 	}
-
-	return os.WriteFile(path, data, 0644)
+	return storage.WriteCacheFile(path, cache)
 }
 
-// loadCullCache loads cull results from disk and matches with current bookmarks.
+// loadCullCache loads cached cull results and matches them with current bookmarks.
 func (a *App) loadCullCache() ([]culler.Result, time.Time, error) {
-	path, err := cullCachePath()
-	if err != nil {
-		return nil, time.Time{}, err
-	}
-
-	data, err := os.ReadFile(path)
+	cache, err := storage.ReadCullCache(a.storage)
 	if err != nil {
 		return nil, time.Time{}, err
 	}
 
-	var cache CullCache
-	if err := json.Unmarshal(data, &cache); err != nil {
-		return nil, time.Time{}, err
-	}
-
 	// Build bookmark ID map for fast lookup
 	bookmarkMap := make(map[string]*model.Bookmark)
 	for i := range a.store.Bookmarks {
@@ -3769,64 +5719,66 @@ func (a *App) loadCullCache() ([]culler.Result, time.Time, error) {
 	return results, cache.Timestamp, nil
 }
 
-// checkCullCache checks if a cache file exists and validates its checksum.
-func (a *App) checkCullCache() {
-	path, err := cullCachePath()
-	if err != nil {
-		a.cull.HasCache = false
-		return
-	}
-
-	data, err := os.ReadFile(path)
+// checkCullCache checks if a cache exists and validates its checksum.
+// It returns a warning if an unreadable cache had to be ignored.
+func (a *App) checkCullCache() tea.Cmd {
+	cache, err := storage.ReadCullCache(a.storage)
 	if err != nil {
 		a.cull.HasCache = false
-		return
-	}
-
-	var cache CullCache
-	if err := json.Unmarshal(data, &cache); err != nil {
-		a.cull.HasCache = false
-		return
+		if errors.Is(err, storage.ErrCorruptCache) {
+			return a.setMessage(MessageWarning, "Ignoring unreadable cull cache")
+		}
+		return nil
 	}
 
 	// Validate checksum - if bookmarks changed, cache is stale
 	currentChecksum := a.computeBookmarkChecksum()
 	if cache.Checksum != "" && cache.Checksum != currentChecksum {
 		a.cull.HasCache = false
-		return
+		return nil
 	}
 
 	a.cull.HasCache = true
 	a.cull.CacheTime = cache.Timestamp
+	return nil
 }
 
-// countCachedProblems returns the count of problematic bookmarks in cache.
-func (a *App) countCachedProblems() int {
+// loadBrokenIDs seeds the breadcrumb's broken-link indicator from the cull cache.
+func (a *App) loadBrokenIDs() {
 	results, _, err := a.loadCullCache()
 	if err != nil {
-		return 0
+		a.brokenIDs = nil
+		if errors.Is(err, storage.ErrCorruptCache) {
+			a.setStatus("Ignoring unreadable cull cache")
+		}
+		return
 	}
-	return len(results)
+	a.setBrokenIDs(results)
 }
 
-// organizeCachePath returns the path to the organize cache file.
-func organizeCachePath() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+// setBrokenIDs records which bookmarks the cull results flag as dead or unreachable.
+func (a *App) setBrokenIDs(results []culler.Result) {
+	a.brokenIDs = make(map[string]bool)
+	for _, r := range results {
+		if r.Status == culler.Dead || r.Status == culler.Unreachable {
+			a.brokenIDs[r.Bookmark.ID] = true
+		}
 	}
-	return filepath.Join(homeDir, ".config", "bm", "organize-cache.json"), nil
 }
 
-// saveOrganizeCache saves organize suggestions to disk.
-func (a *App) saveOrganizeCache(suggestions []OrganizeSuggestion) error {
-	path, err := organizeCachePath()
+// countCachedProblems returns the count of problematic bookmarks in cache.
+func (a *App) countCachedProblems() int {
+	results, _, err := a.loadCullCache()
 	if err != nil {
-		return err
+		return 0
 	}
+	return len(results)
+}
 
+// saveOrganizeCache saves organize suggestions to the database, or to disk for backends without cache support.
+func (a *App) saveOrganizeCache(suggestions []OrganizeSuggestion) error {
 	// Convert to serializable format
-	cacheResults := make([]OrganizeCacheResult, 0, len(suggestions))
+	cacheResults := make([]storage.OrganizeCacheResult, 0, len(suggestions))
 	for _, s := range suggestions {
 		var itemID string
 		var isFolder bool
@@ -3837,7 +5789,7 @@ func (a *App) saveOrganizeCache(suggestions []OrganizeSuggestion) error {
 			itemID = s.Item.Bookmark.ID
 			isFolder = false
 		}
-		cacheResults = append(cacheResults, OrganizeCacheResult{
+		cacheResults = append(cacheResults, storage.OrganizeCacheResult{
 			ItemID:        itemID,
 			IsFolder:      isFolder,
 			CurrentPath:   s.CurrentPath,
@@ -3848,37 +5800,30 @@ func (a *App) saveOrganizeCache(suggestions []OrganizeSuggestion) error {
 		})
 	}
 
-	cache := OrganizeCache{
+	cache := &storage.OrganizeCache{
 		Timestamp: time.Now(),
 		Checksum:  a.computeBookmarkChecksum(),
 		Results:   cacheResults,
 	}
 
-	data, err := json.MarshalIndent(cache, "", "  ")
+	if cs, ok := a.storage.(storage.CacheStorage); ok {
+		return cs.SaveOrganizeCache(cache)
+	}
+
+	path, err := storage.OrganizeCacheFilePath()
 	if err != nil {
 		return err
 	}
-
-	return os.WriteFile(path, data, 0644)
+	return storage.WriteCacheFile(path, cache)
 }
 
-// loadOrganizeCache loads organize suggestions from disk and matches with current items.
+// loadOrganizeCache loads cached organize suggestions and matches them with current items.
 func (a *App) loadOrganizeCache() ([]OrganizeSuggestion, time.Time, error) {
-	path, err := organizeCachePath()
-	if err != nil {
-		return nil, time.Time{}, err
-	}
-
-	data, err := os.ReadFile(path)
+	cache, err := storage.ReadOrganizeCache(a.storage)
 	if err != nil {
 		return nil, time.Time{}, err
 	}
 
-	var cache OrganizeCache
-	if err := json.Unmarshal(data, &cache); err != nil {
-		return nil, time.Time{}, err
-	}
-
 	// Build maps for fast lookup
 	bookmarkMap := make(map[string]*model.Bookmark)
 	for i := range a.store.Bookmarks {
@@ -3920,35 +5865,28 @@ func (a *App) loadOrganizeCache() ([]OrganizeSuggestion, time.Time, error) {
 	return suggestions, cache.Timestamp, nil
 }
 
-// checkOrganizeCache checks if a cache file exists and validates its checksum.
-func (a *App) checkOrganizeCache() {
-	path, err := organizeCachePath()
-	if err != nil {
-		a.organize.HasCache = false
-		return
-	}
-
-	data, err := os.ReadFile(path)
+// checkOrganizeCache checks if a cache exists and validates its checksum.
+// It returns a warning if an unreadable cache had to be ignored.
+func (a *App) checkOrganizeCache() tea.Cmd {
+	cache, err := storage.ReadOrganizeCache(a.storage)
 	if err != nil {
 		a.organize.HasCache = false
-		return
-	}
-
-	var cache OrganizeCache
-	if err := json.Unmarshal(data, &cache); err != nil {
-		a.organize.HasCache = false
-		return
+		if errors.Is(err, storage.ErrCorruptCache) {
+			return a.setMessage(MessageWarning, "Ignoring unreadable organize cache")
+		}
+		return nil
 	}
 
 	// Validate checksum - if bookmarks changed, cache is stale
 	currentChecksum := a.computeBookmarkChecksum()
 	if cache.Checksum != "" && cache.Checksum != currentChecksum {
 		a.organize.HasCache = false
-		return
+		return nil
 	}
 
 	a.organize.HasCache = true
 	a.organize.CacheTime = cache.Timestamp
+	return nil
 }
 
 // countCachedOrganizeSuggestions returns the count of suggestions in cache.
@@ -3966,8 +5904,8 @@ func (a *App) groupCullResults(results []culler.Result) []CullGroup {
 	groupMap := make(map[string]*CullGroup)
 
 	for _, r := range results {
-		if r.Status == culler.Healthy {
-			continue // Skip healthy items
+		if r.Status == culler.Healthy || r.Status == culler.Skipped {
+			continue // Skip healthy and ignored items
 		}
 
 		var key, label, desc string
@@ -4028,6 +5966,9 @@ func (a *App) cullDeleteGroup() (tea.Model, tea.Cmd) {
 
 	count := 0
 	for _, r := range group.Results {
+		if a.store.IsFolderLocked(r.Bookmark.FolderID) {
+			continue // locked folders keep their bookmarks, dead or not
+		}
 		a.store.RemoveBookmarkByID(r.Bookmark.ID)
 		count++
 	}
@@ -4036,6 +5977,46 @@ func (a *App) cullDeleteGroup() (tea.Model, tea.Cmd) {
 	a.refreshItems()
 	a.refreshPinnedItems()
 
+	return a.removeCullGroup("Deleted " + strconv.Itoa(count) + " bookmarks")
+}
+
+// cullQuarantineGroup moves all bookmarks in the current cull group to the
+// broken links folder, so they are out of the way but kept for review.
+func (a *App) cullQuarantineGroup() (tea.Model, tea.Cmd) {
+	group := a.cull.CurrentGroup()
+	if group == nil {
+		return a, nil
+	}
+	folder, _ := a.store.GetOrCreateFolderByPath(a.config.BrokenFolder)
+	if folder == nil {
+		return a, nil
+	}
+	folderID := folder.ID
+
+	count := 0
+	for _, r := range group.Results {
+		if a.store.IsFolderLocked(r.Bookmark.FolderID) {
+			continue // locked folders keep their bookmarks, dead or not
+		}
+		bookmark := a.store.GetBookmarkByID(r.Bookmark.ID)
+		if bookmark == nil {
+			continue
+		}
+		id := folderID
+		bookmark.FolderID = &id
+		count++
+	}
+
+	a.saveStore()
+	a.refreshItems()
+	a.refreshPinnedItems()
+
+	return a.removeCullGroup("Moved " + strconv.Itoa(count) + " bookmarks to " + a.config.BrokenFolder)
+}
+
+// removeCullGroup drops the current group after it was handled and reports
+// done, leaving cull when no groups remain.
+func (a *App) removeCullGroup(done string) (tea.Model, tea.Cmd) {
 	// Remove the group from the list
 	if len(a.cull.Groups) > 0 {
 		idx := a.cull.GroupCursor
@@ -4051,11 +6032,12 @@ func (a *App) cullDeleteGroup() (tea.Model, tea.Cmd) {
 	if len(a.cull.Groups) == 0 {
 		a.cull.Reset()
 		a.mode = ModeNormal
-		cmd := a.setMessage(MessageSuccess, "Deleted "+strconv.Itoa(count)+" bookmarks. Cull complete!")
+		cmd := a.setMessage(MessageSuccess, done+". Cull complete!")
 		return a, cmd
 	}
 
-	cmd := a.setMessage(MessageSuccess, "Deleted "+strconv.Itoa(count)+" bookmarks")
+	a.mode = ModeCullResults
+	cmd := a.setMessage(MessageSuccess, done)
 	return a, cmd
 }
 
@@ -4067,6 +6049,10 @@ func (a *App) cullDeleteItem() (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
+	if a.store.IsFolderLocked(result.Bookmark.FolderID) {
+		return a, a.setMessage(MessageError, "Folder is locked")
+	}
+
 	title := result.Bookmark.Title
 	a.store.RemoveBookmarkByID(result.Bookmark.ID)
 	a.saveStore()
@@ -4171,7 +6157,7 @@ func (a *App) cullMoveItem() (tea.Model, tea.Cmd) {
 // startOrganize initiates the AI-powered organize analysis.
 func (a *App) startOrganize() (tea.Model, tea.Cmd) {
 	// Check for API key first
-	client, err := ai.NewClient()
+	client, err := a.newAIClient()
 	if err != nil {
 		cmd := a.setMessage(MessageError, "No API key: set ANTHROPIC_API_KEY")
 		return a, cmd
@@ -4179,7 +6165,7 @@ func (a *App) startOrganize() (tea.Model, tea.Cmd) {
 	_ = client // Will be used in the command
 
 	// Check for cache
-	a.checkOrganizeCache()
+	warn := a.checkOrganizeCache()
 	if a.organize.HasCache {
 		a.organize.MenuCursor = 0
 		a.mode = ModeOrganizeMenu
@@ -4187,7 +6173,8 @@ func (a *App) startOrganize() (tea.Model, tea.Cmd) {
 	}
 
 	// No cache - start fresh analysis
-	return a.startOrganizeAnalysis()
+	model, cmd := a.startOrganizeAnalysis()
+	return model, tea.Batch(warn, cmd)
 }
 
 // startOrganizeAnalysis begins the AI-powered organize analysis.
@@ -4199,19 +6186,27 @@ func (a *App) startOrganizeAnalysis() (tea.Model, tea.Cmd) {
 		return a, cmd
 	}
 	item := displayItems[a.browser.Cursor]
+	if a.isItemLocked(item) {
+		return a, a.setMessage(MessageError, "Folder is locked")
+	}
 
 	// Reset organize state
 	a.organize.Reset()
 
-	// Collect items to analyze
+	// Collect items to analyze; folders get context scoped to their subtree
 	var itemsToAnalyze []Item
+	var storeContext string
 	if item.IsFolder() {
-		// Recursively collect all items in folder
-		itemsToAnalyze = a.collectFolderItemsRecursive(item.Folder.ID)
+		itemsToAnalyze = a.collectOrganizeItems(item.Folder.ID, a.config.OrganizeRecursive)
 		a.organize.SourceFolderID = &item.Folder.ID
+		storeContext = ai.BuildFolderContext(a.store, item.Folder.ID)
 	} else {
+		if item.Bookmark.Archived {
+			return a, a.setMessage(MessageInfo, "Archived bookmarks are not organized")
+		}
 		itemsToAnalyze = []Item{item}
 		a.organize.SourceItem = &item
+		storeContext = ai.BuildContext(a.store)
 	}
 
 	if len(itemsToAnalyze) == 0 {
@@ -4227,26 +6222,34 @@ func (a *App) startOrganizeAnalysis() (tea.Model, tea.Cmd) {
 
 	// Start analysis
 	return a, tea.Batch(
-		a.analyzeOrganizeItems(itemsToAnalyze),
+		a.analyzeOrganizeItems(itemsToAnalyze, storeContext),
 		organizeTickCmd(),
 	)
 }
 
-// collectFolderItemsRecursive collects all bookmarks and folders recursively.
-func (a *App) collectFolderItemsRecursive(folderID string) []Item {
+// collectOrganizeItems collects the bookmarks and folders in a folder for
+// organize, descending into subfolders when recursive is set.
+func (a *App) collectOrganizeItems(folderID string, recursive bool) []Item {
 	var items []Item
 
 	// Get direct children
-	bookmarks := a.store.GetBookmarksInFolder(&folderID)
+	bookmarks := a.store.GetAllBookmarksInFolder(&folderID)
 	for i := range bookmarks {
+		if bookmarks[i].Archived {
+			continue // shown inline at most, never reorganized
+		}
 		items = append(items, Item{Kind: ItemBookmark, Bookmark: &bookmarks[i]})
 	}
 
 	folders := a.store.GetFoldersInFolder(&folderID)
 	for i := range folders {
+		if folders[i].Locked {
+			continue // locked subtrees are left out of the analysis
+		}
 		items = append(items, Item{Kind: ItemFolder, Folder: &folders[i]})
-		// Recurse into subfolders
-		items = append(items, a.collectFolderItemsRecursive(folders[i].ID)...)
+		if recursive {
+			items = append(items, a.collectOrganizeItems(folders[i].ID, true)...)
+		}
 	}
 
 	return items
@@ -4269,15 +6272,15 @@ func tagsEqual(a, b []string) bool {
 	return true
 }
 
-// analyzeOrganizeItems starts the AI analysis for all items.
-func (a *App) analyzeOrganizeItems(items []Item) tea.Cmd {
+// analyzeOrganizeItems starts the AI analysis for all items using the given store context.
+func (a *App) analyzeOrganizeItems(items []Item, storeContext string) tea.Cmd {
+	ctx, gen := a.startTask()
 	return func() tea.Msg {
-		client, err := ai.NewClient()
+		client, err := a.newAIClient()
 		if err != nil {
 			return organizeCompleteMsg{}
 		}
 
-		context := ai.BuildContext(a.store)
 		var suggestions []OrganizeSuggestion
 
 		for i, item := range items {
@@ -4299,7 +6302,10 @@ func (a *App) analyzeOrganizeItems(items []Item) tea.Cmd {
 				isFolder = false
 			}
 
-			resp, err := client.SuggestOrganize(title, url, currentPath, tags, isFolder, context)
+			if ctx.Err() != nil {
+				break // cancelled; the result is dropped anyway
+			}
+			resp, err := client.SuggestOrganize(ctx, title, url, currentPath, tags, isFolder, storeContext)
 			if err != nil {
 				continue // Skip items that fail
 			}
@@ -4309,8 +6315,14 @@ func (a *App) analyzeOrganizeItems(items []Item) tea.Cmd {
 				continue
 			}
 
+			// Keep the item where it is if the suggested folder is invalid
+			suggestedPath, ok := model.NormalizeFolderPath(resp.FolderPath)
+			if !ok {
+				suggestedPath = currentPath
+			}
+
 			// Check if there are any changes (folder OR tags)
-			folderDiffers := resp.FolderPath != currentPath
+			folderDiffers := suggestedPath != currentPath
 			tagsDiffer := !tagsEqual(tags, resp.SuggestedTags)
 
 			// Skip if neither folder nor tags changed
@@ -4321,7 +6333,7 @@ func (a *App) analyzeOrganizeItems(items []Item) tea.Cmd {
 			suggestions = append(suggestions, OrganizeSuggestion{
 				Item:          item,
 				CurrentPath:   currentPath,
-				SuggestedPath: resp.FolderPath,
+				SuggestedPath: suggestedPath,
 				IsNewFolder:   resp.IsNewFolder,
 				CurrentTags:   tags,
 				SuggestedTags: resp.SuggestedTags,
@@ -4330,7 +6342,7 @@ func (a *App) analyzeOrganizeItems(items []Item) tea.Cmd {
 		}
 
 		// Store suggestions and complete
-		return organizeResultsMsg{suggestions: suggestions}
+		return organizeResultsMsg{gen: gen, suggestions: suggestions}
 	}
 }
 
@@ -4391,7 +6403,8 @@ func (a *App) organizeAcceptCurrent() (tea.Model, tea.Cmd) {
 	if !sug.Item.IsFolder() && sug.HasTagChanges() {
 		bookmark := a.store.GetBookmarkByID(sug.Item.Bookmark.ID)
 		if bookmark != nil {
-			bookmark.Tags = sug.SuggestedTags
+			bookmark.Tags = a.normalizeTags(sug.SuggestedTags)
+			bookmark.MarkModified(time.Now())
 			tagged = true
 		}
 	}
@@ -4432,6 +6445,28 @@ func (a *App) organizeAcceptCurrent() (tea.Model, tea.Cmd) {
 	return a, cmd
 }
 
+// newFoldersForPath returns the folder paths along path that don't exist yet
+// and would be created by moving an item there.
+func (a *App) newFoldersForPath(path string) []string {
+	existing := make(map[string]bool)
+	for _, p := range a.buildFolderPaths() {
+		existing[p] = true
+	}
+
+	var created []string
+	prefix := ""
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		if name == "" {
+			continue
+		}
+		prefix += "/" + name
+		if !existing[prefix] {
+			created = append(created, prefix)
+		}
+	}
+	return created
+}
+
 // organizeSkipCurrent marks the current suggestion as processed without moving.
 func (a *App) organizeSkipCurrent() {
 	sug := a.organize.CurrentSuggestion()
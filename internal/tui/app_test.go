@@ -1,12 +1,25 @@
 package tui_test
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nikbrunner/bm/internal/ai"
+	"github.com/nikbrunner/bm/internal/culler"
+	"github.com/nikbrunner/bm/internal/fetcher"
+	"github.com/nikbrunner/bm/internal/importer"
 	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/storage"
 	"github.com/nikbrunner/bm/internal/tui"
+	"github.com/nikbrunner/bm/internal/tui/layout"
 )
 
 func TestApp_Navigation_JK(t *testing.T) {
@@ -79,6 +92,111 @@ func TestApp_Navigation_JK_AtBounds(t *testing.T) {
 	}
 }
 
+func TestApp_Navigation_WrapAround(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Folder 1", ParentID: nil},
+			{ID: "f2", Name: "Folder 2", ParentID: nil},
+			{ID: "f3", Name: "Folder 3", ParentID: nil},
+		},
+		Bookmarks: []model.Bookmark{},
+	}
+
+	cfg := storage.DefaultConfig()
+	cfg.WrapNavigation = true
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	// k at top wraps to bottom
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	app = updated.(tui.App)
+
+	if app.Cursor() != 2 {
+		t.Errorf("k at top should wrap to 2, got %d", app.Cursor())
+	}
+
+	// j at bottom wraps to top
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	app = updated.(tui.App)
+
+	if app.Cursor() != 0 {
+		t.Errorf("j at bottom should wrap to 0, got %d", app.Cursor())
+	}
+}
+
+func TestApp_JumpToInitial(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Archive", ParentID: nil},
+			{ID: "f2", Name: "Work", ParentID: nil},
+			{ID: "f3", Name: "articles", ParentID: nil},
+		},
+		Bookmarks: []model.Bookmark{},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	jump := func(r rune) {
+		updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'\''}})
+		app = updated.(tui.App)
+		updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+
+	jump('w')
+	if app.Cursor() != 1 {
+		t.Errorf("'w should jump to Work (1), got %d", app.Cursor())
+	}
+
+	// Case-insensitive, continues after the cursor
+	jump('a')
+	if app.Cursor() != 2 {
+		t.Errorf("'a should jump to articles (2), got %d", app.Cursor())
+	}
+
+	// Repeated jump cycles back to the first match
+	jump('a')
+	if app.Cursor() != 0 {
+		t.Errorf("second 'a should cycle to Archive (0), got %d", app.Cursor())
+	}
+
+	// No match leaves the cursor and mode untouched
+	jump('z')
+	if app.Cursor() != 0 {
+		t.Errorf("'z with no match should not move cursor, got %d", app.Cursor())
+	}
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("jump should not leave normal mode, got %v", app.Mode())
+	}
+}
+
+func TestApp_Navigation_WrapAround_PinnedPane(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Folder 1", ParentID: nil, Pinned: true, PinOrder: 1},
+			{ID: "f2", Name: "Folder 2", ParentID: nil, Pinned: true, PinOrder: 2},
+		},
+		Bookmarks: []model.Bookmark{},
+	}
+
+	cfg := storage.DefaultConfig()
+	cfg.WrapNavigation = true
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	// Starts focused on pinned pane; k at top wraps to bottom
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	app = updated.(tui.App)
+
+	if app.PinnedCursor() != 1 {
+		t.Errorf("k at top of pins should wrap to 1, got %d", app.PinnedCursor())
+	}
+
+	// j at bottom wraps to top
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	app = updated.(tui.App)
+
+	if app.PinnedCursor() != 0 {
+		t.Errorf("j at bottom of pins should wrap to 0, got %d", app.PinnedCursor())
+	}
+}
 func TestApp_Navigation_HL(t *testing.T) {
 	f1ID := "f1"
 	store := &model.Store{
@@ -527,6 +645,143 @@ func TestApp_Paste_ShiftP_Before(t *testing.T) {
 	}
 }
 
+func TestApp_Paste_AfterCut_PreservesOriginal(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	visited := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Folder 1", ParentID: nil},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Bookmark 1", URL: "https://1.com", FolderID: nil,
+				CreatedAt: created, VisitedAt: &visited, VisitCount: 3},
+		},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	// Cut b1 (cursor on f1, move down first) and confirm
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'j'}},
+		{Type: tea.KeyRunes, Runes: []rune{'x'}},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRunes, Runes: []rune{'k'}},
+		{Type: tea.KeyRunes, Runes: []rune{'l'}}, // enter f1
+		{Type: tea.KeyRunes, Runes: []rune{'p'}},
+	} {
+		updated, _ := app.Update(msg)
+		app = updated.(tui.App)
+	}
+
+	moved := store.GetBookmarkByID("b1")
+	if moved == nil {
+		t.Fatal("expected cut bookmark to keep its ID after paste")
+	}
+	if moved.FolderID == nil || *moved.FolderID != "f1" {
+		t.Errorf("expected b1 to be moved into f1, got %v", moved.FolderID)
+	}
+	if !moved.CreatedAt.Equal(created) {
+		t.Errorf("expected CreatedAt %v to be preserved, got %v", created, moved.CreatedAt)
+	}
+	if moved.VisitCount != 3 || moved.VisitedAt == nil || !moved.VisitedAt.Equal(visited) {
+		t.Errorf("expected visit history to be preserved, got count=%d visitedAt=%v", moved.VisitCount, moved.VisitedAt)
+	}
+
+	// Pasting the same cut again creates a fresh copy
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	app = updated.(tui.App)
+	if len(store.Bookmarks) != 2 {
+		t.Fatalf("expected second paste to add a copy, got %d bookmarks", len(store.Bookmarks))
+	}
+	for _, b := range store.Bookmarks {
+		if b.ID != "b1" && b.CreatedAt.Equal(created) {
+			t.Error("expected second paste to be a fresh copy")
+		}
+	}
+}
+
+func TestApp_Paste_Folder_DeepCopiesContents(t *testing.T) {
+	f1ID := "f1"
+	f1aID := "f1a"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Folder 1", ParentID: nil},
+			{ID: "f1a", Name: "Nested", ParentID: &f1ID},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "One", URL: "https://1.com", FolderID: &f1ID},
+			{ID: "b2", Title: "Two", URL: "https://2.com", FolderID: &f1aID},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.LargeOperationThreshold = 2
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	press := func(r rune) {
+		updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+
+	// Yank f1 and paste: 4 items exceed the threshold of 2, so it asks first
+	press('y')
+	press('p')
+	if app.Mode() != tui.ModeConfirmLargeOp {
+		t.Fatalf("expected ModeConfirmLargeOp, got %v", app.Mode())
+	}
+	if len(store.Folders) != 2 {
+		t.Fatalf("expected nothing pasted before confirmation, got %d folders", len(store.Folders))
+	}
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeNormal {
+		t.Fatalf("expected ModeNormal after confirming, got %v", app.Mode())
+	}
+
+	items := app.Items()
+	if len(items) != 2 || items[1].Folder.ID == "f1" {
+		t.Fatalf("expected a pasted copy of f1, got %+v", items)
+	}
+	if len(store.Folders) != 4 || len(store.Bookmarks) != 4 {
+		t.Errorf("expected contents to be copied, got %d folders and %d bookmarks", len(store.Folders), len(store.Bookmarks))
+	}
+	if count := store.CountItemsInFolder(items[1].Folder.ID); count != 3 {
+		t.Errorf("expected copied folder to contain 3 items, got %d", count)
+	}
+}
+
+func TestApp_Paste_LargeOperation_Cancel(t *testing.T) {
+	f1ID := "f1"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Folder 1", ParentID: nil},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "One", URL: "https://1.com", FolderID: &f1ID},
+			{ID: "b2", Title: "Two", URL: "https://2.com", FolderID: &f1ID},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.LargeOperationThreshold = 2
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'y'}},
+		{Type: tea.KeyRunes, Runes: []rune{'p'}},
+		{Type: tea.KeyEsc},
+	} {
+		updated, _ := app.Update(msg)
+		app = updated.(tui.App)
+	}
+
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected ModeNormal after cancel, got %v", app.Mode())
+	}
+	if len(store.Folders) != 1 || len(store.Bookmarks) != 2 {
+		t.Errorf("expected cancel to leave the store unchanged, got %d folders and %d bookmarks", len(store.Folders), len(store.Bookmarks))
+	}
+}
+
 func TestApp_Paste_NoYankedItem(t *testing.T) {
 	store := &model.Store{
 		Folders: []model.Folder{
@@ -883,6 +1138,73 @@ func TestApp_EditBookmark_Submit(t *testing.T) {
 	}
 }
 
+func TestApp_EditBookmark_NormalizesTags(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "React", URL: "https://react.dev", Tags: []string{}},
+		},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+	press := func(msg tea.KeyMsg) {
+		updated, _ := app.Update(msg)
+		app = updated.(tui.App)
+	}
+
+	// Open edit modal and tab to the tags field
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	press(tea.KeyMsg{Type: tea.KeyTab})
+	press(tea.KeyMsg{Type: tea.KeyTab})
+	for _, r := range "React,  react , JS" {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+
+	tags := store.Bookmarks[0].Tags
+	if len(tags) != 2 || tags[0] != "react" || tags[1] != "js" {
+		t.Errorf("expected tags [react js], got %q", tags)
+	}
+}
+
+func TestApp_EditBookmark_UpdatesModifiedAt(t *testing.T) {
+	created := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev", Tags: []string{}, CreatedAt: created, ModifiedAt: created},
+		},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+	press := func(msg tea.KeyMsg) {
+		updated, _ := app.Update(msg)
+		app = updated.(tui.App)
+	}
+
+	// Submitting without changes leaves ModifiedAt alone
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if !store.Bookmarks[0].ModifiedAt.Equal(created) {
+		t.Errorf("expected unchanged ModifiedAt, got %v", store.Bookmarks[0].ModifiedAt)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	for _, r := range " Language" {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+
+	b := store.Bookmarks[0]
+	if b.Title != "Go Language" {
+		t.Fatalf("expected edited title, got %q", b.Title)
+	}
+	if !b.ModifiedAt.After(created) {
+		t.Errorf("expected ModifiedAt after %v, got %v", created, b.ModifiedAt)
+	}
+	if !b.CreatedAt.Equal(created) {
+		t.Errorf("expected CreatedAt unchanged, got %v", b.CreatedAt)
+	}
+}
+
 func TestApp_Edit_EmptyList(t *testing.T) {
 	store := &model.Store{
 		Folders:   []model.Folder{},
@@ -962,6 +1284,36 @@ func TestApp_DeleteFolder_ConfirmWithEnter(t *testing.T) {
 	}
 }
 
+func TestApp_DeleteFolder_KeepContents(t *testing.T) {
+	f1ID := "f1"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "My Folder", ParentID: nil},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Inside", URL: "https://example.com", FolderID: &f1ID},
+		},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store}).WithDimensions(120, 30)
+	app = pressKey(app, 'd')
+	if view := layout.StripANSI(app.View()); !strings.Contains(view, "keep contents") {
+		t.Errorf("expected the keep contents option for a non-empty folder:\n%s", view)
+	}
+
+	app = pressKey(app, 'K')
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected normal mode after K, got %d", app.Mode())
+	}
+	if store.GetFolderByID("f1") != nil {
+		t.Error("folder should be deleted")
+	}
+	items := app.Items()
+	if len(items) != 1 || items[0].Bookmark == nil || items[0].Bookmark.ID != "b1" {
+		t.Fatalf("expected the bookmark moved to the root, got %+v", items)
+	}
+}
+
 func TestApp_CutFolder_ConfirmWithEnter(t *testing.T) {
 	store := &model.Store{
 		Folders: []model.Folder{
@@ -1053,6 +1405,52 @@ func TestApp_DeleteBookmark_WithConfirmOff(t *testing.T) {
 	}
 }
 
+func TestApp_ConfirmBookmarkDelete_ConfirmsWithConfirmOff(t *testing.T) {
+	for _, on := range []bool{true, false} {
+		store := &model.Store{
+			Folders: []model.Folder{{ID: "f1", Name: "Empty"}},
+			Bookmarks: []model.Bookmark{
+				{ID: "b1", Title: "My Bookmark", URL: "https://test.com"},
+			},
+		}
+		cfg := storage.DefaultConfig()
+		cfg.ConfirmBookmarkDelete = on
+		app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+		app.SetConfirmDelete(false)
+
+		// Folders come first: j moves onto the bookmark
+		app = pressKey(app, 'j')
+		app = pressKey(app, 'd')
+		if on {
+			if app.Mode() != tui.ModeConfirmDelete {
+				t.Errorf("confirmBookmarkDelete on: expected the confirm modal, got mode %v", app.Mode())
+			}
+			if store.GetBookmarkByID("b1") == nil {
+				t.Error("confirmBookmarkDelete on: bookmark deleted before confirming")
+			}
+			continue
+		}
+		if app.Mode() != tui.ModeNormal || store.GetBookmarkByID("b1") != nil {
+			t.Errorf("confirmBookmarkDelete off: expected an immediate delete, got mode %v", app.Mode())
+		}
+	}
+}
+
+func TestApp_ConfirmBookmarkDelete_LeavesFoldersAlone(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{{ID: "f1", Name: "Empty"}},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.ConfirmBookmarkDelete = true
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+	app.SetConfirmDelete(false)
+
+	app = pressKey(app, 'd')
+	if store.GetFolderByID("f1") != nil {
+		t.Errorf("expected the folder to be deleted without asking, got mode %v", app.Mode())
+	}
+}
+
 // === Phase 4 Tests: Sort Mode ===
 
 func TestApp_SortMode_DefaultIsManual(t *testing.T) {
@@ -1110,10 +1508,22 @@ func TestApp_SortMode_Cycle(t *testing.T) {
 		t.Errorf("expected SortVisited after third 'to', got %d", app.SortMode())
 	}
 
+	// Press 'to' to cycle to date modified
+	app = cycleOrder(app)
+	if app.SortMode() != tui.SortModified {
+		t.Errorf("expected SortModified after fourth 'to', got %d", app.SortMode())
+	}
+
+	// Press 'to' to cycle to priority
+	app = cycleOrder(app)
+	if app.SortMode() != tui.SortPriority {
+		t.Errorf("expected SortPriority after fifth 'to', got %d", app.SortMode())
+	}
+
 	// Press 'to' to cycle back to manual
 	app = cycleOrder(app)
 	if app.SortMode() != tui.SortManual {
-		t.Errorf("expected SortManual after fourth 'to', got %d", app.SortMode())
+		t.Errorf("expected SortManual after sixth 'to', got %d", app.SortMode())
 	}
 }
 
@@ -1527,6 +1937,38 @@ func TestApp_OpenBookmark_UpdatesVisitedAt(t *testing.T) {
 	}
 }
 
+func TestApp_OpenBookmark_PersistsVisitedAt(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []tea.KeyMsg
+		id   string
+	}{
+		// The pinned pane has focus at start
+		{"pinned", []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'1'}}}, "b2"},
+		{"browser", []tea.KeyMsg{{Type: tea.KeyTab}, {Type: tea.KeyRunes, Runes: []rune{'l'}}}, "b1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &model.Store{
+				Bookmarks: []model.Bookmark{
+					{ID: "b1", Title: "Browsed", URL: "https://browsed.com"},
+					{ID: "b2", Title: "Pinned", URL: "https://pinned.com", Pinned: true, PinOrder: 1},
+				},
+			}
+			st := &snapshotStorage{}
+			var app tea.Model = tui.NewApp(tui.AppParams{Store: store, Storage: st})
+			for _, k := range tt.keys {
+				app, _ = app.Update(k)
+			}
+
+			i := slices.IndexFunc(st.bookmarks, func(b model.Bookmark) bool { return b.ID == tt.id })
+			if i < 0 || st.bookmarks[i].VisitedAt == nil || st.bookmarks[i].VisitCount != 1 {
+				t.Fatalf("expected the visit to %s to be saved, got %+v", tt.id, st.bookmarks)
+			}
+		})
+	}
+}
+
 func TestApp_OpenBookmark_OnFolder_EntersFolder(t *testing.T) {
 	store := &model.Store{
 		Folders: []model.Folder{
@@ -1590,69 +2032,211 @@ func TestApp_YankURL_CopiesURLToClipboard(t *testing.T) {
 	}
 }
 
-func TestApp_YankURL_OnFolder_DoesNothing(t *testing.T) {
-	store := &model.Store{
-		Folders: []model.Folder{
-			{ID: "f1", Name: "Test Folder", ParentID: nil},
-		},
-		Bookmarks: []model.Bookmark{},
-	}
-
-	app := tui.NewApp(tui.AppParams{Store: store})
-
-	// Press 'Y' on a folder - should do nothing
-	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+// failingClipboard simulates a headless/SSH session with no clipboard.
+type failingClipboard struct{ reads int }
 
-	// Should not return a command
-	if cmd != nil {
-		t.Error("should not return command when yanking URL from folder")
-	}
+func (c *failingClipboard) ReadAll() (string, error) {
+	c.reads++
+	return "", errors.New("no display")
 }
+func (c *failingClipboard) WriteAll(string) error { return errors.New("no display") }
 
-func TestApp_YankURL_EmptyList(t *testing.T) {
-	store := &model.Store{
-		Folders:   []model.Folder{},
-		Bookmarks: []model.Bookmark{},
-	}
+// staticClipboard always holds the same text.
+type staticClipboard struct{ text string }
 
-	app := tui.NewApp(tui.AppParams{Store: store})
+func (c staticClipboard) ReadAll() (string, error) { return c.text, nil }
+func (c staticClipboard) WriteAll(string) error    { return nil }
 
-	// Press 'Y' on empty list - should do nothing (no crash)
-	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+// recordingClipboard remembers the last text written.
+type recordingClipboard struct{ written string }
 
-	// Should not return a command
-	if cmd != nil {
-		t.Error("should not return command on empty list")
-	}
+func (c *recordingClipboard) ReadAll() (string, error) { return c.written, nil }
+func (c *recordingClipboard) WriteAll(text string) error {
+	c.written = text
+	return nil
 }
 
-func TestApp_HelpOverlay_Toggle(t *testing.T) {
+func TestApp_YankDomain_CopiesHostOnly(t *testing.T) {
 	store := &model.Store{
-		Folders:   []model.Folder{},
-		Bookmarks: []model.Bookmark{},
+		Folders: []model.Folder{{ID: "f1", Name: "Folder"}},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Docs", URL: "https://www.example.com/docs?page=2"},
+		},
 	}
+	clip := &recordingClipboard{}
+	app := tui.NewApp(tui.AppParams{Store: store, Clipboard: clip})
 
-	app := tui.NewApp(tui.AppParams{Store: store})
-
-	// Initially not in help mode
-	if app.Mode() != tui.ModeNormal {
-		t.Error("expected to start in normal mode")
+	// Folder first: gd is a no-op and must not delete it
+	app = pressKey(app, 'g')
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	app = updated.(tui.App)
+	if cmd != nil || len(store.Folders) != 1 {
+		t.Fatalf("expected gd on a folder to do nothing")
 	}
 
-	// Press '?' to open help overlay
-	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	app = pressKey(app, 'j')
+	app = pressKey(app, 'g')
+	updated, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
 	app = updated.(tui.App)
+	for _, msg := range runCmds(cmd) {
+		updated, _ = app.Update(msg)
+		app = updated.(tui.App)
+	}
 
-	// Should be in help mode
-	if app.Mode() != tui.ModeHelp {
-		t.Errorf("expected ModeHelp after '?', got %d", app.Mode())
+	if clip.written != "example.com" {
+		t.Errorf("expected example.com on the clipboard, got %q", clip.written)
 	}
+	if !strings.Contains(app.StatusMessage(), "Domain copied") {
+		t.Errorf("expected domain copied message, got %q", app.StatusMessage())
+	}
+	if len(store.Bookmarks) != 1 {
+		t.Error("expected gd not to delete the bookmark")
+	}
+}
 
-	// Press '?' again to close
-	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+func TestApp_InboxCapture_AddsWithoutAI(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	store := model.NewStore()
+	clip := staticClipboard{text: " https://example.com/article \n"}
+	app := tui.NewApp(tui.AppParams{Store: store, Clipboard: clip})
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	app = updated.(tui.App)
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
 	app = updated.(tui.App)
 
-	// Should be back in normal mode
+	// Saved synchronously: no loading mode waiting on an AI response
+	if app.Mode() != tui.ModeNormal {
+		t.Fatalf("expected ModeNormal, got %v", app.Mode())
+	}
+	if len(store.Bookmarks) != 1 {
+		t.Fatalf("expected 1 bookmark, got %d", len(store.Bookmarks))
+	}
+	b := store.Bookmarks[0]
+	if b.URL != "https://example.com/article" || b.Title != b.URL || len(b.Tags) != 0 {
+		t.Errorf("expected URL as title and no tags, got %+v", b)
+	}
+	if store.GetFolderPath(b.FolderID) != "/Read Later" {
+		t.Errorf("expected bookmark in /Read Later, got %s", store.GetFolderPath(b.FolderID))
+	}
+
+	// Non-URLs are rejected
+	app = tui.NewApp(tui.AppParams{Store: store, Clipboard: staticClipboard{text: "not a url"}})
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	app = updated.(tui.App)
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	app = updated.(tui.App)
+	if len(store.Bookmarks) != 1 || !containsStr(app.StatusMessage(), "Invalid URL") {
+		t.Errorf("expected invalid URL to be rejected, msg %q", app.StatusMessage())
+	}
+}
+
+func TestApp_ClipboardUnavailable_FallsBackToManualEntry(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Test", URL: "https://example.com", FolderID: nil},
+		},
+	}
+	clip := &failingClipboard{}
+	app := tui.NewApp(tui.AppParams{Store: store, Clipboard: clip})
+
+	// Read later should prompt for the URL instead of erroring
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeQuickAdd {
+		t.Fatalf("expected URL prompt (ModeQuickAdd), got mode %v", app.Mode())
+	}
+	if !containsStr(app.StatusMessage(), "Clipboard unavailable") {
+		t.Errorf("expected one-time clipboard message, got %q", app.StatusMessage())
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app = updated.(tui.App)
+
+	// Availability is cached: further actions don't touch the clipboard again
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	app = updated.(tui.App)
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app = updated.(tui.App)
+	if clip.reads != 1 {
+		t.Errorf("expected clipboard to be probed once, got %d reads", clip.reads)
+	}
+
+	// Yank shows the URL instead of failing
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+	app = updated.(tui.App)
+	for _, msg := range runCmds(cmd) {
+		updated, _ = app.Update(msg)
+		app = updated.(tui.App)
+	}
+	if !containsStr(app.StatusMessage(), "https://example.com") {
+		t.Errorf("expected status to show the URL, got %q", app.StatusMessage())
+	}
+}
+
+func TestApp_YankURL_OnFolder_DoesNothing(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Test Folder", ParentID: nil},
+		},
+		Bookmarks: []model.Bookmark{},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	// Press 'Y' on a folder - should do nothing
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+
+	// Should not return a command
+	if cmd != nil {
+		t.Error("should not return command when yanking URL from folder")
+	}
+}
+
+func TestApp_YankURL_EmptyList(t *testing.T) {
+	store := &model.Store{
+		Folders:   []model.Folder{},
+		Bookmarks: []model.Bookmark{},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	// Press 'Y' on empty list - should do nothing (no crash)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+
+	// Should not return a command
+	if cmd != nil {
+		t.Error("should not return command on empty list")
+	}
+}
+
+func TestApp_HelpOverlay_Toggle(t *testing.T) {
+	store := &model.Store{
+		Folders:   []model.Folder{},
+		Bookmarks: []model.Bookmark{},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	// Initially not in help mode
+	if app.Mode() != tui.ModeNormal {
+		t.Error("expected to start in normal mode")
+	}
+
+	// Press '?' to open help overlay
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	app = updated.(tui.App)
+
+	// Should be in help mode
+	if app.Mode() != tui.ModeHelp {
+		t.Errorf("expected ModeHelp after '?', got %d", app.Mode())
+	}
+
+	// Press '?' again to close
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	app = updated.(tui.App)
+
+	// Should be back in normal mode
 	if app.Mode() != tui.ModeNormal {
 		t.Error("expected normal mode after pressing '?' again")
 	}
@@ -1873,3 +2457,2624 @@ func containsStrHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestApp_SnoozeHidesBookmark(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Later", URL: "https://later.dev"},
+			{ID: "b2", Title: "Now", URL: "https://now.dev"},
+		},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	// z opens the snooze prompt
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeSnooze {
+		t.Fatalf("expected ModeSnooze, got %v", app.Mode())
+	}
+
+	for _, r := range "3d" {
+		updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected ModeNormal after snooze, got %v", app.Mode())
+	}
+	if len(app.Items()) != 1 || app.Items()[0].Bookmark.ID != "b2" {
+		t.Errorf("expected only b2 visible after snooze, got %d items", len(app.Items()))
+	}
+	if store.Bookmarks[0].SnoozeUntil == nil {
+		t.Error("expected b1 to have SnoozeUntil set")
+	}
+}
+
+func TestApp_PromoteBookmark_CreatesFolderAndMovesRelated(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "A React/Vue guide", URL: "https://guide.dev", Tags: []string{"frontend"}},
+			{ID: "b2", Title: "B Components", URL: "https://components.dev", Tags: []string{"Frontend"}},
+			{ID: "b3", Title: "C Databases", URL: "https://db.dev", Tags: []string{"backend"}},
+		},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	// F opens the promote prompt prefilled with the sanitized title
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModePromote {
+		t.Fatalf("expected ModePromote, got %v", app.Mode())
+	}
+
+	// Tab includes bookmarks sharing a tag
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	app = updated.(tui.App)
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected ModeNormal after promote, got %v", app.Mode())
+	}
+	folder := store.GetFolderByPath("/A React-Vue guide")
+	if folder == nil {
+		t.Fatal("expected folder /A React-Vue guide to be created")
+	}
+	for _, id := range []string{"b1", "b2"} {
+		b := store.GetBookmarkByID(id)
+		if b.FolderID == nil || *b.FolderID != folder.ID {
+			t.Errorf("expected %s to be moved into the new folder", id)
+		}
+	}
+	if store.GetBookmarkByID("b3").FolderID != nil {
+		t.Error("expected unrelated bookmark to stay at root")
+	}
+	if !containsStr(app.StatusMessage(), "Promoted 2 bookmarks") {
+		t.Errorf("expected promote report, got %q", app.StatusMessage())
+	}
+}
+
+func TestApp_ShowArchivedInline_Toggle(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Current", URL: "https://current.dev"},
+			{ID: "b2", Title: "Old", URL: "https://old.dev", Archived: true},
+		},
+	}
+
+	// Off by default: archived bookmarks are hidden
+	app := tui.NewApp(tui.AppParams{Store: store})
+	if len(app.Items()) != 1 || app.Items()[0].Bookmark.ID != "b1" {
+		t.Fatalf("expected only b1 visible, got %d items", len(app.Items()))
+	}
+
+	// ta shows them inline
+	for _, r := range "ta" {
+		updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+	if len(app.Items()) != 2 {
+		t.Fatalf("expected archived bookmark shown inline, got %d items", len(app.Items()))
+	}
+
+	// Config turns it on from the start; ta hides them again
+	cfg := storage.DefaultConfig()
+	cfg.ShowArchivedInline = true
+	app = tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+	if len(app.Items()) != 2 {
+		t.Fatalf("expected 2 items with showArchivedInline, got %d", len(app.Items()))
+	}
+	for _, r := range "ta" {
+		updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+	if len(app.Items()) != 1 {
+		t.Errorf("expected archived bookmark hidden after toggle, got %d items", len(app.Items()))
+	}
+
+	// Cull candidates never include archived bookmarks
+	if active := store.GetActiveBookmarks(); len(active) != 1 || active[0].ID != "b1" {
+		t.Errorf("expected only b1 eligible for cull, got %+v", active)
+	}
+}
+
+func TestApp_ArchiveBookmark_HidesIt(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "A", URL: "https://a.dev"},
+			{ID: "b2", Title: "B", URL: "https://b.dev"},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	app = updated.(tui.App)
+
+	if !store.GetBookmarkByID("b1").Archived {
+		t.Fatal("expected b1 to be archived")
+	}
+	if len(app.Items()) != 1 || app.Items()[0].Bookmark.ID != "b2" {
+		t.Errorf("expected only b2 visible after archiving, got %d items", len(app.Items()))
+	}
+}
+
+func TestApp_LockedFolder_BlocksDeleteAndMove(t *testing.T) {
+	refID := "ref"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "ref", Name: "Reference", Locked: true},
+			{ID: "inbox", Name: "Inbox"},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Spec", URL: "https://spec.dev", FolderID: &refID},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+	app.SetConfirmDelete(false)
+
+	// Deleting the locked folder itself is refused
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	app = updated.(tui.App)
+	if store.GetFolderByID("ref") == nil {
+		t.Fatal("expected locked folder to survive d")
+	}
+	if !containsStr(app.StatusMessage(), "locked") {
+		t.Errorf("expected locked message, got %q", app.StatusMessage())
+	}
+
+	// Items inside it can be neither deleted nor moved
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	app = updated.(tui.App)
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	app = updated.(tui.App)
+	if store.GetBookmarkByID("b1") == nil {
+		t.Fatal("expected bookmark in locked folder to survive d")
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	app = updated.(tui.App)
+	if app.Mode() == tui.ModeMove {
+		t.Error("expected move to be refused inside a locked folder")
+	}
+
+	// Unlocking (from the parent level) allows the delete again
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	app = updated.(tui.App)
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}})
+	app = updated.(tui.App)
+	if store.GetFolderByID("ref").Locked {
+		t.Fatal("expected ! to unlock the folder")
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	app = updated.(tui.App)
+	if store.GetFolderByID("ref") != nil {
+		t.Error("expected unlocked folder to be deleted")
+	}
+}
+
+func TestApp_FolderViewMode_FlatListsSubtreeOnEnter(t *testing.T) {
+	laterID, goID := "later", "go"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: laterID, Name: "Read Later", ViewMode: model.ViewFlat},
+			{ID: goID, Name: "Go", ParentID: &laterID},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Own", URL: "https://own.dev", FolderID: &laterID},
+			{ID: "b2", Title: "Nested", URL: "https://nested.dev", FolderID: &goID},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	app = pressKey(app, 'l')
+	var ids []string
+	for _, item := range app.Items() {
+		ids = append(ids, item.ID())
+	}
+	if want := []string{"b1", "b2"}; !slices.Equal(ids, want) {
+		t.Errorf("expected the flat view to list %v without folders, got %v", want, ids)
+	}
+}
+
+func TestApp_FolderViewMode_ReadingListsUnvisitedOldestFirst(t *testing.T) {
+	laterID := "later"
+	now := time.Now()
+	visited := now
+	store := &model.Store{
+		Folders: []model.Folder{{ID: laterID, Name: "Read Later", ViewMode: model.ViewReading}},
+		Bookmarks: []model.Bookmark{
+			{ID: "new", Title: "New", URL: "https://new.dev", FolderID: &laterID, CreatedAt: now},
+			{ID: "read", Title: "Read", URL: "https://read.dev", FolderID: &laterID, CreatedAt: now.Add(-2 * time.Hour), VisitedAt: &visited},
+			{ID: "old", Title: "Old", URL: "https://old.dev", FolderID: &laterID, CreatedAt: now.Add(-time.Hour)},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	app = pressKey(app, 'l')
+	var ids []string
+	for _, item := range app.Items() {
+		ids = append(ids, item.ID())
+	}
+	if want := []string{"old", "new", "read"}; !slices.Equal(ids, want) {
+		t.Errorf("expected the reading queue %v, got %v", want, ids)
+	}
+}
+
+func TestApp_FolderViewMode_TvCyclesAndSaves(t *testing.T) {
+	devID := "dev"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: devID, Name: "Dev"},
+			{ID: "sub", Name: "Sub", ParentID: &devID},
+		},
+	}
+	st := &countingStorage{}
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: st})
+
+	for _, r := range "ltv" {
+		app = pressKey(app, r)
+	}
+	if mode := store.GetFolderByID(devID).ViewMode; mode != model.ViewFlat {
+		t.Fatalf("expected tv to switch Dev to flat, got %q", mode)
+	}
+	if len(app.Items()) != 0 {
+		t.Errorf("expected the flat view to hide Sub, got %d items", len(app.Items()))
+	}
+	if st.saves == 0 {
+		t.Error("expected the view mode to be saved")
+	}
+
+	for _, r := range "tvtv" {
+		app = pressKey(app, r)
+	}
+	if mode := store.GetFolderByID(devID).ViewMode; mode != model.ViewColumns {
+		t.Errorf("expected tv to cycle back to columns, got %q", mode)
+	}
+}
+
+func TestApp_MoveInto_MovesSelectionIntoFolderUnderCursor(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "target", Name: "Target"},
+			{ID: "work", Name: "Work"},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev"},
+		},
+	}
+	st := &countingStorage{}
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: st})
+
+	// Select Work and the bookmark, then go back up onto Target
+	for _, r := range "jvjvkkgm" {
+		app = pressKey(app, r)
+	}
+
+	if f := store.GetFolderByID("work"); f.ParentID == nil || *f.ParentID != "target" {
+		t.Errorf("expected Work to move into Target, got parent %v", f.ParentID)
+	}
+	if b := store.GetBookmarkByID("b1"); b.FolderID == nil || *b.FolderID != "target" {
+		t.Errorf("expected the bookmark to move into Target, got folder %v", b.FolderID)
+	}
+	if !strings.Contains(app.StatusMessage(), "/Target") {
+		t.Errorf("expected the destination in the status, got %q", app.StatusMessage())
+	}
+	if st.saves == 0 {
+		t.Error("expected the move to be saved")
+	}
+}
+
+func TestApp_MoveInto_ConfirmsLargeOperation(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "target", Name: "Target"},
+			{ID: "work", Name: "Work"},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev"},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.LargeOperationThreshold = 1
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	// Two items exceed the threshold of 1, so gm asks first like m does
+	for _, r := range "jvjvkkgm" {
+		app = pressKey(app, r)
+	}
+	if app.Mode() != tui.ModeConfirmLargeOp {
+		t.Fatalf("expected ModeConfirmLargeOp, got %v", app.Mode())
+	}
+	if b := store.GetBookmarkByID("b1"); b.FolderID != nil {
+		t.Fatal("expected nothing moved before confirmation")
+	}
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if b := store.GetBookmarkByID("b1"); b.FolderID == nil || *b.FolderID != "target" {
+		t.Errorf("expected the bookmark to move into Target after confirming, got folder %v", b.FolderID)
+	}
+	if f := store.GetFolderByID("work"); f.ParentID == nil || *f.ParentID != "target" {
+		t.Errorf("expected Work to move into Target after confirming, got parent %v", f.ParentID)
+	}
+}
+
+func TestApp_MoveInto_RefusesFolderIntoItself(t *testing.T) {
+	parentID := "parent"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "parent", Name: "Parent"},
+			{ID: "child", Name: "Child", ParentID: &parentID},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	// Select Parent and drop the selection onto Parent itself
+	for _, r := range "vgm" {
+		app = pressKey(app, r)
+	}
+
+	if f := store.GetFolderByID("parent"); f.ParentID != nil {
+		t.Errorf("expected Parent to stay at the root, got parent %v", *f.ParentID)
+	}
+	if !strings.Contains(app.StatusMessage(), "into itself") {
+		t.Errorf("expected a refusal message, got %q", app.StatusMessage())
+	}
+}
+
+func TestApp_LockedFolder_RefusesMoveAndPasteInto(t *testing.T) {
+	refID := "ref"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: refID, Name: "Reference", Locked: true},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Loose", URL: "https://loose.dev"},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	// gm with the bookmark selected and the cursor on the locked folder
+	for _, r := range "jvkgm" {
+		app = pressKey(app, r)
+	}
+	if b := store.GetBookmarkByID("b1"); b.FolderID != nil {
+		t.Fatalf("expected the bookmark to stay at the root, got folder %v", *b.FolderID)
+	}
+	if !containsStr(app.StatusMessage(), "locked") {
+		t.Errorf("expected locked message after gm, got %q", app.StatusMessage())
+	}
+
+	// Yank the bookmark and paste it inside the locked folder
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app = updated.(tui.App)
+	for _, r := range "jyklp" {
+		app = pressKey(app, r)
+	}
+	if got := len(store.Bookmarks); got != 1 {
+		t.Errorf("expected no paste into the locked folder, got %d bookmarks", got)
+	}
+	if !containsStr(app.StatusMessage(), "locked") {
+		t.Errorf("expected locked message after p, got %q", app.StatusMessage())
+	}
+}
+
+func TestApp_MaxFolderDepth_RefusesDeeperFolders(t *testing.T) {
+	aID, cID := "a", "c"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "a", Name: "A"},
+			{ID: "b", Name: "B", ParentID: &aID},
+			{ID: "c", Name: "C"},
+			{ID: "d", Name: "D", ParentID: &cID},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.MaxFolderDepth = 2
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	// Moving C (two levels) under A would reach depth 3
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	app = updated.(tui.App)
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	app = updated.(tui.App)
+	for _, r := range "/A" {
+		updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if store.GetFolderByID("c").ParentID != nil {
+		t.Error("expected move beyond max depth to be refused")
+	}
+	if !containsStr(app.StatusMessage(), "Max folder depth") {
+		t.Errorf("expected max depth message, got %q", app.StatusMessage())
+	}
+
+	// Creating a folder inside A/B (already at the limit) is refused
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	app = updated.(tui.App)
+	for _, r := range "llA" {
+		updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+	if app.Mode() != tui.ModeAddFolder {
+		t.Fatalf("expected ModeAddFolder, got %v", app.Mode())
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	app = updated.(tui.App)
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if len(store.Folders) != 4 {
+		t.Errorf("expected no folder created beyond max depth, got %d folders", len(store.Folders))
+	}
+	if !containsStr(app.StatusMessage(), "Max folder depth") {
+		t.Errorf("expected max depth message, got %q", app.StatusMessage())
+	}
+}
+
+func TestApp_TerminalBrowser_ReportsMissingCommand(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Docs", URL: "https://go.dev"},
+		},
+	}
+	press := func(app tui.App, keys string) tui.App {
+		for _, r := range keys {
+			updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			app = updated.(tui.App)
+		}
+		return app
+	}
+
+	// Unset command explains what to configure
+	app := press(tui.NewApp(tui.AppParams{Store: store}), "gb")
+	if !containsStr(app.StatusMessage(), "terminalBrowserCommand") {
+		t.Errorf("expected hint about terminalBrowserCommand, got %q", app.StatusMessage())
+	}
+
+	// A command that isn't installed is reported without suspending the TUI
+	cfg := storage.DefaultConfig()
+	cfg.TerminalBrowserCommand = "bm-no-such-browser -dump {url}"
+	app = press(tui.NewApp(tui.AppParams{Store: store, Config: &cfg}), "gb")
+	if !containsStr(app.StatusMessage(), "not found: bm-no-such-browser") {
+		t.Errorf("expected not-found message, got %q", app.StatusMessage())
+	}
+	if store.Bookmarks[0].VisitCount != 0 {
+		t.Error("expected no visit to be recorded when the browser can't start")
+	}
+}
+
+func TestApp_Reminders_SetAndReview(t *testing.T) {
+	overdue := time.Now().Add(-time.Hour)
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Follow up", URL: "https://follow.dev", RemindAt: &overdue},
+			{ID: "b2", Title: "Plain", URL: "https://plain.dev"},
+		},
+	}
+	press := func(app tui.App, msgs ...tea.KeyMsg) tui.App {
+		for _, msg := range msgs {
+			updated, _ := app.Update(msg)
+			app = updated.(tui.App)
+		}
+		return app
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// Launch summarizes due reminders
+	app := tui.NewApp(tui.AppParams{Store: store}).WithDimensions(120, 30)
+	if !containsStr(app.StatusMessage(), "1 reminder due") {
+		t.Errorf("expected startup summary, got %q", app.StatusMessage())
+	}
+
+	// r on b2 rejects a bad duration and accepts a valid one
+	app = press(app, runes("j"), runes("r"), runes("soon"), tea.KeyMsg{Type: tea.KeyEnter})
+	if app.Mode() != tui.ModeRemind || !containsStr(app.StatusMessage(), "invalid duration") {
+		t.Fatalf("expected invalid duration to keep the prompt open, mode %v msg %q", app.Mode(), app.StatusMessage())
+	}
+	app = press(app, tea.KeyMsg{Type: tea.KeyEsc}, runes("r"), runes("2h"), tea.KeyMsg{Type: tea.KeyEnter})
+	b2 := store.GetBookmarkByID("b2")
+	if b2.RemindAt == nil || b2.RemindAt.Before(time.Now().Add(time.Hour)) {
+		t.Fatalf("expected b2 reminder about 2h out, got %v", b2.RemindAt)
+	}
+
+	// N lists the due reminder; d clears it and closes the empty list
+	app = press(app, runes("N"))
+	if app.Mode() != tui.ModeReminders {
+		t.Fatalf("expected ModeReminders, got %v", app.Mode())
+	}
+	if !containsStr(app.View(), "Follow up") {
+		t.Error("expected due bookmark in the reminders view")
+	}
+	app = press(app, runes("d"))
+	if store.GetBookmarkByID("b1").RemindAt != nil {
+		t.Error("expected d to clear the reminder")
+	}
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected list to close when nothing is due, got %v", app.Mode())
+	}
+}
+
+func TestApp_SetAlias(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "GitHub", URL: "https://github.com"},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.Aliases = map[string]string{"old": "b1"}
+	configPath := filepath.Join(t.TempDir(), "config.json")
+
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg, ConfigPath: configPath})
+
+	// @ opens the alias prompt prefilled with the existing alias
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'@'}})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeAlias {
+		t.Fatalf("expected ModeAlias, got %v", app.Mode())
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	app = updated.(tui.App)
+	for _, r := range "gh" {
+		updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected ModeNormal after setting alias, got %v", app.Mode())
+	}
+
+	saved, err := storage.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load saved config: %v", err)
+	}
+	if saved.Aliases["gh"] != "b1" {
+		t.Errorf("expected alias gh -> b1, got %v", saved.Aliases)
+	}
+	if _, ok := saved.Aliases["old"]; ok {
+		t.Errorf("expected previous alias to be replaced, got %v", saved.Aliases)
+	}
+}
+
+func TestApp_SetAlias_RejectsSubcommandName(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "GitHub", URL: "https://github.com"},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	for _, r := range "@list" {
+		app = pressKey(app, r)
+	}
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+
+	if app.Mode() != tui.ModeAlias {
+		t.Errorf("expected the alias prompt to stay open, got %v", app.Mode())
+	}
+	if !strings.Contains(app.StatusMessage(), "bm command") {
+		t.Errorf("expected a command clash error, got %q", app.StatusMessage())
+	}
+}
+
+func TestApp_Untitled_ListsOnlyUntitledBookmarks(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev"},
+			{ID: "b2", Title: "https://example.com/a", URL: "https://example.com/a"},
+			{ID: "b3", Title: "", URL: "https://empty.dev"},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store}).WithDimensions(120, 30)
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	app = updated.(tui.App)
+
+	if app.Mode() != tui.ModeSearch {
+		t.Fatalf("expected ModeSearch, got %v", app.Mode())
+	}
+	view := app.View()
+	if !strings.Contains(view, "Untitled Bookmarks") {
+		t.Errorf("expected untitled title in view")
+	}
+	if !strings.Contains(view, "2 results") || strings.Contains(view, "go.dev") {
+		t.Errorf("expected only untitled bookmarks listed, got:\n%s", view)
+	}
+}
+
+func TestApp_PruneEmptyFolders_ConfirmsAndRemoves(t *testing.T) {
+	f1ID, f2ID := "f1", "f2"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Keep"},
+			{ID: "f2", Name: "Empty"},
+			{ID: "f3", Name: "Nested", ParentID: &f2ID},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev", FolderID: &f1ID},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store}).WithDimensions(120, 30)
+
+	app = pressKey(app, 'E')
+	if app.Mode() != tui.ModeConfirmPruneEmpty {
+		t.Fatalf("expected ModeConfirmPruneEmpty, got %v", app.Mode())
+	}
+	if view := app.View(); !strings.Contains(view, "/Empty/Nested") {
+		t.Errorf("expected confirmation to list the nested empty folder")
+	}
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected ModeNormal after confirming, got %v", app.Mode())
+	}
+	if len(store.Folders) != 1 || store.Folders[0].ID != "f1" {
+		t.Errorf("expected only Keep to remain, got %+v", store.Folders)
+	}
+	if !strings.Contains(app.StatusMessage(), "Removed 2") {
+		t.Errorf("expected removal count in status, got %q", app.StatusMessage())
+	}
+}
+
+func TestApp_FolderStatsPreview_Toggle(t *testing.T) {
+	f1ID, f2ID := "f1", "f2"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Dev"},
+			{ID: "f2", Name: "Go", ParentID: &f1ID},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Docs", URL: "https://go.dev", FolderID: &f2ID, Tags: []string{"golang"}},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store}).WithDimensions(120, 30)
+
+	if view := app.View(); strings.Contains(view, "in total") {
+		t.Fatal("expected child list preview by default")
+	}
+
+	app = pressKey(app, 't')
+	app = pressKey(app, 's')
+	view := app.View()
+	if !strings.Contains(view, "1 bookmarks, 1 folders in total") {
+		t.Errorf("expected recursive counts in preview, got:\n%s", view)
+	}
+	if !strings.Contains(view, "#golang (1)") {
+		t.Errorf("expected top tag in preview")
+	}
+}
+
+func TestApp_ItemNumbers_RenderAndGotoIndex(t *testing.T) {
+	store := &model.Store{}
+	for i := 1; i <= 12; i++ {
+		n := strconv.Itoa(i)
+		store.Bookmarks = append(store.Bookmarks, model.Bookmark{ID: "b" + n, Title: "Site " + n, URL: "https://" + n + ".dev"})
+	}
+	app := tui.NewApp(tui.AppParams{Store: store}).WithDimensions(120, 30)
+
+	if view := layout.StripANSI(app.View()); strings.Contains(view, " 1 Site 1 ") {
+		t.Fatal("expected no item numbers by default")
+	}
+
+	app = pressKey(app, 't')
+	app = pressKey(app, 'n')
+	view := layout.StripANSI(app.View())
+	for _, want := range []string{" 1 Site 1 ", " 9 Site 9 ", "12 Site 12"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the current pane, got:\n%s", want, view)
+		}
+	}
+
+	for _, r := range "#11" {
+		app = pressKey(app, r)
+	}
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeNormal {
+		t.Fatalf("expected ModeNormal after jumping, got %v", app.Mode())
+	}
+	if app.Cursor() != 10 {
+		t.Errorf("expected cursor on item 11 (index 10), got %d", app.Cursor())
+	}
+
+	// Out-of-range numbers leave the cursor alone
+	for _, r := range "#40" {
+		app = pressKey(app, r)
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if app.Cursor() != 10 {
+		t.Errorf("expected cursor to stay on index 10, got %d", app.Cursor())
+	}
+}
+
+func TestApp_AdjustPaneWidth_PersistsWeight(t *testing.T) {
+	store := &model.Store{}
+	cfg := storage.DefaultConfig()
+	configPath := filepath.Join(t.TempDir(), "config.json")
+
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg, ConfigPath: configPath})
+
+	for _, r := range ">>><" {
+		updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+
+	if !strings.Contains(app.StatusMessage(), "120%") {
+		t.Errorf("expected status to show 120%%, got %q", app.StatusMessage())
+	}
+	saved, err := storage.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load saved config: %v", err)
+	}
+	if saved.MainPaneWeight != 120 {
+		t.Errorf("expected mainPaneWeight 120, got %d", saved.MainPaneWeight)
+	}
+}
+
+func TestApp_BulkMenu_Delete(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "One", URL: "https://1.com"},
+			{ID: "b2", Title: "Two", URL: "https://2.com"},
+			{ID: "b3", Title: "Three", URL: "https://3.com"},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	press := func(msg tea.KeyMsg) {
+		updated, _ := app.Update(msg)
+		app = updated.(tui.App)
+	}
+	runes := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+
+	// Without a selection, : only shows a hint
+	press(runes(':'))
+	if app.Mode() != tui.ModeNormal {
+		t.Fatalf("expected : without selection to stay in normal mode, got %v", app.Mode())
+	}
+
+	// Select b1 and b3
+	press(runes('v'))
+	press(runes('j'))
+	press(runes('j'))
+	press(runes('v'))
+
+	press(runes(':'))
+	if app.Mode() != tui.ModeBulkMenu {
+		t.Fatalf("expected ModeBulkMenu, got %v", app.Mode())
+	}
+
+	// Delete is the last entry; j stops at the end of the menu
+	for i := 0; i < 10; i++ {
+		press(runes('j'))
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.Mode() != tui.ModeConfirmDelete {
+		t.Fatalf("expected delete to ask for confirmation, got %v", app.Mode())
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if len(store.Bookmarks) != 1 || store.Bookmarks[0].ID != "b2" {
+		t.Errorf("expected only b2 to remain, got %+v", store.Bookmarks)
+	}
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected ModeNormal after delete, got %v", app.Mode())
+	}
+}
+
+func TestApp_BulkExport_WritesOnlySelection(t *testing.T) {
+	workID, deepID := "work", "deep"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: workID, Name: "Work"},
+			{ID: deepID, Name: "Deep", ParentID: &workID},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "One", URL: "https://1.com"},
+			{ID: "b2", Title: "Two", URL: "https://2.com", FolderID: &workID},
+			{ID: "b3", Title: "Three", URL: "https://3.com", FolderID: &deepID},
+			{ID: "b4", Title: "Four", URL: "https://4.com"},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	press := func(msg tea.KeyMsg) {
+		updated, _ := app.Update(msg)
+		app = updated.(tui.App)
+	}
+	runes := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+
+	// Select the Work folder and the Four bookmark, leaving One out
+	press(runes('v'))
+	press(runes('j'))
+	press(runes('j'))
+	press(runes('v'))
+
+	// Export comes after tag, move, pin and open
+	press(runes(':'))
+	for i := 0; i < 4; i++ {
+		press(runes('j'))
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.Mode() != tui.ModeBulkExport {
+		t.Fatalf("expected the export path prompt, got %v", app.Mode())
+	}
+
+	path := filepath.Join(t.TempDir(), "selection.json")
+	press(tea.KeyMsg{Type: tea.KeyCtrlU})
+	for _, r := range path {
+		press(runes(r))
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.Mode() != tui.ModeNormal {
+		t.Fatalf("expected normal mode after exporting, got %v", app.Mode())
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("expected the export file: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	exported, err := importer.ParseJSON(file)
+	if err != nil {
+		t.Fatalf("ParseJSON: %v", err)
+	}
+
+	var urls []string
+	for _, b := range exported.Bookmarks {
+		urls = append(urls, b.URL)
+	}
+	slices.Sort(urls)
+	if want := []string{"https://2.com", "https://3.com", "https://4.com"}; !slices.Equal(urls, want) {
+		t.Errorf("expected only the selected bookmarks and folder contents %v, got %v", want, urls)
+	}
+	if len(exported.Folders) != 2 {
+		t.Errorf("expected the Work and Deep folders, got %+v", exported.Folders)
+	}
+}
+
+func TestApp_BulkOpen_ConfirmsAboveOpenAllThreshold(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "One", URL: "https://1.com"},
+			{ID: "b2", Title: "Two", URL: "https://2.com"},
+			{ID: "b3", Title: "Three", URL: "https://3.com"},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.OpenAllThreshold = 2
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	press := func(msg tea.KeyMsg) {
+		updated, _ := app.Update(msg)
+		app = updated.(tui.App)
+	}
+	runes := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	openSelection := func() {
+		for _, r := range ":jjj" {
+			press(runes(r))
+		}
+		press(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	// Three URLs exceed the threshold of 2, so nothing opens until confirmed
+	for _, r := range "vjvjv" {
+		press(runes(r))
+	}
+	openSelection()
+	if app.Mode() != tui.ModeConfirmLargeOp {
+		t.Fatalf("expected ModeConfirmLargeOp, got %v", app.Mode())
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	for _, b := range store.Bookmarks {
+		if b.VisitCount != 0 {
+			t.Fatalf("expected no visits after cancelling, got %+v", b)
+		}
+	}
+
+	// The selection survives cancelling; confirming opens all of it
+	openSelection()
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.Mode() != tui.ModeNormal {
+		t.Fatalf("expected ModeNormal after confirming, got %v", app.Mode())
+	}
+	for _, b := range store.Bookmarks {
+		if b.VisitCount != 1 {
+			t.Errorf("expected %s to be opened once, got %d visits", b.ID, b.VisitCount)
+		}
+	}
+}
+
+func TestApp_SnoozeRejectsInvalidDuration(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Later", URL: "https://later.dev"},
+		},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	app = updated.(tui.App)
+	for _, r := range "soon" {
+		updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+
+	if app.Mode() != tui.ModeSnooze {
+		t.Errorf("expected to stay in ModeSnooze on invalid input, got %v", app.Mode())
+	}
+	if app.MessageType() != tui.MessageError {
+		t.Errorf("expected error message, got %q", app.StatusMessage())
+	}
+	if store.Bookmarks[0].SnoozeUntil != nil {
+		t.Error("expected bookmark not to be snoozed")
+	}
+}
+
+func TestOrganizeSuggestion_TagDelta(t *testing.T) {
+	sug := tui.OrganizeSuggestion{
+		CurrentTags:   []string{"go", "misc", "tools"},
+		SuggestedTags: []string{"go", "cli", "tools", "terminal"},
+	}
+
+	added := sug.AddedTags()
+	if len(added) != 2 || added[0] != "cli" || added[1] != "terminal" {
+		t.Errorf("expected added [cli terminal], got %v", added)
+	}
+
+	removed := sug.RemovedTags()
+	if len(removed) != 1 || removed[0] != "misc" {
+		t.Errorf("expected removed [misc], got %v", removed)
+	}
+}
+
+func TestApp_TabCyclesFocusWithoutNavigating(t *testing.T) {
+	devID := "dev"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: devID, Name: "Dev"},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev", FolderID: &devID},
+			{ID: "b2", Title: "Pinned", URL: "https://pinned.dev", Pinned: true, PinOrder: 1},
+		},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+	tab := func() {
+		updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyTab})
+		app = updated.(tui.App)
+	}
+
+	if app.FocusedPane() != tui.PanePinned {
+		t.Fatalf("expected to start on the pinned pane, got %v", app.FocusedPane())
+	}
+	tab()
+	if app.FocusedPane() != tui.PaneBrowser {
+		t.Fatalf("expected Tab to focus the browser, got %v", app.FocusedPane())
+	}
+
+	// Enter Dev, then Tab back and forth: the folder must not change
+	app = pressKey(app, 'l')
+	if id := app.CurrentFolderID(); id == nil || *id != devID {
+		t.Fatalf("expected to be inside Dev, got %v", id)
+	}
+	tab()
+	if app.FocusedPane() != tui.PanePinned {
+		t.Errorf("expected Tab to focus the pinned pane, got %v", app.FocusedPane())
+	}
+	tab()
+	if app.FocusedPane() != tui.PaneBrowser {
+		t.Errorf("expected Tab to return to the browser, got %v", app.FocusedPane())
+	}
+	if id := app.CurrentFolderID(); id == nil || *id != devID {
+		t.Errorf("expected Tab to keep the current folder, got %v", id)
+	}
+}
+
+func TestApp_MergeTags_PicksSourceThenTarget(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "MDN", URL: "https://developer.mozilla.org", Tags: []string{"js", "web"}},
+			{ID: "b2", Title: "TC39", URL: "https://tc39.es", Tags: []string{"javascript"}},
+		},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+	enter := func() {
+		updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		app = updated.(tui.App)
+	}
+
+	// Tags are listed alphabetically: javascript, js, web
+	app = pressKey(app, 'T')
+	if app.Mode() != tui.ModeMergeTags {
+		t.Fatalf("expected ModeMergeTags, got %v", app.Mode())
+	}
+	app = pressKey(app, 'j')
+	enter()
+	// The source is left out of the targets, so javascript is first
+	enter()
+
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected ModeNormal after merging, got %v", app.Mode())
+	}
+	if tags := store.Bookmarks[0].Tags; len(tags) != 2 || tags[0] != "javascript" || tags[1] != "web" {
+		t.Errorf("expected js to become javascript, got %v", tags)
+	}
+}
+
+func TestApp_PinnedFilter(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Alpha", Pinned: true, PinOrder: 1},
+			{ID: "f2", Name: "Beta", Pinned: true, PinOrder: 2},
+			{ID: "f3", Name: "Gamma", Pinned: true, PinOrder: 3},
+		},
+		Bookmarks: []model.Bookmark{},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	// Starts focused on pinned pane; / opens the pinned filter
+	app = pressKey(app, '/')
+	if app.Mode() != tui.ModeFilter {
+		t.Fatalf("expected ModeFilter, got %v", app.Mode())
+	}
+	for _, r := range "gam" {
+		app = pressKey(app, r)
+	}
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+
+	pinned := app.PinnedItems()
+	if len(pinned) != 1 || pinned[0].Folder.ID != "f3" {
+		t.Fatalf("expected only Gamma after filtering, got %d items", len(pinned))
+	}
+
+	// 1 acts on the filtered subset (activation returns a *App model)
+	m, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	app = *m.(*tui.App)
+	if app.CurrentFolderID() == nil || *app.CurrentFolderID() != "f3" {
+		t.Errorf("expected 1 to open Gamma, got %v", app.CurrentFolderID())
+	}
+
+	// Back to the pinned pane; Esc clears the filter and restores pin order
+	app = pressKey(app, '0')
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app = updated.(tui.App)
+
+	pinned = app.PinnedItems()
+	if len(pinned) != 3 {
+		t.Fatalf("expected 3 pinned items after clearing filter, got %d", len(pinned))
+	}
+	for i, id := range []string{"f1", "f2", "f3"} {
+		if pinned[i].Folder.ID != id {
+			t.Errorf("pin %d: expected %s, got %s", i, id, pinned[i].Folder.ID)
+		}
+	}
+}
+
+func TestApp_FilterAutoSelect_OpensSingleMatch(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Design"},
+			{ID: "f2", Name: "Golang"},
+			{ID: "f3", Name: "Rust"},
+		},
+		Bookmarks: []model.Bookmark{},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.FilterAutoSelect = true
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	app = pressKey(app, 'G') // cursor on Rust
+	app = pressKey(app, '/')
+	for _, r := range "gol" {
+		app = pressKey(app, r)
+	}
+	if app.Cursor() != 0 {
+		t.Fatalf("expected cursor on the single match, got %d", app.Cursor())
+	}
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if app.CurrentFolderID() == nil || *app.CurrentFolderID() != "f2" {
+		t.Errorf("expected Enter to open Golang, got %v", app.CurrentFolderID())
+	}
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected ModeNormal, got %v", app.Mode())
+	}
+}
+
+func TestApp_PinnedSortAlpha(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "zeta", Pinned: true, PinOrder: 1},
+			{ID: "f2", Name: "Beta", Pinned: true, PinOrder: 3},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Alpha", URL: "https://a.dev", Pinned: true, PinOrder: 2},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.PinnedSortMode = storage.PinnedSortAlpha
+
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	pinned := app.PinnedItems()
+	for i, id := range []string{"b1", "f2", "f1"} {
+		if pinned[i].ID() != id {
+			t.Errorf("pin %d: expected %s, got %s", i, id, pinned[i].ID())
+		}
+	}
+
+	// J/K are disabled outside pin order
+	app = pressKey(app, 'J')
+	if !strings.Contains(app.StatusMessage(), "pinnedSortMode") {
+		t.Errorf("expected reorder hint, got %q", app.StatusMessage())
+	}
+	if store.Bookmarks[0].PinOrder != 2 {
+		t.Errorf("expected pin order untouched, got %d", store.Bookmarks[0].PinOrder)
+	}
+
+	// 2 opens the second displayed item (activation returns a *App model)
+	m, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	app = *m.(*tui.App)
+	if app.CurrentFolderID() == nil || *app.CurrentFolderID() != "f2" {
+		t.Errorf("expected 2 to open Beta, got %v", app.CurrentFolderID())
+	}
+}
+
+// countingStorage records how many times Save is called.
+type countingStorage struct {
+	saves int
+}
+
+func (c *countingStorage) Load() (*model.Store, error) { return nil, nil }
+
+func (c *countingStorage) Save(store *model.Store) error {
+	c.saves++
+	return nil
+}
+
+// snapshotStorage keeps a copy of the bookmarks from the last Save.
+type snapshotStorage struct {
+	bookmarks []model.Bookmark
+}
+
+func (s *snapshotStorage) Load() (*model.Store, error) { return nil, nil }
+
+func (s *snapshotStorage) Save(store *model.Store) error {
+	s.bookmarks = slices.Clone(store.Bookmarks)
+	return nil
+}
+
+// flakyStorage fails every Save while fail is set.
+type flakyStorage struct{ fail bool }
+
+func (f *flakyStorage) Load() (*model.Store, error) { return nil, nil }
+func (f *flakyStorage) Save(*model.Store) error {
+	if f.fail {
+		return errors.New("disk full")
+	}
+	return nil
+}
+
+func TestApp_SaveError_PersistsUntilSaveSucceeds(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "One", URL: "https://1.com"},
+			{ID: "b2", Title: "Two", URL: "https://2.com"},
+		},
+	}
+	st := &flakyStorage{fail: true}
+	cfg := storage.DefaultConfig()
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: st, Config: &cfg}).WithDimensions(120, 30)
+
+	app = pressKey(app, '*') // pin triggers a save
+	app = pressKey(app, 'j') // navigation clears the transient message
+
+	if view := app.View(); !strings.Contains(view, "Not saved: disk full") {
+		t.Fatalf("expected save error banner after the message cleared, got:\n%s", view)
+	}
+
+	st.fail = false
+	app = pressKey(app, '*')
+	if !strings.Contains(app.StatusMessage(), "Changes saved") {
+		t.Errorf("expected recovery message, got %q", app.StatusMessage())
+	}
+	app = pressKey(app, 'k')
+	if view := app.View(); strings.Contains(view, "Not saved") {
+		t.Error("expected banner to disappear after a successful save")
+	}
+}
+
+func TestApp_NoStorage_WarnsWhenStorageRequired(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{{ID: "b1", Title: "One", URL: "https://1.com"}},
+	}
+
+	app := tui.NewApp(tui.AppParams{Store: store, RequireStorage: true}).WithDimensions(120, 30)
+	app = pressKey(app, '*') // the pin message replaces the banner for a moment
+	app = pressKey(app, 'j')
+	if view := app.View(); !strings.Contains(view, "Changes will NOT be saved (no storage)") {
+		t.Fatalf("expected no-storage banner, got:\n%s", view)
+	}
+
+	// Tests and embeddings run without storage on purpose
+	app = tui.NewApp(tui.AppParams{Store: store}).WithDimensions(120, 30)
+	if view := app.View(); strings.Contains(view, "no storage") {
+		t.Error("expected no banner when storage isn't required")
+	}
+}
+
+func TestApp_UnwritableStorage_WarnsAtStartup(t *testing.T) {
+	// A regular file as the parent directory can't be written, even by root
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	unwritable := storage.CheckWritable(filepath.Join(file, "bookmarks.db"))
+	if unwritable == nil {
+		t.Fatal("expected the path to be reported unwritable")
+	}
+
+	app := tui.NewApp(tui.AppParams{
+		Store:      &model.Store{},
+		Storage:    &countingStorage{},
+		Unwritable: unwritable,
+	}).WithDimensions(120, 30)
+	if view := app.View(); !strings.Contains(view, "Changes will NOT be saved") {
+		t.Errorf("expected the unwritable banner, got:\n%s", view)
+	}
+
+	if err := storage.CheckWritable(filepath.Join(t.TempDir(), "bookmarks.db")); err != nil {
+		t.Errorf("expected a temp dir to be writable, got %v", err)
+	}
+}
+
+func TestApp_ConfigWarnings_ShownAtStartup(t *testing.T) {
+	cfg := storage.DefaultConfig()
+	cfg.Warnings = []string{`copyLinkFormat "<{url}>": missing {title}; using "[{title}]({url})"`}
+
+	app := tui.NewApp(tui.AppParams{Store: &model.Store{}, Config: &cfg})
+	if !strings.Contains(app.StatusMessage(), "copyLinkFormat") {
+		t.Errorf("expected the config warning in the status, got %q", app.StatusMessage())
+	}
+}
+
+// cacheStorage serves a fixed cull cache.
+type cacheStorage struct {
+	countingStorage
+	cull *storage.CullCache
+}
+
+func (c *cacheStorage) SaveCullCache(cache *storage.CullCache) error { return nil }
+
+func (c *cacheStorage) LoadCullCache() (*storage.CullCache, error) { return c.cull, nil }
+
+func (c *cacheStorage) SaveOrganizeCache(cache *storage.OrganizeCache) error { return nil }
+
+func (c *cacheStorage) LoadOrganizeCache() (*storage.OrganizeCache, error) {
+	return nil, storage.ErrNoCache
+}
+
+// runCmds executes cmd (flattening batches) and returns the messages produced
+// within a short deadline; slow commands such as message-clear ticks are dropped.
+func runCmds(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, runCmds(c)...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
+
+func TestApp_DebouncedSave_CoalescesMutations(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Folder 1", ParentID: nil},
+		},
+		Bookmarks: []model.Bookmark{},
+	}
+	st := &countingStorage{}
+	cfg := storage.DefaultConfig()
+	cfg.SaveDebounceMs = 1
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: st, Config: &cfg})
+
+	// Toggle pin rapidly; each mutation schedules a save tick
+	var pending []tea.Msg
+	for i := 0; i < 4; i++ {
+		updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+		app = updated.(tui.App)
+		pending = append(pending, runCmds(cmd)...)
+	}
+	if st.saves != 0 {
+		t.Fatalf("expected no saves during burst, got %d", st.saves)
+	}
+
+	// Deliver all ticks; only the latest should write
+	for _, msg := range pending {
+		updated, _ := app.Update(msg)
+		app = updated.(tui.App)
+	}
+	if st.saves != 1 {
+		t.Errorf("expected burst to coalesce into 1 save, got %d", st.saves)
+	}
+}
+
+func TestApp_IdleTimeout_QuitsAndSavesAfterInactivity(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Folder 1", ParentID: nil},
+		},
+	}
+	st := &countingStorage{}
+	cfg := storage.DefaultConfig()
+	cfg.SaveDebounceMs = 60000
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: st, Config: &cfg}).WithIdleTimeout(10 * time.Millisecond)
+
+	// quits delivers msgs and reports whether any of them quit bm
+	quits := func(msgs []tea.Msg) bool {
+		quit := false
+		for _, msg := range msgs {
+			updated, cmd := app.Update(msg)
+			app = updated.(tui.App)
+			for _, m := range runCmds(cmd) {
+				if _, ok := m.(tea.QuitMsg); ok {
+					quit = true
+				}
+			}
+		}
+		return quit
+	}
+
+	startup := app.Init()
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	app = updated.(tui.App)
+	afterKey := runCmds(cmd)
+
+	// The timer started before the key press no longer counts
+	if quits(runCmds(startup)) {
+		t.Fatal("expected a key press to restart the idle timer")
+	}
+	if st.saves != 0 {
+		t.Fatalf("expected the pin to wait for its debounced save, got %d saves", st.saves)
+	}
+
+	if !quits(afterKey) {
+		t.Fatal("expected bm to quit once idle after the last key press")
+	}
+	if st.saves != 1 {
+		t.Errorf("expected the pending change to be saved on the idle quit, got %d saves", st.saves)
+	}
+}
+
+func TestApp_DebouncedSave_FlushesOnQuit(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "f1", Name: "Folder 1", ParentID: nil},
+		},
+		Bookmarks: []model.Bookmark{},
+	}
+	st := &countingStorage{}
+	cfg := storage.DefaultConfig()
+	cfg.SaveDebounceMs = 60000
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: st, Config: &cfg})
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	app = updated.(tui.App)
+	if st.saves != 0 {
+		t.Fatalf("expected save to be deferred, got %d", st.saves)
+	}
+
+	_, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if st.saves != 1 {
+		t.Errorf("expected pending changes to be saved on quit, got %d saves", st.saves)
+	}
+}
+
+func TestApp_ItemCounter_ReflectsCursor(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "One", URL: "https://1.com"},
+			{ID: "b2", Title: "Two", URL: "https://2.com"},
+			{ID: "b3", Title: "Three", URL: "https://3.com"},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.ShowItemCounter = true
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg}).WithDimensions(120, 30)
+
+	if !containsStr(app.View(), "1/3") {
+		t.Error("expected counter 1/3 at the first item")
+	}
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	app = updated.(tui.App)
+	if !containsStr(app.View(), "2/3") {
+		t.Error("expected counter 2/3 after moving down")
+	}
+
+	cfg.ShowItemCounter = false
+	app = tui.NewApp(tui.AppParams{Store: store, Config: &cfg}).WithDimensions(120, 30)
+	if containsStr(app.View(), "1/3") {
+		t.Error("expected no counter when disabled")
+	}
+}
+
+func TestApp_PreviewPane_WrapsLongContent(t *testing.T) {
+	longTitle := strings.Repeat("remarkably verbose bookmark title ", 20) + "END"
+	tags := make([]string, 40)
+	for i := range tags {
+		tags[i] = "tag" + strings.Repeat("x", i%7)
+	}
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: longTitle, URL: "https://example.com/" + strings.Repeat("a", 300), Tags: tags},
+		},
+	}
+
+	height := 40
+	view := tui.NewApp(tui.AppParams{Store: store}).WithDimensions(120, height).View()
+	lines := strings.Split(view, "\n")
+
+	// Every pane row must be exactly as wide as the top border row
+	width := -1
+	for _, line := range lines {
+		if strings.Contains(line, "┏") {
+			width = layout.VisibleLength(line)
+			break
+		}
+	}
+	if width < 0 {
+		t.Fatal("expected pane borders in the view")
+	}
+	for i, line := range lines {
+		if strings.Contains(line, "┃") && layout.VisibleLength(line) != width {
+			t.Errorf("line %d is %d columns wide, want %d: %q", i, layout.VisibleLength(line), width, layout.StripANSI(line))
+		}
+	}
+	if len(lines) > height {
+		t.Errorf("view is %d lines tall, want at most %d", len(lines), height)
+	}
+	if !containsStr(view, "END") {
+		t.Error("expected the end of the long title to wrap into view rather than be cut off")
+	}
+}
+
+func TestApp_Breadcrumb_CountsBrokenLinksInSubtree(t *testing.T) {
+	devID, goID := "dev", "go"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "dev", Name: "Dev"},
+			{ID: "go", Name: "Go", ParentID: &devID},
+			{ID: "misc", Name: "Misc"},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Dead", URL: "https://dead.dev", FolderID: &devID},
+			{ID: "b2", Title: "Gone", URL: "https://gone.dev", FolderID: &goID},
+			{ID: "b3", Title: "Fine", URL: "https://fine.dev", FolderID: &goID},
+			{ID: "b4", Title: "Root", URL: "https://root.dev"},
+		},
+	}
+	st := &cacheStorage{cull: &storage.CullCache{
+		Timestamp: time.Now(),
+		Results: []storage.CullCacheResult{
+			{BookmarkID: "b1", Status: 1, StatusCode: 404},
+			{BookmarkID: "b2", Status: 2, Error: "no such host"},
+			{BookmarkID: "b4", Status: 1, StatusCode: 410},
+		},
+	}}
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: st}).WithDimensions(120, 30)
+
+	if !containsStr(app.View(), "3 broken") {
+		t.Error("expected all 3 broken links counted at root")
+	}
+
+	// Enter Dev: b1 plus b2 in the nested Go folder
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	app = updated.(tui.App)
+	if !containsStr(app.View(), "2 broken") {
+		t.Error("expected 2 broken links in the Dev subtree")
+	}
+
+	// Misc has none, so the indicator is hidden
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	app = updated.(tui.App)
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	app = updated.(tui.App)
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	app = updated.(tui.App)
+	if containsStr(app.View(), "broken") {
+		t.Error("expected no indicator in a folder without broken links")
+	}
+}
+
+func TestApp_Cull_CorruptCacheFileRunsFresh(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cachePath := filepath.Join(home, ".config", "bm", "cull-cache.json")
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cachePath, []byte("\x00garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := &model.Store{Bookmarks: []model.Bookmark{
+		{ID: "b1", Title: "Example", URL: "https://example.com"},
+	}}
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: &countingStorage{}}).WithDimensions(120, 30)
+
+	// Startup reads the cache for broken-link markers and drops the bad file
+	if !strings.Contains(app.StatusMessage(), "unreadable cull cache") {
+		t.Errorf("expected a warning about the cache, got %q", app.StatusMessage())
+	}
+	if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+		t.Error("expected the corrupt cache file to be removed")
+	}
+
+	// Cull skips the cached/fresh menu and starts a fresh run
+	app = pressKey(app, 'C')
+	if app.Mode() != tui.ModeCullLoading {
+		t.Fatalf("expected a fresh cull run, got mode %v", app.Mode())
+	}
+}
+
+func TestApp_Cull_CancelledRunResultIsIgnored(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	store := &model.Store{Bookmarks: []model.Bookmark{
+		{ID: "b1", Title: "Example", URL: "http://127.0.0.1:1"},
+	}}
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: &countingStorage{}}).WithDimensions(120, 30)
+
+	updated, cullCmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeCullLoading {
+		t.Fatalf("expected a cull run, got mode %v", app.Mode())
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app = updated.(tui.App)
+
+	// The run finishes after Esc; its completion must not reopen cull
+	for _, msg := range runCmds(cullCmd) {
+		updated, _ = app.Update(msg)
+		app = updated.(tui.App)
+	}
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected the cancelled run to be ignored, got mode %v", app.Mode())
+	}
+	if strings.Contains(app.StatusMessage(), "healthy") {
+		t.Errorf("expected no cull result, got %q", app.StatusMessage())
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "bm", "cull-cache.json")); !os.IsNotExist(err) {
+		t.Error("expected no cull cache from the cancelled run")
+	}
+}
+
+func TestApp_AddFromTemplate_PrefillsConfirm(t *testing.T) {
+	store := &model.Store{}
+	cfg := storage.DefaultConfig()
+	cfg.Templates = map[string]storage.Template{
+		"standup": {Folder: "/Work/Standups", Tags: []string{"standup"}, TitlePrefix: "Standup: "},
+	}
+	clip := &recordingClipboard{written: "https://notes.example.com/1"}
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg, Clipboard: clip}).WithDimensions(120, 30)
+
+	// A single template skips the picker and goes straight to the URL prompt
+	app = pressKey(app, 'I')
+	if app.Mode() != tui.ModeQuickAdd {
+		t.Fatalf("expected URL prompt, got mode %v", app.Mode())
+	}
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeQuickAddConfirm {
+		t.Fatalf("expected confirmation, got mode %v", app.Mode())
+	}
+	view := layout.StripANSI(app.View())
+	if !strings.Contains(view, "/Work/Standups") || !strings.Contains(view, "standup") {
+		t.Errorf("expected confirmation pre-filled from the template:\n%s", view)
+	}
+
+	for _, r := range "Monday" {
+		app = pressKey(app, r)
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+
+	if len(store.Bookmarks) != 1 {
+		t.Fatalf("expected 1 bookmark, got %d", len(store.Bookmarks))
+	}
+	b := store.Bookmarks[0]
+	if b.Title != "Standup: Monday" {
+		t.Errorf("expected prefixed title, got %q", b.Title)
+	}
+	if got := store.GetFolderPath(b.FolderID); got != "/Work/Standups" {
+		t.Errorf("expected bookmark in /Work/Standups, got %q", got)
+	}
+	if !slices.Equal(b.Tags, []string{"standup"}) {
+		t.Errorf("expected template tags, got %v", b.Tags)
+	}
+}
+
+func TestApp_QuickAdd_RegenerateReplacesSuggestion(t *testing.T) {
+	store := &model.Store{}
+	responses := []*ai.Response{
+		{Title: "First Title", FolderPath: "/", Tags: []string{"first"}},
+		{Title: "Second Title", FolderPath: "/", Tags: []string{"second"}},
+	}
+	calls := 0
+	app := tui.NewApp(tui.AppParams{
+		Store:     store,
+		Clipboard: staticClipboard{text: "https://example.com"},
+		AIClient:  func() (*ai.Client, error) { return &ai.Client{}, nil },
+		SuggestBookmark: func(_ context.Context, _, _ string) (*ai.Response, error) {
+			r := responses[min(calls, len(responses)-1)]
+			calls++
+			return r, nil
+		},
+	}).WithDimensions(120, 30)
+
+	// deliver runs the pending AI call and feeds its result back in
+	deliver := func(cmd tea.Cmd) {
+		t.Helper()
+		for _, msg := range runCmds(cmd) {
+			updated, _ := app.Update(msg)
+			app = updated.(tui.App)
+		}
+	}
+
+	app = pressKey(app, 'i')
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	deliver(cmd)
+	if app.Mode() != tui.ModeQuickAddConfirm {
+		t.Fatalf("expected confirmation, got mode %v", app.Mode())
+	}
+	if view := layout.StripANSI(app.View()); !strings.Contains(view, "First Title") {
+		t.Fatalf("expected first suggestion in the confirm inputs:\n%s", view)
+	}
+
+	updated, cmd = app.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeQuickAddLoading {
+		t.Fatalf("expected loading while regenerating, got mode %v", app.Mode())
+	}
+	deliver(cmd)
+	if app.Mode() != tui.ModeQuickAddConfirm {
+		t.Fatalf("expected confirmation after regenerating, got mode %v", app.Mode())
+	}
+	view := layout.StripANSI(app.View())
+	if !strings.Contains(view, "Second Title") || strings.Contains(view, "First Title") {
+		t.Errorf("expected the second suggestion to replace the first:\n%s", view)
+	}
+
+	// Regenerations are capped, after which Ctrl+r stays on the confirm screen
+	for range 2 {
+		updated, cmd = app.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+		app = updated.(tui.App)
+		deliver(cmd)
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeQuickAddConfirm || calls != 4 {
+		t.Errorf("expected regenerate to stop after 3 retries, got mode %v after %d calls", app.Mode(), calls)
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if len(store.Bookmarks) != 1 {
+		t.Fatalf("expected 1 bookmark, got %d", len(store.Bookmarks))
+	}
+	if b := store.Bookmarks[0]; b.Title != "Second Title" || !slices.Equal(b.Tags, []string{"second"}) {
+		t.Errorf("expected the regenerated suggestion to be saved, got %q %v", b.Title, b.Tags)
+	}
+}
+
+func TestApp_Domains_DrillIntoHostAndReveal(t *testing.T) {
+	codeID := "f1"
+	store := &model.Store{
+		Folders: []model.Folder{{ID: "f1", Name: "Code"}},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Example", URL: "https://example.com"},
+			{ID: "b2", Title: "Repo", URL: "https://github.com/a/b", FolderID: &codeID},
+			{ID: "b3", Title: "Docs", URL: "https://docs.github.com/x"},
+			{ID: "b4", Title: "Other repo", URL: "https://github.com/c/d", FolderID: &codeID},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.MergeSubdomains = true
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg}).WithDimensions(120, 30)
+
+	app = pressKey(app, 'D')
+	if app.Mode() != tui.ModeDomains {
+		t.Fatalf("expected domains view, got mode %v", app.Mode())
+	}
+	view := layout.StripANSI(app.View())
+	if !strings.Contains(view, "github.com  3") || strings.Index(view, "github.com") > strings.Index(view, "example.com") {
+		t.Errorf("expected github.com listed first with 3 bookmarks:\n%s", view)
+	}
+
+	// Drill into github.com: its bookmarks are listed with their folders
+	app = pressKey(app, 'l')
+	view = layout.StripANSI(app.View())
+	if !strings.Contains(view, "Repo  /Code") || !strings.Contains(view, "Docs  /") {
+		t.Errorf("expected github.com bookmarks with folder paths:\n%s", view)
+	}
+
+	// Enter reveals the bookmark in the browser
+	app = pressKey(app, 'j')
+	app = pressKey(app, 'j')
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeNormal {
+		t.Fatalf("expected browser after Enter, got mode %v", app.Mode())
+	}
+	if id := app.CurrentFolderID(); id == nil || *id != codeID {
+		t.Fatalf("expected browser in /Code, got %v", id)
+	}
+	if item := app.Items()[app.Cursor()]; item.Bookmark == nil || item.Bookmark.ID != "b4" {
+		t.Errorf("expected cursor on b4, got %+v", item)
+	}
+}
+
+func TestApp_DisableAI_NeverConstructsClient(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config bool
+		noAI   bool
+	}{
+		{name: "config", config: true},
+		{name: "flag", noAI: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &model.Store{}
+			cfg := storage.DefaultConfig()
+			cfg.DisableAI = tc.config
+			clip := &recordingClipboard{written: "https://example.com/a"}
+			calls := 0
+			app := tui.NewApp(tui.AppParams{
+				Store:     store,
+				Config:    &cfg,
+				Clipboard: clip,
+				NoAI:      tc.noAI,
+				AIClient: func() (*ai.Client, error) {
+					calls++
+					return nil, ai.ErrNoAPIKey
+				},
+			}).WithDimensions(120, 30)
+
+			for _, r := range "iLO" {
+				updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+				app = updated.(tui.App)
+				runCmds(cmd)
+				if app.Mode() != tui.ModeNormal {
+					t.Fatalf("%c: expected to stay in normal mode, got %v", r, app.Mode())
+				}
+			}
+			if calls != 0 {
+				t.Errorf("expected no AI client, constructed %d", calls)
+			}
+			// i and L fell back to instant capture
+			if len(store.Bookmarks) != 2 || store.Bookmarks[0].Title != "https://example.com/a" {
+				t.Errorf("expected two captures titled with the URL, got %+v", store.Bookmarks)
+			}
+			if !strings.Contains(app.StatusMessage(), "AI disabled") {
+				t.Errorf("expected O to report AI disabled, got %q", app.StatusMessage())
+			}
+		})
+	}
+}
+
+func TestApp_Priority_CycleWrapsAndSortsHighestFirst(t *testing.T) {
+	store := &model.Store{Bookmarks: []model.Bookmark{
+		{ID: "b1", Title: "Low", URL: "https://low.dev", Priority: model.PriorityLow},
+		{ID: "b2", Title: "None", URL: "https://none.dev"},
+		{ID: "b3", Title: "High", URL: "https://high.dev", Priority: model.PriorityHigh},
+		{ID: "b4", Title: "Medium", URL: "https://medium.dev", Priority: model.PriorityMedium},
+	}}
+	app := tui.NewApp(tui.AppParams{Store: store}).WithDimensions(120, 30)
+
+	// + on "Low" steps through medium and high, then wraps to none and low
+	want := []int{model.PriorityMedium, model.PriorityHigh, model.PriorityNone, model.PriorityLow}
+	for i, p := range want {
+		app = pressKey(app, '+')
+		if got := store.GetBookmarkByID("b1").Priority; got != p {
+			t.Fatalf("press %d: expected priority %d, got %d", i+1, p, got)
+		}
+	}
+	view := layout.StripANSI(app.View())
+	if !strings.Contains(view, "↑ Low") {
+		t.Error("expected the low priority glyph before the title")
+	}
+	// "!" is the locked-folder marker, so priority must not reuse it
+	if strings.Contains(view, "! Low") {
+		t.Error("expected priority not to render with the locked marker")
+	}
+
+	// Cycle sort to priority (manual -> alpha -> created -> visited -> modified -> priority)
+	for i := 0; i < 5; i++ {
+		app = pressKey(app, 't')
+		app = pressKey(app, 'o')
+	}
+	if app.SortMode() != tui.SortPriority {
+		t.Fatalf("expected SortPriority, got %d", app.SortMode())
+	}
+	var titles []string
+	for _, item := range app.Items() {
+		titles = append(titles, item.Title())
+	}
+	if wantOrder := []string{"High", "Medium", "Low", "None"}; !slices.Equal(titles, wantOrder) {
+		t.Errorf("expected %v, got %v", wantOrder, titles)
+	}
+
+	// Raising a bookmark's priority keeps the cursor on it as it moves up
+	app = pressKey(app, 'j')
+	app = pressKey(app, 'j') // Low
+	app = pressKey(app, '+') // Low becomes medium and moves up
+	if item := app.Items()[app.Cursor()]; item.Bookmark == nil || item.Bookmark.ID != "b1" {
+		t.Errorf("expected cursor to follow b1, got %+v", item)
+	}
+}
+
+func TestApp_VerifyBeforeOpen_DeadLinkWarnsInsteadOfOpening(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Gone", URL: "https://example.com/gone"},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.VerifyBeforeOpen = true
+	var checked []string
+	checker := func(b model.Bookmark) culler.Result {
+		checked = append(checked, b.URL)
+		return culler.Result{Bookmark: &b, Status: culler.Dead, StatusCode: 404}
+	}
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg, LinkChecker: checker}).WithDimensions(120, 30)
+
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeVerifyOpen {
+		t.Fatalf("expected link check before opening, got mode %v", app.Mode())
+	}
+	for _, msg := range runCmds(cmd) {
+		updated, cmd = app.Update(msg)
+		app = updated.(tui.App)
+		if cmd != nil {
+			t.Fatal("expected no open or quit for a dead link")
+		}
+	}
+	if !slices.Equal(checked, []string{"https://example.com/gone"}) {
+		t.Fatalf("expected one check of the bookmark URL, got %v", checked)
+	}
+	if app.Mode() != tui.ModeVerifyOpen {
+		t.Fatalf("expected the warning to stay up, got mode %v", app.Mode())
+	}
+	if view := layout.StripANSI(app.View()); !strings.Contains(view, "Link looks dead (404 Not Found)") {
+		t.Errorf("expected dead link warning:\n%s", view)
+	}
+	if store.Bookmarks[0].VisitedAt != nil {
+		t.Error("expected the bookmark to stay unvisited")
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected Esc to cancel, got mode %v", app.Mode())
+	}
+}
+
+func TestApp_Sequence_GPrefixCommands(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "One", URL: "https://one.example.com/a"},
+			{ID: "b2", Title: "Two", URL: "https://two.example.com/b"},
+		},
+	}
+	clip := &recordingClipboard{}
+	app := tui.NewApp(tui.AppParams{Store: store, Clipboard: clip}).WithDimensions(120, 30)
+
+	app = pressKey(app, 'j')
+	app = pressKey(app, 'g')
+	if view := layout.StripANSI(app.View()); !strings.Contains(view, "g-") {
+		t.Errorf("expected pending g in the status line:\n%s", view)
+	}
+
+	// gd copies the domain of the item under the cursor
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	app = updated.(tui.App)
+	runCmds(cmd)
+	if clip.written != "two.example.com" {
+		t.Fatalf("expected gd to copy the domain, got %v", clip.written)
+	}
+	if len(app.Items()) != 2 {
+		t.Fatal("expected gd not to delete the item")
+	}
+
+	// gg goes to the top; the pending prefix is gone afterwards
+	app = pressKey(app, 'g')
+	app = pressKey(app, 'g')
+	if app.Cursor() != 0 {
+		t.Errorf("expected gg to move to the top, got cursor %d", app.Cursor())
+	}
+	if view := layout.StripANSI(app.View()); strings.Contains(view, "g-") {
+		t.Errorf("expected no pending prefix after gg:\n%s", view)
+	}
+
+	// A key that completes nothing is handled on its own: gj moves down
+	app = pressKey(app, 'g')
+	app = pressKey(app, 'j')
+	if app.Cursor() != 1 {
+		t.Errorf("expected gj to move down, got cursor %d", app.Cursor())
+	}
+}
+
+func TestApp_Sequence_TimeoutCancelsPrefix(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "One", URL: "https://one.example.com"},
+			{ID: "b2", Title: "Two", URL: "https://two.example.com"},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.SequenceTimeoutMs = 1
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg}).WithDimensions(120, 30)
+	app = pressKey(app, 'j')
+
+	// A timeout for an earlier prefix doesn't cancel the current one
+	updated, stale := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	app = updated.(tui.App)
+	app = pressKey(app, 'j')
+	updated, current := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	app = updated.(tui.App)
+	for _, msg := range runCmds(stale) {
+		updated, _ = app.Update(msg)
+		app = updated.(tui.App)
+	}
+	if view := layout.StripANSI(app.View()); !strings.Contains(view, "g-") {
+		t.Fatalf("expected a stale timeout to keep the pending g:\n%s", view)
+	}
+
+	msgs := runCmds(current)
+	if len(msgs) == 0 {
+		t.Fatal("expected a timeout for the pending g")
+	}
+	for _, msg := range msgs {
+		updated, _ = app.Update(msg)
+		app = updated.(tui.App)
+	}
+	if view := layout.StripANSI(app.View()); strings.Contains(view, "g-") {
+		t.Errorf("expected the timeout to clear the pending g:\n%s", view)
+	}
+
+	// The next g starts a new sequence instead of completing gg
+	app = pressKey(app, 'g')
+	if app.Cursor() != 1 {
+		t.Errorf("expected g after a timeout not to jump to the top, got cursor %d", app.Cursor())
+	}
+}
+
+func TestApp_FuzzyFinder_CtrlYCopiesHighlightedURL(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "TanStack Router", URL: "https://tanstack.com"},
+			{ID: "b2", Title: "React Docs", URL: "https://react.dev"},
+		},
+	}
+	clip := &recordingClipboard{}
+	app := tui.NewApp(tui.AppParams{Store: store, Clipboard: clip})
+
+	app = pressKey(app, 'f')
+	for _, r := range "react" {
+		app = pressKey(app, r)
+	}
+	if len(app.FuzzyMatches()) != 1 {
+		t.Fatalf("expected 1 fuzzy match, got %d", len(app.FuzzyMatches()))
+	}
+
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlY})
+	app = updated.(tui.App)
+	runCmds(cmd)
+	if clip.written != "https://react.dev" {
+		t.Errorf("expected the highlighted URL copied, got %q", clip.written)
+	}
+	if app.Mode() != tui.ModeSearch {
+		t.Errorf("expected to stay in the finder, got mode %d", app.Mode())
+	}
+
+	// Ctrl+E jumps to editing the highlighted result
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeEditBookmark {
+		t.Errorf("expected edit mode after Ctrl+E, got mode %d", app.Mode())
+	}
+}
+
+func TestApp_Requeue_MovesBookmarkToReadLater(t *testing.T) {
+	devID := "dev"
+	store := &model.Store{
+		Folders: []model.Folder{{ID: devID, Name: "Dev"}},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go Tour", URL: "https://go.dev/tour", FolderID: &devID},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store}).WithDimensions(120, 30)
+
+	// Folders are left alone
+	app = pressKey(app, 'g')
+	app = pressKey(app, 'L')
+	if len(store.Folders) != 1 {
+		t.Fatalf("expected gL on a folder to do nothing, got %d folders", len(store.Folders))
+	}
+
+	app = pressKey(app, 'l')
+	app = pressKey(app, 'g')
+	app = pressKey(app, 'L')
+	readLater := store.GetFolderByPath("/Read Later")
+	if readLater == nil {
+		t.Fatal("expected the Read Later folder to be created")
+	}
+	if b := store.GetBookmarkByID("b1"); b.FolderID == nil || *b.FolderID != readLater.ID {
+		t.Errorf("expected the bookmark moved to Read Later, got %v", b.FolderID)
+	}
+	if !strings.Contains(app.StatusMessage(), "Moved to Read Later: Go Tour") {
+		t.Errorf("expected the move reported, got %q", app.StatusMessage())
+	}
+	if len(app.Items()) != 0 {
+		t.Errorf("expected Dev to be empty, got %d items", len(app.Items()))
+	}
+}
+
+func TestApp_Preview_UsesConfiguredDateFormat(t *testing.T) {
+	created := time.Date(2026, time.March, 4, 9, 30, 0, 0, time.UTC)
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev", CreatedAt: created},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.DateFormat = "02.01.2006"
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg}).WithDimensions(120, 30)
+
+	view := layout.StripANSI(app.View())
+	if !strings.Contains(view, "Created: 04.03.2026") {
+		t.Errorf("expected the custom date format in the preview:\n%s", view)
+	}
+}
+
+func TestApp_Cull_QuarantineGroupMovesToBrokenFolder(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cachePath := filepath.Join(home, ".config", "bm", "cull-cache.json")
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cache := storage.CullCache{
+		Timestamp: time.Now(),
+		Results: []storage.CullCacheResult{
+			{BookmarkID: "b1", Status: int(culler.Dead), StatusCode: 404},
+			{BookmarkID: "b2", Status: int(culler.Dead), StatusCode: 410},
+			{BookmarkID: "b3", Status: int(culler.Healthy), StatusCode: 200},
+		},
+	}
+	if err := storage.WriteCacheFile(cachePath, cache); err != nil {
+		t.Fatal(err)
+	}
+
+	devID := "dev"
+	store := &model.Store{
+		Folders: []model.Folder{{ID: devID, Name: "Dev"}},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Gone", URL: "https://gone.example.com", FolderID: &devID},
+			{ID: "b2", Title: "Removed", URL: "https://removed.example.com"},
+			{ID: "b3", Title: "Fine", URL: "https://fine.example.com", FolderID: &devID},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: &countingStorage{}}).WithDimensions(120, 30)
+
+	// Use the cached results
+	app = pressKey(app, 'C')
+	app = pressKey(app, 'j')
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeCullResults {
+		t.Fatalf("expected cull results, got mode %v", app.Mode())
+	}
+
+	// The cull handlers work on *App, like the rest of the cull flow
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	app = *updated.(*tui.App)
+	broken := store.GetFolderByPath("/Broken")
+	if broken == nil {
+		t.Fatal("expected the Broken folder to be created")
+	}
+	for _, id := range []string{"b1", "b2"} {
+		if b := store.GetBookmarkByID(id); b == nil || b.FolderID == nil || *b.FolderID != broken.ID {
+			t.Errorf("expected %s moved to Broken, got %+v", id, b)
+		}
+	}
+	if b := store.GetBookmarkByID("b3"); *b.FolderID != devID {
+		t.Error("expected the healthy bookmark to stay in Dev")
+	}
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected cull to finish with its only group handled, got mode %v", app.Mode())
+	}
+	if !strings.Contains(app.StatusMessage(), "Moved 2 bookmarks to Broken") {
+		t.Errorf("expected the count reported, got %q", app.StatusMessage())
+	}
+}
+
+func TestApp_Onboarding_ShownOnceUntilDismissed(t *testing.T) {
+	store := &model.Store{Folders: []model.Folder{}, Bookmarks: []model.Bookmark{}}
+	cfg := storage.DefaultConfig()
+	configPath := filepath.Join(t.TempDir(), "config.json")
+
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg, ConfigPath: configPath, FirstRun: true})
+	if app.Mode() != tui.ModeOnboarding {
+		t.Fatalf("expected ModeOnboarding on first run, got %v", app.Mode())
+	}
+
+	// Any key dismisses, q included, without quitting
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected ModeNormal after dismissing, got %v", app.Mode())
+	}
+	if cmd != nil {
+		if _, ok := cmd().(tea.QuitMsg); ok {
+			t.Error("expected q to dismiss the tour, not quit")
+		}
+	}
+
+	saved, err := storage.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load saved config: %v", err)
+	}
+	if !saved.OnboardingShown {
+		t.Fatal("expected dismissal to be recorded in the config")
+	}
+
+	app = tui.NewApp(tui.AppParams{Store: store, Config: saved, ConfigPath: configPath, FirstRun: true})
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected no tour once dismissed, got %v", app.Mode())
+	}
+}
+
+func TestApp_SiblingFolder_CtrlNMovesToNextSibling(t *testing.T) {
+	devID, docsID, musicID := "dev", "docs", "music"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: devID, Name: "Dev"},
+			{ID: docsID, Name: "Docs"},
+			{ID: musicID, Name: "Music"},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go Docs", URL: "https://go.dev/doc", FolderID: &docsID},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+	app = pressKey(app, 'l') // into Dev
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+	app = updated.(tui.App)
+	if id := app.CurrentFolderID(); id == nil || *id != docsID {
+		t.Fatalf("expected to be in Docs after ctrl+n, got %v", id)
+	}
+	if items := app.Items(); len(items) != 1 || items[0].Bookmark.ID != "b1" {
+		t.Errorf("expected Docs contents to be listed, got %v", items)
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	app = updated.(tui.App)
+	if id := app.CurrentFolderID(); id == nil || *id != devID {
+		t.Errorf("expected to be back in Dev after ctrl+p, got %v", id)
+	}
+
+	// Nothing before the first sibling
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	app = updated.(tui.App)
+	if id := app.CurrentFolderID(); id == nil || *id != devID {
+		t.Errorf("expected to stay in Dev, got %v", id)
+	}
+}
+
+func TestApp_FuzzyFinder_SearchBookmarksOnly(t *testing.T) {
+	folderID := "f1"
+	store := &model.Store{
+		Folders: []model.Folder{{ID: folderID, Name: "Dev"}},
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Go", URL: "https://go.dev", FolderID: &folderID},
+			{ID: "b2", Title: "Rust", URL: "https://rust-lang.org"},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.SearchBookmarksOnly = true
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	app = pressKey(app, 'f')
+	matches := app.FuzzyMatches()
+	if len(matches) != 2 {
+		t.Fatalf("expected only the 2 bookmarks, got %d items", len(matches))
+	}
+	for _, m := range matches {
+		if m.Item.IsFolder() {
+			t.Errorf("expected no folders, got %q", m.Item.Title())
+		}
+	}
+
+	// Ctrl+F brings folders back for this search
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	app = updated.(tui.App)
+	if got := len(app.FuzzyMatches()); got != 3 {
+		t.Errorf("expected folders and bookmarks after ctrl+f, got %d items", got)
+	}
+}
+
+func TestApp_FuzzyFinder_MatchesURL(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Team handbook", URL: "https://notion.so/handbook"},
+			{ID: "b2", Title: "Recipes", URL: "https://cooking.example"},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	app = pressKey(app, 'f')
+	for _, r := range "notion" {
+		app = pressKey(app, r)
+	}
+	matches := app.FuzzyMatches()
+	if len(matches) != 1 || matches[0].Item.ID() != "b1" {
+		t.Fatalf("expected the URL match b1 only, got %d matches", len(matches))
+	}
+}
+
+func TestApp_History_ListsVisitedNewestFirst(t *testing.T) {
+	earlier, later := time.Now().Add(-time.Hour), time.Now().Add(-time.Minute)
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Earlier", URL: "https://earlier.dev", VisitedAt: &earlier},
+			{ID: "b2", Title: "Never", URL: "https://never.dev"},
+			{ID: "b3", Title: "Later", URL: "https://later.dev", VisitedAt: &later},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	app = pressKey(app, 'H')
+	if app.Mode() != tui.ModeSearch {
+		t.Fatalf("expected the finder, got mode %v", app.Mode())
+	}
+	var ids []string
+	for _, m := range app.FuzzyMatches() {
+		ids = append(ids, m.Item.ID())
+	}
+	if want := []string{"b3", "b1"}; !slices.Equal(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+}
+
+func TestApp_FuzzyFinder_NumberKeySelectsResult(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "Alpha", URL: "https://alpha.example"},
+			{ID: "b2", Title: "Beta", URL: "https://beta.example"},
+			{ID: "b3", Title: "Gamma", URL: "https://gamma.example"},
+			{ID: "b4", Title: "Delta", URL: "https://delta.example"},
+		},
+	}
+	cfg := storage.DefaultConfig()
+	cfg.FinderNumberKeys = true
+	app := tui.NewApp(tui.AppParams{Store: store, Config: &cfg})
+
+	app = pressKey(app, 'f')
+	matches := app.FuzzyMatches()
+	if len(matches) < 3 {
+		t.Fatalf("expected at least 3 results, got %d", len(matches))
+	}
+	want := matches[2].Item.ID()
+
+	app = pressKey(app, '3')
+	if app.Mode() != tui.ModeNormal {
+		t.Fatalf("expected the finder to close, got mode %v", app.Mode())
+	}
+	items := app.Items()
+	if app.Cursor() >= len(items) || items[app.Cursor()].ID() != want {
+		t.Errorf("expected the third result %s to be selected", want)
+	}
+}
+
+func TestApp_Organize_CollectsDirectChildrenUnlessRecursive(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		recursive bool
+		want      string
+	}{
+		{name: "direct children", want: "Analyzing 2 items"},
+		{name: "recursive", recursive: true, want: "Analyzing 4 items"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			devID, subID := "dev", "sub"
+			store := &model.Store{
+				Folders: []model.Folder{
+					{ID: devID, Name: "Dev"},
+					{ID: subID, Name: "Sub", ParentID: &devID},
+				},
+				Bookmarks: []model.Bookmark{
+					{ID: "b1", Title: "Go", URL: "https://go.dev", FolderID: &devID},
+					{ID: "b2", Title: "Rust", URL: "https://rust-lang.org", FolderID: &subID},
+					{ID: "b3", Title: "Zig", URL: "https://ziglang.org", FolderID: &subID},
+				},
+			}
+			cfg := storage.DefaultConfig()
+			cfg.OrganizeRecursive = tc.recursive
+			app := tui.NewApp(tui.AppParams{
+				Store:    store,
+				Config:   &cfg,
+				AIClient: func() (*ai.Client, error) { return &ai.Client{}, nil },
+			}).WithDimensions(120, 30)
+
+			// Cursor starts on Dev; the analysis command is never run
+			updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+			app = *updated.(*tui.App)
+			if app.Mode() != tui.ModeOrganizeLoading {
+				t.Fatalf("expected organize to start, got mode %v", app.Mode())
+			}
+			if view := app.View(); !strings.Contains(view, tc.want) {
+				t.Errorf("expected %q in the loading view", tc.want)
+			}
+		})
+	}
+}
+
+// organizeCacheStorage serves a fixed organize cache.
+type organizeCacheStorage struct {
+	countingStorage
+	organize *storage.OrganizeCache
+}
+
+func (c *organizeCacheStorage) SaveCullCache(cache *storage.CullCache) error { return nil }
+
+func (c *organizeCacheStorage) LoadCullCache() (*storage.CullCache, error) {
+	return nil, storage.ErrNoCache
+}
+
+func (c *organizeCacheStorage) SaveOrganizeCache(cache *storage.OrganizeCache) error { return nil }
+
+func (c *organizeCacheStorage) LoadOrganizeCache() (*storage.OrganizeCache, error) {
+	return c.organize, nil
+}
+
+func TestApp_Organize_PreviewBeforeApplying(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		skipConfirm bool
+	}{
+		{name: "preview first"},
+		{name: "skipOrganizeConfirm", skipConfirm: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &model.Store{
+				Bookmarks: []model.Bookmark{
+					{ID: "b1", Title: "Go", URL: "https://go.dev", Tags: []string{"misc"}},
+				},
+			}
+			st := &organizeCacheStorage{organize: &storage.OrganizeCache{
+				Timestamp: time.Now(),
+				Results: []storage.OrganizeCacheResult{{
+					ItemID:        "b1",
+					CurrentPath:   "/",
+					SuggestedPath: "/Dev/Go",
+					IsNewFolder:   true,
+					CurrentTags:   []string{"misc"},
+					SuggestedTags: []string{"go", "lang"},
+				}},
+			}}
+			cfg := storage.DefaultConfig()
+			cfg.SkipOrganizeConfirm = tc.skipConfirm
+			app := tui.NewApp(tui.AppParams{
+				Store:    store,
+				Storage:  st,
+				Config:   &cfg,
+				AIClient: func() (*ai.Client, error) { return &ai.Client{}, nil },
+			}).WithDimensions(120, 30)
+
+			press := func(msg tea.KeyMsg) {
+				switch updated, _ := app.Update(msg); m := updated.(type) {
+				case tui.App:
+					app = m
+				case *tui.App:
+					app = *m
+				}
+			}
+			enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+			// O with a cache offers it; pick the cached results
+			press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+			press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+			press(enter)
+			if app.Mode() != tui.ModeOrganizeResults {
+				t.Fatalf("expected organize results, got mode %v", app.Mode())
+			}
+
+			moved := func() bool { return store.GetBookmarkByID("b1").FolderID != nil }
+
+			press(enter)
+			if tc.skipConfirm {
+				if !moved() || !slices.Equal(store.GetBookmarkByID("b1").Tags, []string{"go", "lang"}) {
+					t.Fatalf("expected the first Enter to apply, got %+v", store.GetBookmarkByID("b1"))
+				}
+				return
+			}
+
+			// The first Enter only previews the change
+			if moved() {
+				t.Fatal("expected the first Enter not to move the bookmark")
+			}
+			view := app.View()
+			for _, want := range []string{"To:   /Dev/Go", "Creates: /Dev, /Dev/Go", "+ go, lang", "- misc"} {
+				if !strings.Contains(view, want) {
+					t.Errorf("expected %q in the preview", want)
+				}
+			}
+
+			// Esc closes the preview without applying
+			press(tea.KeyMsg{Type: tea.KeyEsc})
+			if moved() || app.Mode() != tui.ModeOrganizeResults {
+				t.Fatalf("expected Esc to cancel the preview, got mode %v", app.Mode())
+			}
+			if strings.Contains(app.View(), "To:   /Dev/Go") {
+				t.Error("expected the preview to close on Esc")
+			}
+
+			// Enter previews again, and a second Enter applies move and tags
+			press(enter)
+			press(enter)
+			b := store.GetBookmarkByID("b1")
+			if !moved() || store.GetFolderPath(b.FolderID) != "/Dev/Go" {
+				t.Errorf("expected the bookmark moved to /Dev/Go, got %+v", b)
+			}
+			if !slices.Equal(b.Tags, []string{"go", "lang"}) {
+				t.Errorf("expected tags [go lang], got %q", b.Tags)
+			}
+		})
+	}
+}
+
+func TestApp_Mark_SwapsItemsInManualOrder(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "a", Title: "A", URL: "https://a.com"},
+			{ID: "b", Title: "B", URL: "https://b.com"},
+			{ID: "c", Title: "C", URL: "https://c.com"},
+			{ID: "d", Title: "D", URL: "https://d.com"},
+		},
+	}
+	st := &countingStorage{}
+	app := tui.NewApp(tui.AppParams{Store: store, Storage: st})
+
+	app = pressKey(app, '`')
+	app = pressKey(app, 'a')
+	if !strings.Contains(app.StatusMessage(), "Marked A") {
+		t.Fatalf("expected A to be marked, got %q", app.StatusMessage())
+	}
+
+	app = pressKey(app, 'j')
+	app = pressKey(app, 'j')
+	app = pressKey(app, '`')
+	app = pressKey(app, 'a')
+
+	var order []string
+	for _, item := range app.Items() {
+		order = append(order, item.ID())
+	}
+	if got := strings.Join(order, ","); got != "c,b,a,d" {
+		t.Errorf("expected c,b,a,d after the swap, got %s", got)
+	}
+	if !strings.Contains(app.StatusMessage(), "Swapped C and A") {
+		t.Errorf("expected the swap to be reported, got %q", app.StatusMessage())
+	}
+	if st.saves != 1 {
+		t.Errorf("expected the swap to be saved once, got %d saves", st.saves)
+	}
+}
+
+func TestApp_Peek_ShowsPageMetaAndAdoptsTitle(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{{ID: "b1", Title: "go.dev", URL: "https://go.dev"}},
+	}
+	fetch := func(_ context.Context, url string) (fetcher.Meta, error) {
+		return fetcher.Meta{Title: "The Go Programming Language", Description: "Build simple, secure software."}, nil
+	}
+	app := tui.NewApp(tui.AppParams{Store: store, FetchPage: fetch}).WithDimensions(120, 30)
+
+	app = pressKey(app, 'g')
+	updated, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	app = updated.(tui.App)
+	if app.Mode() != tui.ModePeek {
+		t.Fatalf("expected the peek to open, got mode %v", app.Mode())
+	}
+	if !strings.Contains(app.View(), "Fetching page...") {
+		t.Error("expected a loading state while the page is fetched")
+	}
+	for _, msg := range runCmds(cmd) {
+		updated, _ = app.Update(msg)
+		app = updated.(tui.App)
+	}
+	if view := app.View(); !strings.Contains(view, "Build simple, secure software.") {
+		t.Errorf("expected the page description, got:\n%s", view)
+	}
+
+	app = pressKey(app, 't')
+	if app.Mode() != tui.ModeNormal {
+		t.Errorf("expected t to close the peek, got mode %v", app.Mode())
+	}
+	if got := store.Bookmarks[0].Title; got != "The Go Programming Language" {
+		t.Errorf("expected the page title to be adopted, got %q", got)
+	}
+}
+
+func TestApp_Repeat_DeletesNextItem(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "b1", Title: "One", URL: "https://1.com"},
+			{ID: "b2", Title: "Two", URL: "https://2.com"},
+			{ID: "b3", Title: "Three", URL: "https://3.com"},
+		},
+	}
+	app := tui.NewApp(tui.AppParams{Store: store})
+
+	app = pressKey(app, '.')
+	if len(store.Bookmarks) != 3 {
+		t.Fatal("expected . to do nothing before any change")
+	}
+
+	app = pressKey(app, 'd')
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+
+	// . deletes again, with the same confirmation
+	app = pressKey(app, '.')
+	if app.Mode() != tui.ModeConfirmDelete {
+		t.Fatalf("expected . to ask for confirmation, got mode %v", app.Mode())
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = updated.(tui.App)
+
+	if len(store.Bookmarks) != 1 || store.Bookmarks[0].ID != "b3" {
+		t.Errorf("expected only b3 to remain, got %+v", store.Bookmarks)
+	}
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"runtime"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Clipboard reads and writes the system clipboard.
+// Tests inject a fake to simulate headless or SSH sessions.
+type Clipboard interface {
+	ReadAll() (string, error)
+	WriteAll(text string) error
+}
+
+// systemClipboard is the default Clipboard backed by the OS clipboard.
+type systemClipboard struct{}
+
+func (systemClipboard) ReadAll() (string, error)   { return clipboard.ReadAll() }
+func (systemClipboard) WriteAll(text string) error { return clipboard.WriteAll(text) }
+
+// systemClipboardAvailable reports whether the OS clipboard can plausibly be
+// reached. On Linux/BSD this requires a display server; over plain SSH or in
+// a headless container there is none, so every access would fail.
+func systemClipboardAvailable() bool {
+	if clipboard.Unsupported {
+		return false
+	}
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return true
+	}
+	return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+}
+
+// errClipboardUnavailable is reported for writes once the clipboard is known
+// to be unavailable.
+var errClipboardUnavailable = errors.New("clipboard unavailable")
+
+// clipboardUnavailableMsg is shown once when the clipboard can't be used.
+const clipboardUnavailableMsg = "Clipboard unavailable - paste URLs manually"
+
+// readClipboard returns the clipboard contents, or "" if the clipboard is
+// unavailable. The first failure marks the clipboard unavailable for the
+// rest of the session so later actions skip it silently.
+func (a *App) readClipboard() string {
+	if !a.clipboardOK {
+		return ""
+	}
+	content, err := a.clipboard.ReadAll()
+	if err != nil {
+		a.clipboardOK = false
+		return ""
+	}
+	return content
+}
+
+// writeClipboardCmd copies text to the clipboard. When the clipboard is
+// unavailable the text is shown in the status bar instead so it can still be
+// copied from the terminal.
+func (a *App) writeClipboardCmd(text, label string) tea.Cmd {
+	if !a.clipboardOK {
+		return func() tea.Msg {
+			return clipboardErrorMsg{err: errClipboardUnavailable, text: text, label: label}
+		}
+	}
+	clip := a.clipboard
+	return func() tea.Msg {
+		if err := clip.WriteAll(text); err != nil {
+			return clipboardErrorMsg{err: err, text: text, label: label}
+		}
+		return clipboardSuccessMsg{label: label}
+	}
+}
+
+// noteClipboardUnavailable returns a one-time message explaining that the
+// clipboard is unavailable, or nil if it was already shown.
+func (a *App) noteClipboardUnavailable() tea.Cmd {
+	if a.clipboardOK || a.clipboardWarned {
+		return nil
+	}
+	a.clipboardWarned = true
+	return a.setMessage(MessageInfo, clipboardUnavailableMsg)
+}
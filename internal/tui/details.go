@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/tui/layout"
+)
+
+// startDetails shows everything about b in a modal (gI), including the
+// title and URL the preview pane has to cut short.
+func (a App) startDetails(b *model.Bookmark) (tea.Model, tea.Cmd) {
+	a.detailsID = b.ID
+	a.mode = ModeDetails
+	return a, nil
+}
+
+// updateDetails handles keys in the details modal: e edits the bookmark,
+// Enter/o opens it, y yanks its URL, Esc/q closes.
+func (a App) updateDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	b := a.store.GetBookmarkByID(a.detailsID)
+	if b == nil {
+		a.mode = ModeNormal
+		return a, nil
+	}
+	switch msg.String() {
+	case "e":
+		return a.startEditBookmark(*b)
+	case "enter", "o":
+		a.mode = ModeNormal
+		return a.openBookmarkURL(*b)
+	case "y":
+		a.mode = ModeNormal
+		return a, a.writeClipboardCmd(b.URL, "URL")
+	case "esc", "q":
+		a.mode = ModeNormal
+	}
+	return a, nil
+}
+
+// startEditBookmark opens the edit modal for b with its title focused.
+func (a App) startEditBookmark(b model.Bookmark) (tea.Model, tea.Cmd) {
+	a.mode = ModeEditBookmark
+	a.modal.EditItemID = b.ID
+	a.modal.TitleInput.Reset()
+	a.modal.TitleInput.SetValue(b.Title)
+	a.modal.URLInput.Reset()
+	a.modal.URLInput.SetValue(b.URL)
+	a.modal.TagsInput.Reset()
+	a.modal.TagsInput.SetValue(strings.Join(b.Tags, ", "))
+	a.collectAllTags()
+	a.modal.TagSuggestions = nil
+	a.modal.TagSuggestionIdx = -1
+	a.modal.URLInput.Blur()
+	a.modal.TagsInput.Blur()
+	a.modal.TitleInput.Focus()
+	return a, a.modal.TitleInput.Focus()
+}
+
+// renderDetailsContent renders every field of the bookmark in the details
+// modal, wrapping long values instead of truncating them.
+func (a App) renderDetailsContent() string {
+	b := a.store.GetBookmarkByID(a.detailsID)
+	if b == nil {
+		return a.styles.Empty.Render("Bookmark no longer exists")
+	}
+	width := layout.CalculateModalWidth(a.width, a.layoutConfig.Modal.DefaultWidthPercent, a.layoutConfig.Modal) - 4
+
+	var s strings.Builder
+	field := func(label string, value string, style func(...string) string) {
+		s.WriteString(a.styles.Title.Render(label) + "\n")
+		if value == "" {
+			s.WriteString(a.styles.Empty.Render("(none)") + "\n\n")
+			return
+		}
+		for _, line := range layout.WrapText(value, width) {
+			s.WriteString(style(line) + "\n")
+		}
+		s.WriteString("\n")
+	}
+	plain := func(strs ...string) string { return strings.Join(strs, " ") }
+
+	field("Title", b.Title, plain)
+	field("URL", b.URL, a.styles.URL.Render)
+	field("Folder", a.store.GetFolderPath(b.FolderID), plain)
+	tags := make([]string, len(b.Tags))
+	for i, tag := range b.Tags {
+		tags[i] = "#" + tag
+	}
+	field("Tags", strings.Join(tags, " "), a.styles.Tag.Render)
+
+	dates := []string{"Created: " + a.config.FormatDate(b.CreatedAt)}
+	if !b.ModifiedAt.IsZero() {
+		dates = append(dates, "Modified: "+a.config.FormatDate(b.ModifiedAt))
+	}
+	if b.VisitedAt != nil {
+		dates = append(dates, "Visited: "+a.config.FormatDate(*b.VisitedAt)+" ("+strconv.Itoa(b.VisitCount)+" visits)")
+	} else {
+		dates = append(dates, "Never visited")
+	}
+	field("History", strings.Join(dates, "\n"), a.styles.Date.Render)
+
+	var state []string
+	if b.Pinned {
+		state = append(state, "Pinned ("+strconv.Itoa(b.PinOrder)+")")
+	}
+	if b.Archived {
+		state = append(state, "Archived")
+	}
+	if b.Priority > model.PriorityNone {
+		state = append(state, "Priority: "+model.PriorityName(b.Priority))
+	}
+	if b.SnoozeUntil != nil {
+		state = append(state, "Snoozed until "+a.config.FormatDate(*b.SnoozeUntil))
+	}
+	if b.RemindAt != nil {
+		state = append(state, "Reminder: "+a.config.FormatDate(*b.RemindAt))
+	}
+	if aliases := a.aliasesFor(*b); len(aliases) > 0 {
+		state = append(state, "Alias: "+strings.Join(aliases, ", "))
+	}
+	field("State", strings.Join(state, "\n"), plain)
+
+	s.WriteString(a.renderHintsInline([]Hint{
+		{Key: "e", Desc: "edit"},
+		{Key: "Enter", Desc: "open"},
+		{Key: "y", Desc: "yank URL"},
+		{Key: "Esc", Desc: "close"},
+	}))
+	return s.String()
+}
+
+// aliasesFor returns the sorted config aliases that point at b, by ID or URL.
+func (a App) aliasesFor(b model.Bookmark) []string {
+	var names []string
+	for name, target := range a.config.Aliases {
+		if target == b.ID || target == b.URL {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// domainsVisible is how many rows the domains modal lists at once.
+const domainsVisible = 15
+
+// openDomains opens the domains view: hosts by bookmark count.
+func (a *App) openDomains() tea.Cmd {
+	if len(a.store.GroupByDomain(a.config.MergeSubdomains)) == 0 {
+		return a.setMessage(MessageInfo, "No bookmarks")
+	}
+	a.domains = DomainsState{}
+	a.mode = ModeDomains
+	return nil
+}
+
+// domainGroups returns the groups listed in the domains view.
+func (a App) domainGroups() []model.DomainGroup {
+	return a.store.GroupByDomain(a.config.MergeSubdomains)
+}
+
+// selectedDomainBookmarks returns the bookmarks of the domain drilled into,
+// or nil while picking a domain.
+func (a App) selectedDomainBookmarks() []*model.Bookmark {
+	if a.domains.Domain == "" {
+		return nil
+	}
+	for _, g := range a.domainGroups() {
+		if g.Domain == a.domains.Domain {
+			return g.Bookmarks
+		}
+	}
+	return nil
+}
+
+// updateDomains handles keys in the domains view: Enter drills into a host,
+// then reveals the chosen bookmark in the browser. o opens it. Esc steps
+// back, then closes.
+func (a App) updateDomains(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	count := len(a.domainGroups())
+	cursor := &a.domains.DomainCursor
+	bookmarks := a.selectedDomainBookmarks()
+	if a.domains.Domain != "" {
+		count = len(bookmarks)
+		cursor = &a.domains.Cursor
+	}
+	if *cursor >= count {
+		*cursor = max(0, count-1)
+	}
+
+	switch msg.String() {
+	case "esc", "q", "h", "left":
+		if a.domains.Domain != "" {
+			a.domains.Domain = ""
+			a.domains.Cursor = 0
+			return a, nil
+		}
+		a.mode = ModeNormal
+		return a, nil
+	case "j", "down":
+		if *cursor < count-1 {
+			*cursor++
+		}
+		return a, nil
+	case "k", "up":
+		if *cursor > 0 {
+			*cursor--
+		}
+		return a, nil
+	case "enter", "l":
+		if count == 0 {
+			return a, nil
+		}
+		if a.domains.Domain == "" {
+			a.domains.Domain = a.domainGroups()[a.domains.DomainCursor].Domain
+			a.domains.Cursor = 0
+			return a, nil
+		}
+		a.mode = ModeNormal
+		a.focusedPane = PaneBrowser
+		a.revealBookmark(bookmarks[a.domains.Cursor])
+		return a, nil
+	case "o":
+		if a.domains.Domain == "" || count == 0 {
+			return a, nil
+		}
+		b := bookmarks[a.domains.Cursor]
+		b.MarkVisited(time.Now())
+		a.saveStore()
+		return a, openURLCmd(b.URL)
+	}
+	return a, nil
+}
+
+// renderDomainsContent renders the host list, or the bookmarks of the chosen
+// host with their folders, scrolled so the cursor stays visible.
+func (a App) renderDomainsContent() string {
+	var lines []string
+	cursor := a.domains.DomainCursor
+	if a.domains.Domain == "" {
+		for _, g := range a.domainGroups() {
+			lines = append(lines, g.Domain+"  "+a.styles.Empty.Render(strconv.Itoa(len(g.Bookmarks))))
+		}
+	} else {
+		cursor = a.domains.Cursor
+		for _, b := range a.selectedDomainBookmarks() {
+			lines = append(lines, b.Title+"  "+a.styles.Empty.Render(a.store.GetFolderPath(b.FolderID)))
+		}
+	}
+
+	start := max(0, cursor-domainsVisible+1)
+	end := min(len(lines), start+domainsVisible)
+
+	var b strings.Builder
+	for i := start; i < end; i++ {
+		if i == cursor {
+			b.WriteString(a.styles.ItemSelected.Render("▸ ") + lines[i] + "\n")
+		} else {
+			b.WriteString("  " + lines[i] + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if a.domains.Domain == "" {
+		b.WriteString(a.renderHintsInline([]Hint{
+			{Key: "Enter", Desc: "show bookmarks"},
+			{Key: "Esc", Desc: "close"},
+		}))
+	} else {
+		b.WriteString(a.renderHintsInline([]Hint{
+			{Key: "Enter", Desc: "go to"},
+			{Key: "o", Desc: "open"},
+			{Key: "Esc", Desc: "back"},
+		}))
+	}
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// errNoEditor is returned when neither $VISUAL nor $EDITOR is set.
+var errNoEditor = errors.New("set $EDITOR to edit in an external editor")
+
+// editorField identifies which modal input an external edit belongs to.
+type editorField int
+
+const (
+	editorFieldTitle editorField = iota
+	editorFieldURL
+	editorFieldTags
+)
+
+// editorFinishedMsg is sent when the external editor exits.
+type editorFinishedMsg struct {
+	field   editorField
+	content string
+	err     error
+}
+
+// editorCommand returns the user's editor command split into name and args.
+func editorCommand() ([]string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	parts := strings.Fields(editor)
+	if len(parts) == 0 {
+		return nil, errNoEditor
+	}
+	return parts, nil
+}
+
+// focusedEditorField returns the focused modal input and its field id.
+// Returns nil if no editable input is focused.
+func (a *App) focusedEditorField() (*textinput.Model, editorField) {
+	switch {
+	case a.modal.TitleInput.Focused():
+		return &a.modal.TitleInput, editorFieldTitle
+	case a.modal.URLInput.Focused():
+		return &a.modal.URLInput, editorFieldURL
+	case a.modal.TagsInput.Focused():
+		return &a.modal.TagsInput, editorFieldTags
+	}
+	return nil, editorFieldTitle
+}
+
+// editInEditor suspends the TUI and opens the focused modal field in $EDITOR.
+// Tags are written one per line so bulk edits are easy.
+func (a *App) editInEditor() tea.Cmd {
+	input, field := a.focusedEditorField()
+	if input == nil {
+		return nil
+	}
+
+	editor, err := editorCommand()
+	if err != nil {
+		return a.setMessage(MessageError, err.Error())
+	}
+
+	content := input.Value()
+	if field == editorFieldTags {
+		content = strings.Join(model.ParseTags(content), "\n")
+	}
+
+	file, err := os.CreateTemp("", "bm-*.txt")
+	if err != nil {
+		return a.setMessage(MessageError, "Failed to create temp file: "+err.Error())
+	}
+	path := file.Name()
+	_, writeErr := file.WriteString(content + "\n")
+	closeErr := file.Close()
+	if writeErr != nil || closeErr != nil {
+		_ = os.Remove(path)
+		return a.setMessage(MessageError, "Failed to write temp file")
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer func() { _ = os.Remove(path) }()
+		if err != nil {
+			return editorFinishedMsg{field: field, err: err}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return editorFinishedMsg{field: field, err: err}
+		}
+		return editorFinishedMsg{field: field, content: string(data)}
+	})
+}
+
+// applyEditorResult writes edited content back into the modal input it came from.
+func (a *App) applyEditorResult(msg editorFinishedMsg) tea.Cmd {
+	if msg.err != nil {
+		return a.setMessage(MessageError, "Editor failed: "+msg.err.Error())
+	}
+
+	var input *textinput.Model
+	var value string
+	switch msg.field {
+	case editorFieldTitle:
+		input = &a.modal.TitleInput
+		value = strings.Join(strings.Fields(msg.content), " ")
+	case editorFieldURL:
+		input = &a.modal.URLInput
+		value = strings.TrimSpace(msg.content)
+	case editorFieldTags:
+		input = &a.modal.TagsInput
+		value = strings.Join(model.ParseTags(strings.ReplaceAll(msg.content, "\n", ",")), ", ")
+	}
+
+	input.SetValue(value)
+	input.CursorEnd()
+	return nil
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// openAddModal returns an app in the add bookmark modal with "Go" typed into
+// the focused title field.
+func openAddModal(t *testing.T) App {
+	t.Helper()
+	app := NewApp(AppParams{Store: &model.Store{}})
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Go")})
+	app = updated.(App)
+	if app.Mode() != ModeAddBookmark || app.modal.TitleInput.Value() != "Go" {
+		t.Fatalf("expected the add modal with title %q, got mode %v title %q", "Go", app.Mode(), app.modal.TitleInput.Value())
+	}
+	return app
+}
+
+func TestEditInEditor_NoEditorKeepsModalOpen(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	app := openAddModal(t)
+
+	updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	app = updated.(App)
+
+	if app.Mode() != ModeAddBookmark {
+		t.Errorf("expected the modal to stay open, got mode %v", app.Mode())
+	}
+	if !strings.Contains(app.StatusMessage(), "$EDITOR") {
+		t.Errorf("expected a hint to set $EDITOR, got %q", app.StatusMessage())
+	}
+	if app.modal.TitleInput.Value() != "Go" {
+		t.Errorf("expected the title to be kept, got %q", app.modal.TitleInput.Value())
+	}
+}
+
+func TestApplyEditorResult_ErrorLeavesFieldUnchanged(t *testing.T) {
+	app := openAddModal(t)
+
+	updated, _ := app.Update(editorFinishedMsg{field: editorFieldTitle, content: "Rust", err: errors.New("exit status 1")})
+	app = updated.(App)
+
+	if app.modal.TitleInput.Value() != "Go" {
+		t.Errorf("expected the title to be kept after a failed edit, got %q", app.modal.TitleInput.Value())
+	}
+	if app.Mode() != ModeAddBookmark {
+		t.Errorf("expected the modal to stay open, got mode %v", app.Mode())
+	}
+	if !strings.Contains(app.StatusMessage(), "Editor failed") {
+		t.Errorf("expected an editor error, got %q", app.StatusMessage())
+	}
+}
+
+func TestApplyEditorResult_TagsOnePerLine(t *testing.T) {
+	app := openAddModal(t)
+
+	updated, _ := app.Update(editorFinishedMsg{field: editorFieldTags, content: "go\n\n cli \n"})
+	app = updated.(App)
+
+	if got := app.modal.TagsInput.Value(); got != "go, cli" {
+		t.Errorf("expected tags %q, got %q", "go, cli", got)
+	}
+}
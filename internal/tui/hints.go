@@ -3,6 +3,8 @@ package tui
 import (
 	"strconv"
 	"strings"
+
+	"github.com/nikbrunner/bm/internal/tui/layout"
 )
 
 // Hint represents a single keybind hint for display.
@@ -13,7 +15,11 @@ type Hint struct {
 
 // renderHint renders a single hint as "key:desc" with styling.
 func (a App) renderHint(h Hint) string {
-	return a.styles.HintKey.Render(h.Key) + ":" + a.styles.HintDesc.Render(h.Desc)
+	desc := h.Desc
+	if a.helpBarLayout().Abbreviate {
+		desc = layout.AbbreviateLabel(desc, a.layoutConfig.Help.AbbreviatedDescLength)
+	}
+	return a.styles.HintKey.Render(h.Key) + ":" + a.styles.HintDesc.Render(desc)
 }
 
 // renderHints renders hints in horizontal format for bottom bar: "j/k:move h:back l:open"
@@ -89,12 +95,23 @@ func (a App) getContextualHints() HintSet {
 		return a.getBookmarkFormHints()
 	case ModeAddFolder, ModeEditFolder:
 		return a.getFolderFormHints()
-	case ModeConfirmDelete:
+	case ModeConfirmDelete, ModeConfirmLargeOp, ModeConfirmPruneEmpty:
+		// All confirmations show their hints inside the modal
 		return a.getConfirmDeleteHints()
 	case ModeMove:
 		return a.getMoveHints()
 	case ModeQuickAdd:
 		return a.getQuickAddHints()
+	case ModeSnooze:
+		return a.getSnoozeHints()
+	case ModeRemind:
+		return a.getRemindHints()
+	case ModeGotoIndex:
+		return a.getGotoIndexHints()
+	case ModeAlias:
+		return a.getAliasHints()
+	case ModePromote:
+		return a.getPromoteHints()
 	case ModeQuickAddLoading:
 		return a.getQuickAddLoadingHints()
 	case ModeQuickAddConfirm:
@@ -110,6 +127,16 @@ func (a App) getContextualHints() HintSet {
 		}
 	case ModeCullMenu:
 		return a.getCullMenuHints()
+	case ModeBulkMenu:
+		// Same shape as the cull menu: navigate, select, cancel
+		return a.getCullMenuHints()
+	case ModeMergeTags, ModePickTemplate, ModeDomains, ModeVerifyOpen, ModeOnboarding, ModePeek, ModeDetails:
+		// Hints are shown inside the modal
+		return HintSet{}
+	case ModeBulkTag:
+		return a.getBulkTagHints()
+	case ModeBulkExport:
+		return a.getBulkExportHints()
 	case ModeCullLoading:
 		return a.getCullLoadingHints()
 	case ModeCullResults:
@@ -159,6 +186,7 @@ func (a App) getNormalModeHints() HintSet {
 			{Key: "v", Desc: "±sel"},
 			{Key: "Esc", Desc: "clear"},
 			{Key: "d", Desc: "del " + strconv.Itoa(count)},
+			{Key: ":", Desc: "bulk"},
 		}
 	}
 
@@ -191,6 +219,13 @@ func (a App) getSearchModeHints() HintSet {
 	if len(a.search.ParsedTags) > 0 {
 		navHints = append(navHints, Hint{Key: "^t", Desc: "any/all"})
 	}
+	if a.search.Source == SourceAll {
+		if a.search.IncludeFolders {
+			navHints = append(navHints, Hint{Key: "^f", Desc: "hide folders"})
+		} else {
+			navHints = append(navHints, Hint{Key: "^f", Desc: "show folders"})
+		}
+	}
 
 	return HintSet{
 		Nav: navHints,
@@ -215,6 +250,7 @@ func (a App) getBookmarkFormHints() HintSet {
 		},
 		Action: []Hint{
 			{Key: "Enter", Desc: "save"},
+			{Key: "^o", Desc: "$EDITOR"},
 		},
 		System: []Hint{
 			{Key: "Esc", Desc: "cancel"},
@@ -233,6 +269,7 @@ func (a App) getFolderFormHints() HintSet {
 	return HintSet{
 		Action: []Hint{
 			{Key: "Enter", Desc: "save"},
+			{Key: "^o", Desc: "$EDITOR"},
 		},
 		System: []Hint{
 			{Key: "Esc", Desc: "cancel"},
@@ -264,9 +301,98 @@ func (a App) getMoveHints() HintSet {
 
 // getQuickAddHints returns hints for ModeQuickAdd (URL input).
 func (a App) getQuickAddHints() HintSet {
+	enter := "analyze"
+	if a.quickAdd.Template != "" {
+		enter = "continue"
+	}
+	return HintSet{
+		Action: []Hint{
+			{Key: "Enter", Desc: enter},
+		},
+		System: []Hint{
+			{Key: "Esc", Desc: "cancel"},
+		},
+	}
+}
+
+// getSnoozeHints returns hints for ModeSnooze (duration input).
+func (a App) getSnoozeHints() HintSet {
+	return HintSet{
+		Action: []Hint{
+			{Key: "Enter", Desc: "snooze"},
+		},
+		System: []Hint{
+			{Key: "Esc", Desc: "cancel"},
+		},
+	}
+}
+
+// getRemindHints returns hints for ModeRemind (reminder duration input).
+func (a App) getRemindHints() HintSet {
+	return HintSet{
+		Action: []Hint{
+			{Key: "Enter", Desc: "set reminder"},
+		},
+		System: []Hint{
+			{Key: "Esc", Desc: "cancel"},
+		},
+	}
+}
+
+// getGotoIndexHints returns hints for ModeGotoIndex (item number input).
+func (a App) getGotoIndexHints() HintSet {
+	return HintSet{
+		Action: []Hint{
+			{Key: "Enter", Desc: "go to item"},
+		},
+		System: []Hint{
+			{Key: "Esc", Desc: "cancel"},
+		},
+	}
+}
+
+// getBulkTagHints returns hints for ModeBulkTag (tag input for the selection).
+func (a App) getBulkTagHints() HintSet {
 	return HintSet{
 		Action: []Hint{
-			{Key: "Enter", Desc: "analyze"},
+			{Key: "Enter", Desc: "add tags"},
+		},
+		System: []Hint{
+			{Key: "Esc", Desc: "cancel"},
+		},
+	}
+}
+
+// getBulkExportHints returns hints for ModeBulkExport (path for the selection export).
+func (a App) getBulkExportHints() HintSet {
+	return HintSet{
+		Action: []Hint{
+			{Key: "Enter", Desc: "export"},
+		},
+		System: []Hint{
+			{Key: "Esc", Desc: "cancel"},
+		},
+	}
+}
+
+// getAliasHints returns hints for ModeAlias (alias name input).
+func (a App) getAliasHints() HintSet {
+	return HintSet{
+		Action: []Hint{
+			{Key: "Enter", Desc: "save"},
+		},
+		System: []Hint{
+			{Key: "Esc", Desc: "cancel"},
+		},
+	}
+}
+
+// getPromoteHints returns hints for ModePromote (folder name input).
+func (a App) getPromoteHints() HintSet {
+	return HintSet{
+		Action: []Hint{
+			{Key: "Enter", Desc: "promote"},
+			{Key: "Tab", Desc: "toggle related"},
 		},
 		System: []Hint{
 			{Key: "Esc", Desc: "cancel"},
@@ -293,6 +419,7 @@ func (a App) getQuickAddConfirmHints() HintSet {
 		},
 		Action: []Hint{
 			{Key: "Enter", Desc: "save"},
+			{Key: "^r", Desc: "regenerate"},
 		},
 		System: []Hint{
 			{Key: "Esc", Desc: "cancel"},
@@ -357,6 +484,7 @@ func (a App) getCullResultsHints() HintSet {
 		Action: []Hint{
 			{Key: "Enter", Desc: "inspect"},
 			{Key: "d", Desc: "del all"},
+			{Key: "M", Desc: "move all to " + a.config.BrokenFolder},
 		},
 		System: []Hint{
 			{Key: "Esc", Desc: "back"},
@@ -375,6 +503,7 @@ func (a App) getCullInspectHints() HintSet {
 			{Key: "o", Desc: "open"},
 			{Key: "e", Desc: "edit"},
 			{Key: "m", Desc: "move"},
+			{Key: "M", Desc: "move all to " + a.config.BrokenFolder},
 		},
 		System: []Hint{
 			{Key: "Esc", Desc: "back"},
@@ -408,6 +537,16 @@ func (a App) getOrganizeLoadingHints() HintSet {
 
 // getOrganizeResultsHints returns hints for ModeOrganizeResults.
 func (a App) getOrganizeResultsHints() HintSet {
+	if a.organize.Confirming {
+		return HintSet{
+			Action: []Hint{
+				{Key: "Enter", Desc: "apply"},
+			},
+			System: []Hint{
+				{Key: "Esc", Desc: "back"},
+			},
+		}
+	}
 	return HintSet{
 		Nav: []Hint{
 			{Key: "j/k", Desc: "move"},
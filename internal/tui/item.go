@@ -33,7 +33,27 @@ func (i Item) Title() string {
 	return i.Bookmark.Title
 }
 
+// SearchText returns what search matches the item against: a folder's name,
+// or a bookmark's title, URL and tags (see model.Bookmark.SearchText).
+func (i Item) SearchText() string {
+	if i.Kind == ItemFolder {
+		return i.Folder.Name
+	}
+	return i.Bookmark.SearchText()
+}
+
 // IsFolder returns true if this item is a folder.
 func (i Item) IsFolder() bool {
 	return i.Kind == ItemFolder
 }
+
+// detachItem returns a copy of the item that no longer points into the store,
+// so it stays valid after the original is removed.
+func detachItem(i Item) Item {
+	if i.Kind == ItemFolder {
+		f := *i.Folder
+		return Item{Kind: ItemFolder, Folder: &f}
+	}
+	b := *i.Bookmark
+	return Item{Kind: ItemBookmark, Bookmark: &b}
+}
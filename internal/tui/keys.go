@@ -4,37 +4,79 @@ import "github.com/charmbracelet/bubbles/key"
 
 // KeyMap defines all key bindings for the application.
 type KeyMap struct {
-	Up           key.Binding
-	Down         key.Binding
-	Left         key.Binding
-	Right        key.Binding
-	Top          key.Binding
-	Bottom       key.Binding
-	Yank         key.Binding
-	Delete       key.Binding
-	Cut          key.Binding
-	PasteAfter   key.Binding
-	PasteBefore  key.Binding
-	AddBookmark  key.Binding
-	AddFolder    key.Binding
-	QuickAdd     key.Binding
-	ReadLater    key.Binding
-	Edit         key.Binding
-	Open         key.Binding
-	Search       key.Binding
-	Filter       key.Binding
-	YankURL      key.Binding
-	Pin          key.Binding
-	Move         key.Binding
-	Select       key.Binding
-	SelectVisual key.Binding
-	ClearSelect  key.Binding
-	Cull         key.Binding
-	Organize     key.Binding
-	Recent       key.Binding
-	Toggle       key.Binding
-	Help         key.Binding
-	Quit         key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Left   key.Binding
+	Right  key.Binding
+	Top    key.Binding
+	Bottom key.Binding
+	// NextSibling and PrevSibling switch to the folder next to the current one.
+	NextSibling key.Binding
+	PrevSibling key.Binding
+	// TerminalBrowser is the second key of the gb sequence.
+	TerminalBrowser key.Binding
+	// Inbox is the second key of the gi sequence.
+	Inbox key.Binding
+	// YankDomain is the second key of the gd sequence.
+	YankDomain key.Binding
+	// VerifyOpen is the second key of the gv sequence.
+	VerifyOpen key.Binding
+	// MoveInto is the second key of the gm sequence.
+	MoveInto key.Binding
+	// Peek is the second key of the gp sequence.
+	Peek key.Binding
+	// Details is the second key of the gI sequence.
+	Details key.Binding
+	// Requeue is the second key of the gL sequence.
+	Requeue         key.Binding
+	Yank            key.Binding
+	Delete          key.Binding
+	Cut             key.Binding
+	PasteAfter      key.Binding
+	PasteBefore     key.Binding
+	AddBookmark     key.Binding
+	AddFolder       key.Binding
+	QuickAdd        key.Binding
+	AddFromTemplate key.Binding
+	ReadLater       key.Binding
+	Edit            key.Binding
+	Open            key.Binding
+	Search          key.Binding
+	Filter          key.Binding
+	YankURL         key.Binding
+	CopyLink        key.Binding
+	Pin             key.Binding
+	Move            key.Binding
+	Select          key.Binding
+	SelectVisual    key.Binding
+	ClearSelect     key.Binding
+	Cull            key.Binding
+	Organize        key.Binding
+	Recent          key.Binding
+	Untitled        key.Binding
+	History         key.Binding
+	PruneEmpty      key.Binding
+	Snooze          key.Binding
+	Alias           key.Binding
+	Remind          key.Binding
+	Reminders       key.Binding
+	MergeTags       key.Binding
+	Domains         key.Binding
+	Promote         key.Binding
+	Archive         key.Binding
+	Priority        key.Binding
+	Lock            key.Binding
+	Bulk            key.Binding
+	Toggle          key.Binding
+	Jump            key.Binding
+	Mark            key.Binding
+	Repeat          key.Binding
+	GotoIndex       key.Binding
+	FocusPane       key.Binding
+	WidenPanes      key.Binding
+	NarrowPanes     key.Binding
+	Help            key.Binding
+	Quit            key.Binding
 }
 
 // DefaultKeyMap returns the default vim-style key bindings.
@@ -64,6 +106,46 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("G"),
 			key.WithHelp("G", "go to bottom"),
 		),
+		NextSibling: key.NewBinding(
+			key.WithKeys("ctrl+n"),
+			key.WithHelp("ctrl+n", "next sibling folder"),
+		),
+		PrevSibling: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "previous sibling folder"),
+		),
+		TerminalBrowser: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("gb", "open in terminal browser"),
+		),
+		Inbox: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("gi", "capture to inbox (no AI)"),
+		),
+		YankDomain: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("gd", "yank domain"),
+		),
+		VerifyOpen: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("gv", "check link, then open"),
+		),
+		MoveInto: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("gm", "move selection into folder"),
+		),
+		Peek: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("gp", "peek at page info"),
+		),
+		Details: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("gI", "show bookmark details"),
+		),
+		Requeue: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("gL", "move back to read later"),
+		),
 		Yank: key.NewBinding(
 			key.WithKeys("y"),
 			key.WithHelp("y", "yank"),
@@ -96,6 +178,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("i"),
 			key.WithHelp("i", "AI quick add"),
 		),
+		AddFromTemplate: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "add from template"),
+		),
 		ReadLater: key.NewBinding(
 			key.WithKeys("L"),
 			key.WithHelp("L", "add to read later"),
@@ -120,6 +206,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("Y"),
 			key.WithHelp("Y", "yank URL"),
 		),
+		CopyLink: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "copy as link"),
+		),
 		Pin: key.NewBinding(
 			key.WithKeys("*"),
 			key.WithHelp("*", "pin/unpin"),
@@ -152,10 +242,94 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("R"),
 			key.WithHelp("R", "recent bookmarks"),
 		),
+		Untitled: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "untitled bookmarks"),
+		),
+		History: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "recently visited"),
+		),
+		PruneEmpty: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "prune empty folders"),
+		),
+		Snooze: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "snooze"),
+		),
+		Alias: key.NewBinding(
+			key.WithKeys("@"),
+			key.WithHelp("@", "alias"),
+		),
+		Remind: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "set reminder"),
+		),
+		Reminders: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "due reminders"),
+		),
+		MergeTags: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "merge tags"),
+		),
+		Domains: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "domains"),
+		),
+		Promote: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "promote to folder"),
+		),
+		Archive: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "archive"),
+		),
+		Priority: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "cycle priority"),
+		),
+		Lock: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "lock folder"),
+		),
+		Bulk: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "bulk actions"),
+		),
 		Toggle: key.NewBinding(
 			key.WithKeys("t"),
 			key.WithHelp("t", "toggle"),
 		),
+		Jump: key.NewBinding(
+			key.WithKeys("'"),
+			key.WithHelp("'x", "jump to x"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys("`"),
+			key.WithHelp("`x", "mark / swap with x"),
+		),
+		Repeat: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "repeat last change"),
+		),
+		GotoIndex: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#N", "go to item N"),
+		),
+		FocusPane: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "switch pane"),
+		),
+		WidenPanes: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "widen main panes"),
+		),
+		NarrowPanes: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "narrow main panes"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
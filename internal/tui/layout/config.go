@@ -7,6 +7,7 @@ type LayoutConfig struct {
 	Input InputConfig
 	Text  TextConfig
 	Fuzzy FuzzyConfig
+	Help  HelpBarConfig
 }
 
 // PaneConfig holds pane dimension configuration.
@@ -46,6 +47,15 @@ type PaneConfig struct {
 
 	// PinnedHeaderReduction accounts for header lines in pinned pane.
 	PinnedHeaderReduction int
+
+	// MainPaneWeight is the width of the current and preview panes relative
+	// to the side panes (pinned, parent), in percent: 100 = equal widths,
+	// 150 = main panes half again as wide. Clamped to Min/MaxMainPaneWeight.
+	MainPaneWeight int
+
+	// MinMainPaneWeight and MaxMainPaneWeight bound MainPaneWeight.
+	MinMainPaneWeight int
+	MaxMainPaneWeight int
 }
 
 // ModalConfig holds modal dialog configuration.
@@ -108,6 +118,22 @@ type FuzzyConfig struct {
 	HeaderReduction int
 }
 
+// HelpBarConfig holds bottom help bar configuration.
+type HelpBarConfig struct {
+	// ReservedLines is the help bar height already accounted for in Pane.HeightReduction.
+	// Taller help bars shrink the panes by the difference.
+	ReservedLines int
+
+	// CompactHeight: below this terminal height the help bar collapses to a single line.
+	CompactHeight int
+
+	// AbbreviateWidth: below this terminal width hint labels are abbreviated.
+	AbbreviateWidth int
+
+	// AbbreviatedDescLength: max characters of a hint description when abbreviated.
+	AbbreviatedDescLength int
+}
+
 // DefaultConfig returns the default layout configuration.
 func DefaultConfig() LayoutConfig {
 	return LayoutConfig{
@@ -122,6 +148,9 @@ func DefaultConfig() LayoutConfig {
 			MinFourPaneWidth:      15,
 			ContentPadding:        4,
 			PinnedHeaderReduction: 4,
+			MainPaneWeight:        100,
+			MinMainPaneWeight:     50,
+			MaxMainPaneWeight:     300,
 		},
 		Modal: ModalConfig{
 			DefaultWidthPercent:    40,
@@ -151,5 +180,11 @@ func DefaultConfig() LayoutConfig {
 			PreviewWidthPercent: 55,
 			HeaderReduction:     8,
 		},
+		Help: HelpBarConfig{
+			ReservedLines:         3,
+			CompactHeight:         22,
+			AbbreviateWidth:       118,
+			AbbreviatedDescLength: 4,
+		},
 	}
 }
@@ -0,0 +1,25 @@
+package layout
+
+// HelpBarLayout describes how the help bar adapts to the terminal size.
+type HelpBarLayout struct {
+	Compact    bool // single line of contextual hints
+	Abbreviate bool // shortened hint labels
+}
+
+// CalculateHelpBarLayout decides whether the help bar should collapse or
+// abbreviate its hints so it fits small terminals instead of overflowing.
+func CalculateHelpBarLayout(terminalWidth, terminalHeight int, cfg HelpBarConfig) HelpBarLayout {
+	return HelpBarLayout{
+		Compact:    terminalHeight < cfg.CompactHeight,
+		Abbreviate: terminalWidth < cfg.AbbreviateWidth,
+	}
+}
+
+// AbbreviateLabel shortens a hint label to at most maxLen characters.
+func AbbreviateLabel(label string, maxLen int) string {
+	runes := []rune(label)
+	if maxLen <= 0 || len(runes) <= maxLen {
+		return label
+	}
+	return string(runes[:maxLen])
+}
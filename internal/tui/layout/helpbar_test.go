@@ -0,0 +1,50 @@
+package layout
+
+import "testing"
+
+func TestCalculateHelpBarLayout(t *testing.T) {
+	cfg := DefaultConfig().Help
+
+	tests := []struct {
+		name           string
+		width, height  int
+		wantCompact    bool
+		wantAbbreviate bool
+	}{
+		{"large terminal", 200, 40, false, false},
+		{"short terminal", 200, 20, true, false},
+		{"narrow terminal", 116, 40, false, true},
+		{"common 120 column terminal", 120, 24, false, false},
+		{"small terminal", 115, 20, true, true},
+		{"exactly at thresholds", 118, 22, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateHelpBarLayout(tt.width, tt.height, cfg)
+			if got.Compact != tt.wantCompact || got.Abbreviate != tt.wantAbbreviate {
+				t.Errorf("CalculateHelpBarLayout(%d, %d) = %+v, want compact=%v abbreviate=%v",
+					tt.width, tt.height, got, tt.wantCompact, tt.wantAbbreviate)
+			}
+		})
+	}
+}
+
+func TestAbbreviateLabel(t *testing.T) {
+	tests := []struct {
+		label  string
+		maxLen int
+		want   string
+	}{
+		{"organize", 4, "orga"},
+		{"del", 4, "del"},
+		{"select", 0, "select"},
+		{"read later", 3, "rea"},
+	}
+
+	for _, tt := range tests {
+		if got := AbbreviateLabel(tt.label, tt.maxLen); got != tt.want {
+			t.Errorf("AbbreviateLabel(%q, %d) = %q, want %q", tt.label, tt.maxLen, got, tt.want)
+		}
+	}
+}
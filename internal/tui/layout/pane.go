@@ -2,8 +2,9 @@ package layout
 
 // PaneLayout holds calculated pane dimensions.
 type PaneLayout struct {
-	Width int
-	Count int // 3 or 4 panes
+	Width     int // side panes (pinned, parent)
+	MainWidth int // current and preview panes
+	Count     int // 3 or 4 panes
 }
 
 // CalculatePaneHeight computes the content height for panes.
@@ -19,6 +20,8 @@ func CalculatePaneHeight(terminalHeight int, cfg PaneConfig) int {
 // CalculatePaneWidth computes width for each pane based on layout.
 // hasPinnedItems: whether pinned pane is shown
 // atRoot: whether currently at root folder
+// The current and preview panes are weighted by cfg.MainPaneWeight; both
+// kinds of pane keep at least the layout's minimum width.
 func CalculatePaneWidth(terminalWidth int, hasPinnedItems, atRoot bool, cfg PaneConfig) PaneLayout {
 	var paneCount int
 	var offset int
@@ -36,15 +39,40 @@ func CalculatePaneWidth(terminalWidth int, hasPinnedItems, atRoot bool, cfg Pane
 		minWidth = cfg.MinThreePaneWidth
 	}
 
-	width := (terminalWidth - offset) / paneCount
+	weight := ClampMainPaneWeight(cfg.MainPaneWeight, cfg)
+	sideCount := paneCount - 2 // current and preview are the main panes
+	totalWeight := sideCount*100 + 2*weight
+	available := terminalWidth - offset
+
+	width := available * 100 / totalWeight
+	mainWidth := available * weight / totalWeight
 	if width < minWidth {
 		width = minWidth
 	}
+	if mainWidth < minWidth {
+		mainWidth = minWidth
+	}
 
 	return PaneLayout{
-		Width: width,
-		Count: paneCount,
+		Width:     width,
+		MainWidth: mainWidth,
+		Count:     paneCount,
+	}
+}
+
+// ClampMainPaneWeight bounds weight to the configured range.
+// Zero (unset) means equal widths.
+func ClampMainPaneWeight(weight int, cfg PaneConfig) int {
+	if weight == 0 {
+		return 100
+	}
+	if cfg.MinMainPaneWeight > 0 && weight < cfg.MinMainPaneWeight {
+		return cfg.MinMainPaneWeight
+	}
+	if cfg.MaxMainPaneWeight > 0 && weight > cfg.MaxMainPaneWeight {
+		return cfg.MaxMainPaneWeight
 	}
+	return weight
 }
 
 // CalculateItemWidth computes the width available for item content.
@@ -156,3 +156,36 @@ func TestCalculateViewportOffset(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculatePaneWidth_MainPaneWeight(t *testing.T) {
+	tests := []struct {
+		name          string
+		terminalWidth int
+		hasPinned     bool
+		atRoot        bool
+		weight        int
+		wantWidth     int
+		wantMain      int
+	}{
+		{"unset weight is equal", 80, false, true, 0, 24, 24},      // (80-8)/3 = 24
+		{"three panes 150%", 128, false, true, 150, 30, 45},        // 120 split 100:150:150
+		{"four panes 200%", 130, true, false, 200, 20, 40},         // 120 split 100:100:200:200
+		{"narrow main panes 50%", 128, false, true, 50, 60, 30},    // 120 split 100:50:50
+		{"weight clamped to max", 128, false, true, 1000, 20, 51},  // 300%: side 17 -> min 20
+		{"weight clamped to min", 128, false, true, 10, 60, 30},    // 50%
+		{"small width enforces min", 40, false, true, 150, 20, 20}, // both below min 20
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig().Pane
+			cfg.MainPaneWeight = tt.weight
+			got := CalculatePaneWidth(tt.terminalWidth, tt.hasPinned, tt.atRoot, cfg)
+			if got.Width != tt.wantWidth || got.MainWidth != tt.wantMain {
+				t.Errorf("CalculatePaneWidth(%d, weight %d) = {%d, %d}, want {%d, %d}",
+					tt.terminalWidth, tt.weight, got.Width, got.MainWidth,
+					tt.wantWidth, tt.wantMain)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package layout
 
 import (
 	"regexp"
+	"strings"
 	"unicode/utf8"
 )
 
@@ -167,3 +168,81 @@ func TruncatePathFromLeft(path string, maxWidth int, cfg TextConfig) string {
 	// Take from the right side
 	return cfg.Ellipsis + string(runes[pathLen-availableLen:])
 }
+
+// TruncatePathMiddle truncates a path by replacing middle segments with the
+// ellipsis, keeping the first segment (the root context) and as many trailing
+// segments as fit. Paths too short to drop a middle segment, or whose first
+// and last segments alone don't fit, fall back to TruncatePathFromLeft.
+// Example: TruncatePathMiddle("/Dev/React/Components/Forms", 20, cfg) -> "/Dev/.../Forms"
+func TruncatePathMiddle(path string, maxWidth int, cfg TextConfig) string {
+	if utf8.RuneCountInString(path) <= maxWidth {
+		return path
+	}
+
+	lead := ""
+	rest := path
+	if strings.HasPrefix(path, "/") {
+		lead = "/"
+		rest = path[1:]
+	}
+	segments := strings.Split(rest, "/")
+	if len(segments) < 3 {
+		return TruncatePathFromLeft(path, maxWidth, cfg)
+	}
+
+	head := lead + segments[0] + "/" + cfg.Ellipsis
+	tail := ""
+	for i := len(segments) - 1; i > 0; i-- {
+		candidate := "/" + segments[i] + tail
+		if utf8.RuneCountInString(head+candidate) > maxWidth {
+			break
+		}
+		tail = candidate
+	}
+	if tail == "" {
+		return TruncatePathFromLeft(path, maxWidth, cfg)
+	}
+	return head + tail
+}
+
+// JoinLeftRight pads between left and right so right ends at the given visible width.
+// Returns left alone if both don't fit with at least one space between them.
+func JoinLeftRight(left, right string, width int) string {
+	gap := width - VisibleLength(left) - VisibleLength(right)
+	if right == "" || gap < 1 {
+		return left
+	}
+	return left + strings.Repeat(" ", gap) + right
+}
+
+// WrapText word-wraps plain text into lines of at most width runes.
+// Words longer than width are broken across lines; existing newlines are kept.
+func WrapText(text string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		var line []rune
+		for _, word := range strings.Fields(paragraph) {
+			w := []rune(word)
+			switch {
+			case len(line) == 0:
+			case len(line)+1+len(w) <= width:
+				line = append(line, ' ')
+			default:
+				lines = append(lines, string(line))
+				line = nil
+			}
+			for len(line)+len(w) > width {
+				n := width - len(line)
+				lines = append(lines, string(append(line, w[:n]...)))
+				line, w = nil, w[n:]
+			}
+			line = append(line, w...)
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}
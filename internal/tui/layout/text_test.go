@@ -215,3 +215,107 @@ func TestTruncatePathFromLeft(t *testing.T) {
 		})
 	}
 }
+
+func TestTruncatePathMiddle(t *testing.T) {
+	cfg := DefaultConfig().Text
+
+	tests := []struct {
+		name     string
+		path     string
+		maxWidth int
+		want     string
+	}{
+		{"fits", "/Dev/React", 20, "/Dev/React"},
+		{"keeps root and current", "/Dev/React/Components/Forms", 20, "/Dev/.../Forms"},
+		{"keeps trailing segments that fit", "/Dev/React/Components/Forms", 25, "/Dev/.../Components/Forms"},
+		{"relative path", "bm/dev/react/hooks", 15, "bm/.../hooks"},
+		{"two segments falls back", "/Development/Components", 15, "...t/Components"},
+		{"ends don't fit falls back", "/Development/React/Components", 12, "...omponents"},
+		{"zero width", "/Dev/React/Forms", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncatePathMiddle(tt.path, tt.maxWidth, cfg)
+			if got != tt.want {
+				t.Errorf("TruncatePathMiddle(%q, %d) = %q, want %q",
+					tt.path, tt.maxWidth, got, tt.want)
+			}
+			if VisibleLength(got) > max(tt.maxWidth, 0) {
+				t.Errorf("TruncatePathMiddle(%q, %d) = %q exceeds the width", tt.path, tt.maxWidth, got)
+			}
+		})
+	}
+}
+
+func TestTruncatePath_LeftVersusMiddle(t *testing.T) {
+	cfg := DefaultConfig().Text
+	path := "/Work/Projects/Frontend/React/Hooks/State"
+
+	// Left truncation loses the root; middle keeps it and the current folder
+	left := TruncatePathFromLeft(path, 24, cfg)
+	middle := TruncatePathMiddle(path, 24, cfg)
+	if left != "...end/React/Hooks/State" {
+		t.Errorf("left = %q", left)
+	}
+	if middle != "/Work/.../Hooks/State" {
+		t.Errorf("middle = %q", middle)
+	}
+}
+
+func TestJoinLeftRight(t *testing.T) {
+	tests := []struct {
+		name  string
+		left  string
+		right string
+		width int
+		want  string
+	}{
+		{"pads between", "bm", "3/47", 10, "bm    3/47"},
+		{"exact fit with one space", "bm", "3/4", 6, "bm 3/4"},
+		{"too narrow drops right", "bm", "3/47", 6, "bm"},
+		{"empty right", "bm", "", 10, "bm"},
+		{"ansi ignored in width", "\x1b[1mbm\x1b[0m", "1/2", 6, "\x1b[1mbm\x1b[0m 1/2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := JoinLeftRight(tt.left, tt.right, tt.width)
+			if got != tt.want {
+				t.Errorf("JoinLeftRight(%q, %q, %d) = %q, want %q", tt.left, tt.right, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{"fits", "short note", 20, []string{"short note"}},
+		{"wraps on words", "the quick brown fox jumps", 10, []string{"the quick", "brown fox", "jumps"}},
+		{"breaks long word", "abcdefghijkl", 5, []string{"abcde", "fghij", "kl"}},
+		{"long word after text", "go abcdefgh", 5, []string{"go", "abcde", "fgh"}},
+		{"keeps newlines", "one\ntwo", 10, []string{"one", "two"}},
+		{"collapses spaces", "a   b", 10, []string{"a b"}},
+		{"zero width", "text", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapText(tt.text, tt.width)
+			if len(got) != len(tt.want) {
+				t.Fatalf("WrapText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("WrapText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// markOrSwap handles `x: it marks the item under the cursor as x, or, when
+// another item in this folder already has mark x, swaps the two in the
+// manual order and clears the mark. Marks last for the session.
+func (a App) markOrSwap(mark rune) (tea.Model, tea.Cmd) {
+	if a.browser.SortMode != SortManual {
+		return a, a.setMessage(MessageWarning, "Swapping needs manual order (press o)")
+	}
+	item := a.focusedItem()
+	if item == nil {
+		return a, nil
+	}
+
+	markedID, ok := a.marks[mark]
+	var marked *Item
+	if ok && markedID != item.ID() {
+		for i, it := range a.browser.Items {
+			if it.ID() == markedID {
+				marked = &a.browser.Items[i]
+				break
+			}
+		}
+	}
+	// Unset, on this item, or left behind in another folder: (re)mark here
+	if marked == nil {
+		if a.marks == nil {
+			a.marks = make(map[rune]string)
+		}
+		a.marks[mark] = item.ID()
+		return a, a.setMessage(MessageInfo, "Marked "+item.Title()+" as "+string(mark))
+	}
+
+	if !a.store.SwapOrder(item.ID(), markedID) {
+		return a, a.setMessage(MessageWarning, "Only two folders or two bookmarks can be swapped")
+	}
+	msg := "Swapped " + item.Title() + " and " + marked.Title()
+	delete(a.marks, mark)
+	cursor := a.browser.Cursor
+	a.refreshItems()
+	a.browser.Cursor = cursor
+	a.saveStore()
+	return a, a.setMessage(MessageSuccess, msg)
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nikbrunner/bm/internal/storage"
+)
+
+// dismissOnboarding closes the first-run tour on any key and records it in
+// the config so it isn't shown again.
+func (a App) dismissOnboarding() (tea.Model, tea.Cmd) {
+	a.mode = ModeNormal
+	a.config.OnboardingShown = true
+	if a.configPath != "" {
+		if err := storage.SaveConfig(a.configPath, a.config); err != nil {
+			return a, a.setMessage(MessageError, "Save config failed: "+err.Error())
+		}
+	}
+	return a, nil
+}
+
+// renderOnboardingContent lists the core keys, following the active key map.
+func (a App) renderOnboardingContent() string {
+	keys := []struct {
+		keys []key.Binding
+		desc string
+	}{
+		{[]key.Binding{a.keys.Down, a.keys.Up}, "move"},
+		{[]key.Binding{a.keys.Right, a.keys.Left}, "open folder / go back"},
+		{[]key.Binding{a.keys.AddBookmark}, "add a bookmark"},
+		{[]key.Binding{a.keys.AddFolder}, "add a folder"},
+		{[]key.Binding{a.keys.Search}, "find anything"},
+		{[]key.Binding{a.keys.Help}, "all keys"},
+		{[]key.Binding{a.keys.Quit}, "quit"},
+	}
+
+	var s strings.Builder
+	s.WriteString("A bookmark manager driven by the keyboard. The essentials:\n\n")
+	for _, k := range keys {
+		names := make([]string, len(k.keys))
+		for i, b := range k.keys {
+			names[i] = b.Keys()[0]
+		}
+		s.WriteString(a.styles.HintKey.Render(fmt.Sprintf("%-5s", strings.Join(names, "/"))) + " " + k.desc + "\n")
+	}
+	s.WriteString("\n" + a.styles.Help.Render("Shown once. Press any key to start."))
+	return s.String()
+}
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nikbrunner/bm/internal/fetcher"
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// peekMsg carries the fetched metadata for the bookmark being peeked at.
+type peekMsg struct {
+	gen  int // task generation, see startTask
+	meta fetcher.Meta
+	err  error
+}
+
+// startPeek fetches b's page in the background and shows what it says about
+// itself: title, description and image (gp).
+func (a App) startPeek(b *model.Bookmark) (tea.Model, tea.Cmd) {
+	a.peek = PeekState{Bookmark: *b}
+	a.mode = ModePeek
+	ctx, gen := a.startTask()
+	fetch := a.fetchPage
+	url := b.URL
+	return a, func() tea.Msg {
+		meta, err := fetch(ctx, url)
+		return peekMsg{gen: gen, meta: meta, err: err}
+	}
+}
+
+// handlePeek shows the fetch result, unless the peek was closed meanwhile.
+func (a App) handlePeek(msg peekMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != a.taskGen || a.mode != ModePeek {
+		return a, nil
+	}
+	a.finishTask()
+	a.peek.Loaded = true
+	a.peek.Meta = msg.meta
+	a.peek.Err = msg.err
+	return a, nil
+}
+
+// updatePeek handles keys in the peek: Enter/o opens the bookmark, t takes
+// the page title, Esc/q closes (and stops a fetch still running).
+func (a App) updatePeek(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "o":
+		a.cancelTask()
+		a.mode = ModeNormal
+		return a.openBookmarkURL(a.peek.Bookmark)
+	case "t":
+		title := a.peek.Meta.Title
+		if !a.peek.Loaded || title == "" || title == a.peek.Bookmark.Title {
+			return a, nil
+		}
+		a.mode = ModeNormal
+		bookmark := a.store.GetBookmarkByID(a.peek.Bookmark.ID)
+		if bookmark == nil {
+			return a, nil
+		}
+		bookmark.Title = title
+		bookmark.MarkModified(time.Now())
+		a.saveStore()
+		a.refreshItems()
+		return a, a.setMessage(MessageSuccess, "Title updated")
+	case "esc", "q":
+		a.cancelTask()
+		a.mode = ModeNormal
+	}
+	return a, nil
+}
+
+// renderPeekContent renders the fetch in progress, its error, or the page's
+// metadata.
+func (a App) renderPeekContent() string {
+	s := a.styles.URL.Render(a.peek.Bookmark.URL) + "\n\n"
+	if !a.peek.Loaded {
+		s += a.styles.Empty.Render("Fetching page...") + "\n\n"
+		return s + a.renderHintsInline([]Hint{
+			{Key: "Enter", Desc: "open"},
+			{Key: "Esc", Desc: "cancel"},
+		})
+	}
+	if a.peek.Err != nil {
+		s += "Fetch failed: " + a.peek.Err.Error() + "\n\n"
+		return s + a.renderHintsInline([]Hint{
+			{Key: "Enter", Desc: "open anyway"},
+			{Key: "Esc", Desc: "close"},
+		})
+	}
+
+	meta := a.peek.Meta
+	field := func(label, value string) string {
+		if value == "" {
+			value = a.styles.Empty.Render("(none)")
+		}
+		return a.styles.Title.Render(label) + "\n" + value + "\n\n"
+	}
+	s += field("Title", meta.Title)
+	s += field("Description", meta.Description)
+	s += field("Image", meta.Image)
+
+	hints := []Hint{{Key: "Enter", Desc: "open"}}
+	if meta.Title != "" && meta.Title != a.peek.Bookmark.Title {
+		hints = append(hints, Hint{Key: "t", Desc: "use title"})
+	}
+	hints = append(hints, Hint{Key: "Esc", Desc: "close"})
+	return s + a.renderHintsInline(hints)
+}
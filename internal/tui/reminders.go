@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// reminderLaterDuration is how far z in the reminders list pushes a reminder.
+const reminderLaterDuration = "1d"
+
+// reminderSummary returns e.g. "2 reminders due".
+func reminderSummary(n int) string {
+	if n == 1 {
+		return "1 reminder due"
+	}
+	return strconv.Itoa(n) + " reminders due"
+}
+
+// submitRemind sets (or, with an empty duration, clears) the reminder on the
+// bookmark being edited.
+func (a App) submitRemind() (tea.Model, tea.Cmd) {
+	input := strings.TrimSpace(a.modal.RemindInput.Value())
+	var at *time.Time
+	if input != "" {
+		t, err := model.ParseSnoozeDuration(input, time.Now())
+		if err != nil {
+			return a, a.setMessage(MessageError, err.Error())
+		}
+		at = &t
+	}
+
+	if err := a.store.SetReminder(a.modal.EditItemID, at); err != nil {
+		a.mode = ModeNormal
+		return a, a.setMessage(MessageError, err.Error())
+	}
+
+	a.saveStore()
+	a.mode = ModeNormal
+	a.modal.RemindInput.Blur()
+	if at == nil {
+		return a, a.setMessage(MessageSuccess, "Reminder cleared")
+	}
+	return a, a.setMessage(MessageSuccess, "Reminder set for "+at.Format("Mon Jan 2 15:04"))
+}
+
+// updateReminders handles keys in the due reminders list: open, remind
+// again later, or clear. The list closes once nothing is due.
+func (a App) updateReminders(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	due := a.store.GetDueReminders(time.Now())
+	if a.reminderCursor >= len(due) {
+		a.reminderCursor = len(due) - 1
+	}
+	if len(due) == 0 || msg.Type == tea.KeyEsc || msg.String() == "q" {
+		a.mode = ModeNormal
+		return a, nil
+	}
+	current := due[a.reminderCursor]
+
+	switch msg.String() {
+	case "j", "down":
+		if a.reminderCursor < len(due)-1 {
+			a.reminderCursor++
+		}
+		return a, nil
+	case "k", "up":
+		if a.reminderCursor > 0 {
+			a.reminderCursor--
+		}
+		return a, nil
+	case "enter", "l":
+		// Opening counts as following up
+		if b := a.store.GetBookmarkByID(current.ID); b != nil {
+			b.MarkVisited(time.Now())
+			b.RemindAt = nil
+		}
+		a.saveStore()
+		a.refreshItems()
+		return a.afterReminderAction(openURLCmd(current.URL))
+	case "z":
+		later, _ := model.ParseSnoozeDuration(reminderLaterDuration, time.Now())
+		_ = a.store.SetReminder(current.ID, &later)
+		a.saveStore()
+		return a.afterReminderAction(a.setMessage(MessageInfo, "Reminding again "+later.Format("Mon Jan 2 15:04")))
+	case "d", "c":
+		_ = a.store.SetReminder(current.ID, nil)
+		a.saveStore()
+		return a.afterReminderAction(a.setMessage(MessageSuccess, "Reminder cleared"))
+	}
+	return a, nil
+}
+
+// afterReminderAction keeps the cursor in range and leaves the list once
+// the last due reminder has been handled.
+func (a App) afterReminderAction(cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	remaining := len(a.store.GetDueReminders(time.Now()))
+	if remaining == 0 {
+		a.mode = ModeNormal
+	} else if a.reminderCursor >= remaining {
+		a.reminderCursor = remaining - 1
+	}
+	return a, cmd
+}
+
+// renderRemindersContent renders the due reminders list for the modal.
+func (a App) renderRemindersContent() string {
+	due := a.store.GetDueReminders(time.Now())
+
+	var b strings.Builder
+	for i, bm := range due {
+		if i == a.reminderCursor {
+			b.WriteString(a.styles.ItemSelected.Render("▸ " + bm.Title))
+		} else {
+			b.WriteString("  " + bm.Title)
+		}
+		b.WriteString("  " + a.styles.Empty.Render(model.FormatTimeAgo(*bm.RemindAt)) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(a.renderHintsInline([]Hint{
+		{Key: "Enter", Desc: "open"},
+		{Key: "z", Desc: "remind in " + reminderLaterDuration},
+		{Key: "d", Desc: "clear"},
+		{Key: "Esc", Desc: "close"},
+	}))
+	return b.String()
+}
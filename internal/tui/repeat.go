@@ -0,0 +1,30 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// repeatAction is a single-item change that . applies again to the item under
+// the cursor.
+type repeatAction int
+
+const (
+	repeatNone    repeatAction = iota
+	repeatDelete               // d
+	repeatPin                  // *
+	repeatArchive              // X
+)
+
+// repeatLast re-runs the last repeatable change on the current item, going
+// through the same checks (locks, delete confirmation) as the original key.
+func (a App) repeatLast() (tea.Model, tea.Cmd) {
+	switch a.lastAction {
+	case repeatDelete:
+		a.deleteCurrentItem()
+		return a, nil
+	case repeatPin:
+		cmd := a.togglePinCurrentItem()
+		return a, cmd
+	case repeatArchive:
+		return a.toggleArchiveCurrentItem()
+	}
+	return a, a.setMessage(MessageInfo, "Nothing to repeat")
+}
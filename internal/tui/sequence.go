@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sequencePrefix identifies the first key of a multi-key command.
+type sequencePrefix int
+
+const (
+	prefixNone   sequencePrefix = iota
+	prefixG                     // gg, gb, gd, gi, gv, gp, gI, gL, gm
+	prefixToggle                // to, tc, ta, ts, tn, tv
+	prefixJump                  // 'x type-ahead jump
+	prefixMark                  // `x mark, then swap with the mark
+)
+
+// pendingSequence is a prefix key waiting for the key that completes it.
+type pendingSequence struct {
+	prefix sequencePrefix
+	key    string // the prefix as typed, shown in the status line
+}
+
+// sequenceTimeoutMsg cancels a prefix that wasn't completed in time.
+type sequenceTimeoutMsg struct {
+	gen int
+}
+
+// gCommand is a g-prefixed command: the second key and what it does in the
+// focused pane.
+type gCommand struct {
+	key key.Binding
+	run func(a App) (tea.Model, tea.Cmd)
+}
+
+// gCommands lists the g sequences. A new one only needs an entry here.
+func (a App) gCommands() []gCommand {
+	return []gCommand{
+		{a.keys.Top, App.gotoTop},
+		{a.keys.Inbox, App.startInboxCapture},
+		{a.keys.Requeue, App.requeueReadLater},
+		{a.keys.TerminalBrowser, func(a App) (tea.Model, tea.Cmd) {
+			return a, a.openInTerminalBrowser(a.focusedItem())
+		}},
+		{a.keys.YankDomain, func(a App) (tea.Model, tea.Cmd) {
+			return a, a.yankDomainCmd(a.focusedItem())
+		}},
+		{a.keys.VerifyOpen, func(a App) (tea.Model, tea.Cmd) {
+			item := a.focusedItem()
+			if item == nil || item.IsFolder() {
+				return a, nil
+			}
+			return a.startVerifyOpen(item.Bookmark)
+		}},
+		{a.keys.MoveInto, App.moveSelectionIntoFolder},
+		{a.keys.Peek, func(a App) (tea.Model, tea.Cmd) {
+			item := a.focusedItem()
+			if item == nil || item.IsFolder() {
+				return a, nil
+			}
+			return a.startPeek(item.Bookmark)
+		}},
+		{a.keys.Details, func(a App) (tea.Model, tea.Cmd) {
+			item := a.focusedItem()
+			if item == nil || item.IsFolder() {
+				return a, nil
+			}
+			return a.startDetails(item.Bookmark)
+		}},
+	}
+}
+
+// startSequence waits for the key completing prefix. The returned command
+// cancels the prefix after Config.SequenceTimeoutMs.
+func (a *App) startSequence(prefix sequencePrefix, msg tea.KeyMsg) tea.Cmd {
+	a.pending = pendingSequence{prefix: prefix, key: msg.String()}
+	a.pendingGen++
+	if a.config.SequenceTimeoutMs <= 0 {
+		return nil
+	}
+	gen := a.pendingGen
+	return tea.Tick(time.Duration(a.config.SequenceTimeoutMs)*time.Millisecond, func(time.Time) tea.Msg {
+		return sequenceTimeoutMsg{gen: gen}
+	})
+}
+
+// completeSequence clears the pending prefix and runs the command msg
+// completes. handled is false when msg completes nothing after g, so it is
+// processed as a key of its own (gj still moves down).
+func (a App) completeSequence(msg tea.KeyMsg) (model tea.Model, cmd tea.Cmd, handled bool) {
+	prefix := a.pending.prefix
+	a.pending = pendingSequence{}
+
+	switch prefix {
+	case prefixJump:
+		if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
+			return a, a.jumpToInitial(msg.Runes[0]), true
+		}
+		// Any other key after ' - cancel
+		return a, nil, true
+	case prefixMark:
+		if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
+			model, cmd = a.markOrSwap(msg.Runes[0])
+			return model, cmd, true
+		}
+		return a, nil, true
+	case prefixToggle:
+		model, cmd = a.runToggle(msg)
+		return model, cmd, true
+	case prefixG:
+		for _, c := range a.gCommands() {
+			if key.Matches(msg, c.key) {
+				model, cmd = c.run(a)
+				return model, cmd, true
+			}
+		}
+	}
+	return a, nil, false
+}
+
+// runToggle handles the second key of a t sequence.
+func (a App) runToggle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "o":
+		// Toggle order mode
+		a.browser.SortMode = (a.browser.SortMode + 1) % sortModeCount
+		a.refreshItems()
+		return a, nil
+	case "c":
+		// Toggle delete confirmation
+		a.confirmDelete = !a.confirmDelete
+		if a.confirmDelete {
+			return a, a.setMessage(MessageInfo, "Delete confirmation: ON")
+		}
+		return a, a.setMessage(MessageInfo, "Delete confirmation: OFF")
+	case "a":
+		// Toggle archived bookmarks inline
+		a.showArchived = !a.showArchived
+		a.refreshItems()
+		if a.browser.Cursor >= len(a.browser.Items) {
+			a.browser.Cursor = max(len(a.browser.Items)-1, 0)
+		}
+		if a.showArchived {
+			return a, a.setMessage(MessageInfo, "Archived bookmarks: SHOWN")
+		}
+		return a, a.setMessage(MessageInfo, "Archived bookmarks: HIDDEN")
+	case "s":
+		// Toggle folder stats in the preview pane
+		a.folderStats = !a.folderStats
+		if a.folderStats {
+			return a, a.setMessage(MessageInfo, "Folder stats: ON")
+		}
+		return a, a.setMessage(MessageInfo, "Folder stats: OFF")
+	case "n":
+		// Toggle item numbers in the current pane
+		a.itemNumbers = !a.itemNumbers
+		if a.itemNumbers {
+			return a, a.setMessage(MessageInfo, "Item numbers: ON")
+		}
+		return a, a.setMessage(MessageInfo, "Item numbers: OFF")
+	case "v":
+		// Cycle the current folder's view mode
+		cmd := a.cycleViewMode()
+		return a, cmd
+	}
+	// Any other key after t - ignore
+	return a, nil
+}
+
+// gotoTop moves the cursor of the focused pane to the first item (gg).
+func (a App) gotoTop() (tea.Model, tea.Cmd) {
+	if a.focusedPane == PanePinned {
+		a.pinnedCursor = 0
+	} else {
+		a.browser.Cursor = 0
+	}
+	return a, nil
+}
+
+// focusedItem returns the item under the cursor in the focused pane, or nil.
+func (a *App) focusedItem() *Item {
+	if a.focusedPane == PanePinned {
+		return a.selectedPinnedItem()
+	}
+	displayItems := a.getDisplayItems()
+	if a.browser.Cursor >= len(displayItems) {
+		return nil
+	}
+	return &displayItems[a.browser.Cursor]
+}
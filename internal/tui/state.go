@@ -6,6 +6,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/nikbrunner/bm/internal/ai"
 	"github.com/nikbrunner/bm/internal/culler"
+	"github.com/nikbrunner/bm/internal/fetcher"
+	"github.com/nikbrunner/bm/internal/model"
 	"github.com/nikbrunner/bm/internal/tui/layout"
 )
 
@@ -13,8 +15,10 @@ import (
 type ListSource int
 
 const (
-	SourceAll    ListSource = iota // All items (folders + bookmarks), fuzzy search behavior
-	SourceRecent                   // Bookmarks only, sorted by CreatedAt descending
+	SourceAll             ListSource = iota // All items (folders + bookmarks), fuzzy search behavior
+	SourceRecent                            // Bookmarks only, sorted by CreatedAt descending
+	SourceUntitled                          // Bookmarks whose title is empty or just the URL
+	SourceRecentlyVisited                   // Visited bookmarks, sorted by VisitedAt descending
 )
 
 // TagMatchMode controls how multiple tags are matched in search.
@@ -42,6 +46,10 @@ type QuickAddState struct {
 	FilteredFolders []string        // Filtered folder paths based on search
 	FolderIdx       int             // Selected folder index in filtered list
 	FilterInput     textinput.Model // Filter input for folder search
+	ReadLater       bool            // URL prompt feeds Read Later (no clipboard available)
+	Inbox           bool            // URL prompt feeds the no-AI inbox capture (gi)
+	Template        string          // URL prompt feeds an add from this config template (I)
+	Regenerations   int             // times the suggestion was asked for again (Ctrl+r)
 }
 
 // NewQuickAddState creates a new QuickAddState with initialized input.
@@ -71,6 +79,10 @@ func (q *QuickAddState) Reset() {
 	q.FilteredFolders = nil
 	q.FolderIdx = 0
 	q.FilterInput.Reset()
+	q.ReadLater = false
+	q.Inbox = false
+	q.Template = ""
+	q.Regenerations = 0
 }
 
 // QuickAddCreateFolderState holds state for creating a new folder during quick add.
@@ -116,11 +128,13 @@ func (m *MoveState) Reset() {
 // SearchState holds state for fullscreen list mode (global search and recent view) and local filtering.
 type SearchState struct {
 	// Fullscreen list mode (ModeSearch)
-	Source       ListSource      // Current data source (SourceAll or SourceRecent)
+	Source       ListSource      // Current data source (SourceAll, SourceRecent, ...)
 	Input        textinput.Model // Search/filter input
 	FuzzyMatches []fuzzyMatch    // Current fuzzy match results
 	FuzzyCursor  int             // Selected index in fuzzy results
 	AllItems     []Item          // Base items for current source
+	// IncludeFolders lists folders alongside bookmarks for SourceAll
+	IncludeFolders bool
 
 	// Tag filter state
 	TagInput         textinput.Model  // Tag filter input
@@ -135,6 +149,11 @@ type SearchState struct {
 	FilterInput   textinput.Model // Filter input for current folder
 	FilterQuery   string          // Active filter query (persists after closing filter)
 	FilteredItems []Item          // Items matching filter in current folder
+
+	// Pinned pane filter
+	PinnedFilterInput   textinput.Model // Filter input for pinned pane
+	PinnedFilterQuery   string          // Active pinned filter query (persists after closing filter)
+	PinnedFilteredItems []Item          // Pinned items matching filter
 }
 
 // NewSearchState creates a new SearchState with initialized inputs.
@@ -154,13 +173,19 @@ func NewSearchState(cfg layout.LayoutConfig) SearchState {
 	filterInput.CharLimit = cfg.Input.FilterCharLimit
 	filterInput.Width = cfg.Input.FilterWidth
 
+	pinnedFilterInput := textinput.New()
+	pinnedFilterInput.Placeholder = "Filter pins..."
+	pinnedFilterInput.CharLimit = cfg.Input.FilterCharLimit
+	pinnedFilterInput.Width = cfg.Input.FilterWidth
+
 	return SearchState{
-		Input:            searchInput,
-		TagInput:         tagInput,
-		TagFilterMode:    TagMatchAny,
-		TagSuggestionIdx: -1,
-		FocusedField:     SearchFocusQuery,
-		FilterInput:      filterInput,
+		Input:             searchInput,
+		TagInput:          tagInput,
+		TagFilterMode:     TagMatchAny,
+		TagSuggestionIdx:  -1,
+		FocusedField:      SearchFocusQuery,
+		FilterInput:       filterInput,
+		PinnedFilterInput: pinnedFilterInput,
 	}
 }
 
@@ -190,11 +215,19 @@ func (s *SearchState) ResetFilter() {
 
 // ModalState holds state for edit/add modals (bookmark/folder).
 type ModalState struct {
-	TitleInput textinput.Model // Title input for folders/bookmarks
-	URLInput   textinput.Model // URL input for bookmarks
-	TagsInput  textinput.Model // Tags input for bookmarks
-	EditItemID string          // ID of item being edited (folder or bookmark)
-	CutMode    bool            // true = cut (buffer), false = delete (no buffer)
+	TitleInput  textinput.Model // Title input for folders/bookmarks
+	URLInput    textinput.Model // URL input for bookmarks
+	TagsInput   textinput.Model // Tags input for bookmarks
+	SnoozeInput textinput.Model // Duration input for snoozing bookmarks
+	AliasInput  textinput.Model // Alias name input for CLI shortcuts
+	RemindInput textinput.Model // Duration input for bookmark reminders
+	GotoInput   textinput.Model // Item number input for # jumps
+	EditItemID  string          // ID of item being edited (folder or bookmark)
+	CutMode     bool            // true = cut (buffer), false = delete (no buffer)
+
+	// Promote bookmark to folder
+	PromoteInput   textinput.Model // Folder name input, defaults to the bookmark title
+	PromoteRelated bool            // Also move sibling bookmarks sharing a tag
 
 	// Batch delete support
 	DeleteItems []Item // items to delete (for batch operations)
@@ -222,10 +255,40 @@ func NewModalState(cfg layout.LayoutConfig) ModalState {
 	tagsInput.CharLimit = cfg.Input.TagsCharLimit
 	tagsInput.Width = cfg.Input.StandardWidth
 
+	snoozeInput := textinput.New()
+	snoozeInput.Placeholder = "3d, 1w, 2h"
+	snoozeInput.CharLimit = 8
+	snoozeInput.Width = cfg.Input.StandardWidth
+
+	aliasInput := textinput.New()
+	aliasInput.Placeholder = "gh"
+	aliasInput.CharLimit = 32
+	aliasInput.Width = cfg.Input.StandardWidth
+
+	remindInput := textinput.New()
+	remindInput.Placeholder = "3d, 1w, 2h (empty clears)"
+	remindInput.CharLimit = 8
+	remindInput.Width = cfg.Input.StandardWidth
+
+	gotoInput := textinput.New()
+	gotoInput.Placeholder = "14"
+	gotoInput.CharLimit = 6
+	gotoInput.Width = cfg.Input.StandardWidth
+
+	promoteInput := textinput.New()
+	promoteInput.Placeholder = "Folder name"
+	promoteInput.CharLimit = cfg.Input.TitleCharLimit
+	promoteInput.Width = cfg.Input.StandardWidth
+
 	return ModalState{
 		TitleInput:       titleInput,
 		URLInput:         urlInput,
 		TagsInput:        tagsInput,
+		SnoozeInput:      snoozeInput,
+		AliasInput:       aliasInput,
+		RemindInput:      remindInput,
+		GotoInput:        gotoInput,
+		PromoteInput:     promoteInput,
 		TagSuggestionIdx: -1,
 	}
 }
@@ -235,6 +298,12 @@ func (m *ModalState) ResetInputs() {
 	m.TitleInput.Reset()
 	m.URLInput.Reset()
 	m.TagsInput.Reset()
+	m.SnoozeInput.Reset()
+	m.AliasInput.Reset()
+	m.RemindInput.Reset()
+	m.GotoInput.Reset()
+	m.PromoteInput.Reset()
+	m.PromoteRelated = false
 	m.EditItemID = ""
 	m.CutMode = false
 	m.DeleteItems = nil
@@ -319,6 +388,87 @@ func (b *BrowserNav) ResetToRoot() {
 	b.Cursor = 0
 }
 
+// BulkAction is a batch operation offered by the bulk actions menu.
+type BulkAction int
+
+const (
+	BulkTag BulkAction = iota
+	BulkMove
+	BulkPin
+	BulkOpen
+	BulkExport
+	BulkYank
+	BulkDelete
+)
+
+// BulkState holds state for the bulk actions menu.
+type BulkState struct {
+	Actions    []BulkAction    // actions available for the current selection
+	MenuCursor int             // selected action
+	TagInput   textinput.Model // tags to add to the selected bookmarks
+	PathInput  textinput.Model // file to export the selection to
+}
+
+// NewBulkState creates a BulkState with initialized tag and path inputs.
+func NewBulkState(cfg layout.LayoutConfig) BulkState {
+	input := textinput.New()
+	input.Placeholder = "tag1, tag2"
+	input.CharLimit = cfg.Input.TagsCharLimit
+	input.Width = cfg.Input.StandardWidth
+
+	pathInput := textinput.New()
+	pathInput.Placeholder = "~/Downloads/selection.html"
+	pathInput.CharLimit = cfg.Input.URLCharLimit
+	pathInput.Width = cfg.Input.StandardWidth
+	return BulkState{TagInput: input, PathInput: pathInput}
+}
+
+// TagMergeState holds the tag merge picker: first the tag to merge away is
+// chosen, then the tag it merges into.
+type TagMergeState struct {
+	Cursor int
+	From   string // chosen source tag; empty while picking it
+}
+
+// DomainsState holds the domains view: first a host is picked, then one of
+// its bookmarks.
+type DomainsState struct {
+	DomainCursor int
+	Domain       string // chosen host; empty while picking it
+	Cursor       int    // cursor in the chosen host's bookmarks
+}
+
+// VerifyOpenState holds a bookmark whose link is checked before opening.
+type VerifyOpenState struct {
+	Bookmark model.Bookmark
+	Checked  bool          // the check finished and the link looks broken
+	Result   culler.Result // set once Checked
+}
+
+// PeekState holds a bookmark whose page metadata is fetched for a peek.
+type PeekState struct {
+	Bookmark model.Bookmark
+	Loaded   bool         // the fetch finished, with Meta or Err
+	Meta     fetcher.Meta // set once Loaded
+	Err      error
+}
+
+// LargeOpKind identifies an operation held back by the large operation guard.
+type LargeOpKind int
+
+const (
+	LargeOpPaste LargeOpKind = iota
+	LargeOpMove
+	LargeOpOpen
+)
+
+// LargeOpState holds a paste, move or multi-open waiting for confirmation because it affects many items.
+type LargeOpState struct {
+	Kind   LargeOpKind
+	Before bool // paste before the cursor instead of after
+	Count  int  // affected items, counting folder contents (URLs for LargeOpOpen)
+}
+
 // CullState holds state for the URL cull feature.
 type CullState struct {
 	Results     []culler.Result // Raw results from URL check
@@ -386,6 +536,7 @@ type OrganizeState struct {
 	MenuCursor     int                  // Cursor for organize menu (0=fresh, 1=cached)
 	CacheTime      time.Time            // When cache was created
 	HasCache       bool                 // Whether cache file exists
+	Confirming     bool                 // Showing change preview for the current suggestion
 }
 
 // OrganizeSuggestion represents a suggested organization change for an item.
@@ -413,6 +564,7 @@ func (s *OrganizeState) Reset() {
 	s.Progress = 0
 	s.Total = 0
 	s.MenuCursor = 0
+	s.Confirming = false
 	// Note: HasCache and CacheTime are preserved
 }
 
@@ -457,3 +609,28 @@ func (s *OrganizeSuggestion) HasTagChanges() bool {
 	}
 	return false
 }
+
+// AddedTags returns suggested tags that the item doesn't have yet.
+func (s *OrganizeSuggestion) AddedTags() []string {
+	return tagsMissingFrom(s.SuggestedTags, s.CurrentTags)
+}
+
+// RemovedTags returns current tags that the suggestion drops.
+func (s *OrganizeSuggestion) RemovedTags() []string {
+	return tagsMissingFrom(s.CurrentTags, s.SuggestedTags)
+}
+
+// tagsMissingFrom returns tags in from that are not in other, preserving order.
+func tagsMissingFrom(from, other []string) []string {
+	otherSet := make(map[string]bool)
+	for _, tag := range other {
+		otherSet[tag] = true
+	}
+	var missing []string
+	for _, tag := range from {
+		if !otherSet[tag] {
+			missing = append(missing, tag)
+		}
+	}
+	return missing
+}
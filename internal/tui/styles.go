@@ -19,6 +19,7 @@ type Styles struct {
 	Date             lipgloss.Style
 	Help             lipgloss.Style
 	Empty            lipgloss.Style
+	ItemArchived     lipgloss.Style // Archived bookmarks shown inline
 	HintKey          lipgloss.Style // Key portion of hints (e.g., "Enter", "j/k")
 	HintDesc         lipgloss.Style // Description portion of hints (e.g., "confirm", "move")
 	HintLabel        lipgloss.Style // Label for hint sections (e.g., "Local:", "Global:")
@@ -96,6 +97,12 @@ func DefaultStyles() Styles {
 		Empty: lipgloss.NewStyle().
 			Foreground(subtle),
 
+		ItemArchived: lipgloss.NewStyle().
+			Foreground(subtle).
+			Faint(true).
+			Strikethrough(true).
+			PaddingLeft(1),
+
 		HintKey: lipgloss.NewStyle().
 			Foreground(accent).
 			Bold(true),
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// openTagMerge opens the tag merge picker at its first step.
+func (a *App) openTagMerge() tea.Cmd {
+	if len(a.store.TagCounts()) < 2 {
+		return a.setMessage(MessageInfo, "Need at least two tags to merge")
+	}
+	a.tagMerge = TagMergeState{}
+	a.mode = ModeMergeTags
+	return nil
+}
+
+// tagMergeChoices returns the tags offered at the current step; the source
+// tag is left out when picking the target.
+func (a App) tagMergeChoices() []model.TagCount {
+	var choices []model.TagCount
+	for _, tc := range a.store.TagCounts() {
+		if a.tagMerge.From != "" && strings.EqualFold(tc.Tag, a.tagMerge.From) {
+			continue
+		}
+		choices = append(choices, tc)
+	}
+	return choices
+}
+
+// updateTagMerge handles keys in the tag merge picker: Enter picks the source
+// tag, then the target, which merges them. Esc steps back, then closes.
+func (a App) updateTagMerge(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	choices := a.tagMergeChoices()
+	if a.tagMerge.Cursor >= len(choices) {
+		a.tagMerge.Cursor = len(choices) - 1
+	}
+
+	switch msg.String() {
+	case "esc", "q", "h", "left":
+		if a.tagMerge.From != "" {
+			a.tagMerge = TagMergeState{}
+			return a, nil
+		}
+		a.mode = ModeNormal
+		return a, nil
+	case "j", "down":
+		if a.tagMerge.Cursor < len(choices)-1 {
+			a.tagMerge.Cursor++
+		}
+		return a, nil
+	case "k", "up":
+		if a.tagMerge.Cursor > 0 {
+			a.tagMerge.Cursor--
+		}
+		return a, nil
+	case "enter", "l":
+		if len(choices) == 0 {
+			return a, nil
+		}
+		picked := choices[a.tagMerge.Cursor].Tag
+		if a.tagMerge.From == "" {
+			a.tagMerge = TagMergeState{From: picked}
+			return a, nil
+		}
+
+		from := a.tagMerge.From
+		changed := a.store.MergeTags(from, picked)
+		a.tagMerge = TagMergeState{}
+		a.mode = ModeNormal
+		a.saveStore()
+		a.refreshItems()
+		return a, a.setMessage(MessageSuccess, "Merged #"+from+" into #"+picked+" ("+strconv.Itoa(changed)+" bookmarks)")
+	}
+	return a, nil
+}
+
+// tagMergeVisible is how many tags the tag merge modal lists at once.
+const tagMergeVisible = 15
+
+// renderTagMergeContent renders the tag list for the tag merge modal,
+// scrolled so the cursor stays visible.
+func (a App) renderTagMergeContent() string {
+	choices := a.tagMergeChoices()
+	start := max(0, a.tagMerge.Cursor-tagMergeVisible+1)
+	end := min(len(choices), start+tagMergeVisible)
+
+	var b strings.Builder
+	for i := start; i < end; i++ {
+		tc := choices[i]
+		line := "#" + tc.Tag
+		if i == a.tagMerge.Cursor {
+			b.WriteString(a.styles.ItemSelected.Render("▸ " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("  " + a.styles.Empty.Render(strconv.Itoa(tc.Count)) + "\n")
+	}
+
+	b.WriteString("\n")
+	if a.tagMerge.From == "" {
+		b.WriteString(a.renderHintsInline([]Hint{
+			{Key: "Enter", Desc: "merge this tag away"},
+			{Key: "Esc", Desc: "close"},
+		}))
+	} else {
+		b.WriteString(a.renderHintsInline([]Hint{
+			{Key: "Enter", Desc: "merge into this tag"},
+			{Key: "Esc", Desc: "back"},
+		}))
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openTemplatePicker starts an add from a config template. With a single
+// template it goes straight to the URL prompt.
+func (a *App) openTemplatePicker() tea.Cmd {
+	names := a.config.TemplateNames()
+	switch len(names) {
+	case 0:
+		return a.setMessage(MessageInfo, "No templates configured (templates in config.json)")
+	case 1:
+		return a.startTemplateAdd(names[0])
+	}
+	a.templateCursor = 0
+	a.mode = ModePickTemplate
+	return nil
+}
+
+// updateTemplatePicker handles keys in the template picker.
+func (a App) updateTemplatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	names := a.config.TemplateNames()
+	switch msg.String() {
+	case "esc", "q", "h", "left":
+		a.mode = ModeNormal
+		return a, nil
+	case "j", "down":
+		if a.templateCursor < len(names)-1 {
+			a.templateCursor++
+		}
+		return a, nil
+	case "k", "up":
+		if a.templateCursor > 0 {
+			a.templateCursor--
+		}
+		return a, nil
+	case "enter", "l":
+		if a.templateCursor >= len(names) {
+			return a, nil
+		}
+		return a, a.startTemplateAdd(names[a.templateCursor])
+	}
+	return a, nil
+}
+
+// startTemplateAdd opens the URL prompt for adding with the named template,
+// pre-filled from the clipboard.
+func (a *App) startTemplateAdd(name string) tea.Cmd {
+	a.mode = ModeQuickAdd
+	a.quickAdd.Reset()
+	a.quickAdd.Template = name
+	if clipContent := a.readClipboard(); clipContent != "" {
+		a.quickAdd.Input.SetValue(clipContent)
+	}
+	return tea.Batch(a.quickAdd.Input.Focus(), a.noteClipboardUnavailable())
+}
+
+// confirmTemplateAdd opens the quick add confirmation for url, pre-filled
+// from the template instead of an AI suggestion.
+func (a App) confirmTemplateAdd(url string) (tea.Model, tea.Cmd) {
+	if !isWebURL(url) {
+		return a, a.setMessage(MessageError, "Invalid URL")
+	}
+	t := a.config.Templates[a.quickAdd.Template]
+
+	folder := t.Folder
+	if folder == "" {
+		folder = a.config.QuickAddFolder
+	}
+	folder = "/" + strings.TrimPrefix(folder, "/")
+	a.quickAdd.Folders = a.buildOrderedFolderPaths(a.browser.CurrentFolderID, folder)
+	a.quickAdd.FilteredFolders = a.quickAdd.Folders
+	a.quickAdd.FilterInput.Reset()
+	a.quickAdd.FolderIdx = a.findFolderIndex(folder)
+
+	title := t.TitlePrefix
+	if title == "" {
+		title = url
+	}
+	a.modal.TitleInput.Reset()
+	a.modal.TitleInput.SetValue(title)
+	a.modal.TagsInput.Reset()
+	a.modal.TagsInput.SetValue(strings.Join(a.normalizeTags(t.Tags), ", "))
+
+	a.mode = ModeQuickAddConfirm
+	return a, a.modal.TitleInput.Focus()
+}
+
+// renderTemplatePickerContent renders the template list for the picker modal.
+func (a App) renderTemplatePickerContent() string {
+	var b strings.Builder
+	for i, name := range a.config.TemplateNames() {
+		t := a.config.Templates[name]
+		detail := t.Folder
+		if len(t.Tags) > 0 {
+			detail = strings.TrimSpace(detail + " #" + strings.Join(t.Tags, " #"))
+		}
+		if i == a.templateCursor {
+			b.WriteString(a.styles.ItemSelected.Render("▸ " + name))
+		} else {
+			b.WriteString("  " + name)
+		}
+		if detail != "" {
+			b.WriteString("  " + a.styles.Empty.Render(detail))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(a.renderHintsInline([]Hint{
+		{Key: "Enter", Desc: "use template"},
+		{Key: "Esc", Desc: "close"},
+	}))
+	return b.String()
+}
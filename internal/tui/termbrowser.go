@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errNoTerminalBrowser is returned when terminalBrowserCommand isn't configured.
+var errNoTerminalBrowser = errors.New("set terminalBrowserCommand in config (e.g. \"w3m\") to use gb")
+
+// terminalBrowserFinishedMsg is sent when the terminal browser exits.
+type terminalBrowserFinishedMsg struct {
+	err error
+}
+
+// terminalBrowserCommand splits command into name and args, substituting
+// {url} or appending the URL when there is no placeholder.
+func terminalBrowserCommand(command, url string) ([]string, error) {
+	parts := strings.Fields(command)
+	if len(parts) == 0 {
+		return nil, errNoTerminalBrowser
+	}
+	substituted := false
+	for i, p := range parts {
+		if strings.Contains(p, "{url}") {
+			parts[i] = strings.ReplaceAll(p, "{url}", url)
+			substituted = true
+		}
+	}
+	if !substituted {
+		parts = append(parts, url)
+	}
+	return parts, nil
+}
+
+// openInTerminalBrowser suspends the TUI and shows the bookmark in the
+// configured terminal browser (w3m, lynx, ...), resuming when it exits.
+func (a *App) openInTerminalBrowser(item *Item) tea.Cmd {
+	if item == nil || item.IsFolder() || item.Bookmark == nil {
+		return nil
+	}
+
+	args, err := terminalBrowserCommand(a.config.TerminalBrowserCommand, item.Bookmark.URL)
+	if err != nil {
+		return a.setMessage(MessageError, err.Error())
+	}
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return a.setMessage(MessageError, "Terminal browser not found: "+args[0])
+	}
+
+	if b := a.store.GetBookmarkByID(item.Bookmark.ID); b != nil {
+		b.MarkVisited(time.Now())
+		a.saveStore()
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return terminalBrowserFinishedMsg{err: err}
+	})
+}
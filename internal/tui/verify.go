@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nikbrunner/bm/internal/culler"
+	"github.com/nikbrunner/bm/internal/model"
+)
+
+// verifyOpenTimeout bounds the liveness check before opening a bookmark.
+const verifyOpenTimeout = 5 * time.Second
+
+// verifyOpenMsg carries the liveness check for the bookmark waiting to open.
+type verifyOpenMsg struct {
+	result culler.Result
+}
+
+// startVerifyOpen checks b's URL before opening it. A healthy link opens
+// right away; a dead or unreachable one asks first.
+func (a App) startVerifyOpen(b *model.Bookmark) (tea.Model, tea.Cmd) {
+	a.verifyOpen = VerifyOpenState{Bookmark: *b}
+	a.mode = ModeVerifyOpen
+	check := a.linkChecker
+	bookmark := *b
+	return a, func() tea.Msg {
+		return verifyOpenMsg{result: check(bookmark)}
+	}
+}
+
+// handleVerifyOpen opens the bookmark once its link checks out, and
+// otherwise keeps the modal up with a warning.
+func (a App) handleVerifyOpen(msg verifyOpenMsg) (tea.Model, tea.Cmd) {
+	// Cancelled, or a stale check for another bookmark
+	if a.mode != ModeVerifyOpen || a.verifyOpen.Checked || msg.result.Bookmark == nil ||
+		msg.result.Bookmark.ID != a.verifyOpen.Bookmark.ID {
+		return a, nil
+	}
+	if msg.result.Status == culler.Healthy {
+		a.mode = ModeNormal
+		return a.openBookmarkURL(a.verifyOpen.Bookmark)
+	}
+	a.verifyOpen.Checked = true
+	a.verifyOpen.Result = msg.result
+	return a, nil
+}
+
+// updateVerifyOpen handles keys while a link is checked or flagged:
+// Enter/o opens anyway, Esc/q/n cancels.
+func (a App) updateVerifyOpen(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "o":
+		a.mode = ModeNormal
+		return a.openBookmarkURL(a.verifyOpen.Bookmark)
+	case "esc", "q", "n":
+		a.mode = ModeNormal
+		return a, a.setMessage(MessageInfo, "Not opened")
+	}
+	return a, nil
+}
+
+// verifyOpenProblem describes why the checked link looks broken.
+func verifyOpenProblem(r culler.Result) string {
+	if r.Status == culler.Dead {
+		return "Link looks dead (" + strconv.Itoa(r.StatusCode) + " " + http.StatusText(r.StatusCode) + ")"
+	}
+	return "Link unreachable: " + r.Error
+}
+
+// renderVerifyOpenContent renders the check in progress or its warning.
+func (a App) renderVerifyOpenContent() string {
+	b := a.verifyOpen.Bookmark
+	s := a.styles.URL.Render(b.URL) + "\n\n"
+	if !a.verifyOpen.Checked {
+		s += a.styles.Empty.Render("Checking link...") + "\n\n"
+		return s + a.renderHintsInline([]Hint{
+			{Key: "Enter", Desc: "open now"},
+			{Key: "Esc", Desc: "cancel"},
+		})
+	}
+	s += verifyOpenProblem(a.verifyOpen.Result) + "\n\n"
+	return s + a.renderHintsInline([]Hint{
+		{Key: "Enter", Desc: "open anyway"},
+		{Key: "Esc", Desc: "cancel"},
+	})
+}
@@ -5,9 +5,11 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/storage"
 	"github.com/nikbrunner/bm/internal/tui/layout"
 )
 
@@ -23,20 +25,25 @@ func (a App) renderView() string {
 		return a.renderModal()
 	}
 
+	// Render help bar first so panes can shrink (or grow) to its actual height
+	helpBar := a.renderHelpBar()
+	helpBarOverflow := lipgloss.Height(helpBar) - a.layoutConfig.Help.ReservedLines
+
 	// Calculate pane dimensions using layout config
-	paneHeight := layout.CalculatePaneHeight(a.height, a.layoutConfig.Pane)
+	paneHeight := layout.CalculatePaneHeight(a.height-helpBarOverflow, a.layoutConfig.Pane)
 	hasPinnedItems := len(a.pinnedItems) > 0
 	atRoot := a.browser.CurrentFolderID == nil
 	paneLayout := layout.CalculatePaneWidth(a.width, hasPinnedItems, atRoot, a.layoutConfig.Pane)
 	paneWidth := paneLayout.Width
+	mainWidth := paneLayout.MainWidth
 
 	var columns string
 
 	if hasPinnedItems && atRoot {
 		// At root with pinned items: 3 panes (pinned replaces parent since both would show "bm/bookmarks")
 		pinnedPane := a.renderPinnedPane(paneWidth, paneHeight)
-		middlePane := a.renderCurrentPane(paneWidth, paneHeight)
-		rightPane := a.renderPreviewPane(paneWidth, paneHeight)
+		middlePane := a.renderCurrentPane(mainWidth, paneHeight)
+		rightPane := a.renderPreviewPane(mainWidth, paneHeight)
 
 		columns = lipgloss.JoinHorizontal(
 			lipgloss.Top,
@@ -48,8 +55,8 @@ func (a App) renderView() string {
 		// In subfolder with pinned items: 4 panes (pinned | parent | current | preview)
 		pinnedPane := a.renderPinnedPane(paneWidth, paneHeight)
 		leftPane := a.renderParentPane(paneWidth, paneHeight)
-		middlePane := a.renderCurrentPane(paneWidth, paneHeight)
-		rightPane := a.renderPreviewPane(paneWidth, paneHeight)
+		middlePane := a.renderCurrentPane(mainWidth, paneHeight)
+		rightPane := a.renderPreviewPane(mainWidth, paneHeight)
 
 		columns = lipgloss.JoinHorizontal(
 			lipgloss.Top,
@@ -61,8 +68,8 @@ func (a App) renderView() string {
 	} else {
 		// No pinned items: 3 panes (parent | current | preview)
 		leftPane := a.renderParentPane(paneWidth, paneHeight)
-		middlePane := a.renderCurrentPane(paneWidth, paneHeight)
-		rightPane := a.renderPreviewPane(paneWidth, paneHeight)
+		middlePane := a.renderCurrentPane(mainWidth, paneHeight)
+		rightPane := a.renderPreviewPane(mainWidth, paneHeight)
 
 		columns = lipgloss.JoinHorizontal(
 			lipgloss.Top,
@@ -75,9 +82,6 @@ func (a App) renderView() string {
 	// Add breadcrumb above columns
 	breadcrumb := a.renderBreadcrumb()
 
-	// Add help bar at bottom
-	helpBar := a.renderHelpBar()
-
 	content := a.styles.App.Render(
 		lipgloss.JoinVertical(lipgloss.Left, breadcrumb, columns, helpBar),
 	)
@@ -101,10 +105,54 @@ func (a App) renderBreadcrumb() string {
 	// Calculate available width (terminal width minus app padding: left=2, right=2)
 	availableWidth := a.width - 4
 
-	// Truncate from left if path is too long
-	path = layout.TruncatePathFromLeft(path, availableWidth, a.layoutConfig.Text)
+	// Reserve room for the right-aligned status, dropping it when the path would get too short
+	status := a.breadcrumbStatus()
+	pathWidth := availableWidth
+	if status != "" {
+		pathWidth = availableWidth - layout.VisibleLength(status) - 2 // breadcrumb padding + gap
+		if pathWidth < minBreadcrumbPathWidth {
+			status = ""
+			pathWidth = availableWidth
+		}
+	}
+
+	// Shorten the path if it is too long, keeping the current folder visible
+	if a.config.BreadcrumbTruncation == storage.BreadcrumbTruncateMiddle {
+		path = layout.TruncatePathMiddle(path, pathWidth, a.layoutConfig.Text)
+	} else {
+		path = layout.TruncatePathFromLeft(path, pathWidth, a.layoutConfig.Text)
+	}
 
-	return a.styles.Breadcrumb.Render(path)
+	return layout.JoinLeftRight(a.styles.Breadcrumb.Render(path), a.styles.HintDesc.Render(status), availableWidth)
+}
+
+// minBreadcrumbPathWidth is the narrowest path the breadcrumb status may squeeze it to.
+const minBreadcrumbPathWidth = 20
+
+// breadcrumbStatus returns the enabled right-aligned breadcrumb elements:
+// broken links below the current folder (from the last cull), the cursor
+// position in the focused pane and the clock.
+func (a App) breadcrumbStatus() string {
+	var parts []string
+	if len(a.brokenIDs) > 0 {
+		if n := a.store.CountBookmarksInSubtree(a.browser.CurrentFolderID, a.brokenIDs); n > 0 {
+			parts = append(parts, "⚠ "+strconv.Itoa(n)+" broken")
+		}
+	}
+	if a.config.ShowItemCounter {
+		cursor, total := a.browser.Cursor, len(a.getDisplayItems())
+		if a.focusedPane == PanePinned {
+			cursor, total = a.pinnedCursor, len(a.getDisplayPinnedItems())
+		}
+		if total > 0 {
+			cursor++
+		}
+		parts = append(parts, strconv.Itoa(cursor)+"/"+strconv.Itoa(total))
+	}
+	if a.config.ShowClock {
+		parts = append(parts, time.Now().Format("15:04"))
+	}
+	return strings.Join(parts, "  ")
 }
 
 // renderPinnedPane renders the leftmost pane with pinned items.
@@ -118,16 +166,32 @@ func (a App) renderPinnedPane(width, height int) string {
 	// Pinned section header
 	content.WriteString("── Pinned ──\n")
 
-	visibleHeight := layout.CalculateVisibleHeight(height, a.layoutConfig.Pane.PinnedHeaderReduction)
+	// Show filter input or indicator below the header
+	headerLines := a.layoutConfig.Pane.PinnedHeaderReduction
+	filtering := a.mode == ModeFilter && a.focusedPane == PanePinned
+	if filtering {
+		content.WriteString("/" + a.search.PinnedFilterInput.View() + "\n")
+		headerLines++
+	} else if a.search.PinnedFilterQuery != "" {
+		content.WriteString(a.styles.Tag.Render("/"+a.search.PinnedFilterQuery) + "\n")
+		headerLines++
+	}
+
+	visibleHeight := layout.CalculateVisibleHeight(height, headerLines)
 	itemWidth := layout.CalculateItemWidth(width, a.layoutConfig.Pane)
 
-	if len(a.pinnedItems) == 0 {
-		content.WriteString(a.styles.Empty.Render("(no pinned items)"))
+	pinnedItems := a.getDisplayPinnedItems()
+	if len(pinnedItems) == 0 {
+		if a.search.PinnedFilterQuery != "" {
+			content.WriteString(a.styles.Empty.Render("(no matches)"))
+		} else {
+			content.WriteString(a.styles.Empty.Render("(no pinned items)"))
+		}
 	} else {
 		// Calculate viewport offset to keep cursor visible
-		offset := layout.CalculateViewportOffset(a.pinnedCursor, len(a.pinnedItems), visibleHeight)
+		offset := layout.CalculateViewportOffset(a.pinnedCursor, len(pinnedItems), visibleHeight)
 
-		for i, item := range a.pinnedItems {
+		for i, item := range pinnedItems {
 			// Skip items before viewport
 			if i < offset {
 				continue
@@ -164,6 +228,9 @@ func (a App) renderPinnedItem(item Item, selected bool, maxWidth int, index int)
 	prefix := fmt.Sprintf("[%d] ", index+1)
 
 	if item.IsFolder() {
+		if item.Folder.Locked {
+			prefix = "! " + prefix
+		}
 		text = item.Title()
 		suffix = "/"
 	} else {
@@ -290,11 +357,54 @@ func (a App) renderModal() string {
 			title.WriteString(action + " " + itemType + "?\n\n")
 			content.WriteString("\"" + itemName + "\"\n\n")
 			content.WriteString(a.styles.Help.Render("This action cannot be undone.") + "\n\n")
-			content.WriteString(a.renderHintsInline([]Hint{
-				{Key: "Enter", Desc: "confirm"},
-				{Key: "Esc", Desc: "cancel"},
-			}))
+			if a.canDeleteKeepingContents() {
+				content.WriteString(a.renderHintsInline([]Hint{
+					{Key: "Enter", Desc: "delete with contents"},
+					{Key: "K", Desc: "keep contents"},
+					{Key: "Esc", Desc: "cancel"},
+				}))
+			} else {
+				content.WriteString(a.renderHintsInline([]Hint{
+					{Key: "Enter", Desc: "confirm"},
+					{Key: "Esc", Desc: "cancel"},
+				}))
+			}
+		}
+
+	case ModeConfirmLargeOp:
+		switch a.largeOp.Kind {
+		case LargeOpOpen:
+			title.WriteString("Open " + strconv.Itoa(a.largeOp.Count) + " URLs?\n\n")
+			content.WriteString(a.styles.Help.Render("Each one opens in a new browser tab.") + "\n\n")
+		default:
+			action := "Paste"
+			if a.largeOp.Kind == LargeOpMove {
+				action = "Move"
+			}
+			title.WriteString(action + " " + strconv.Itoa(a.largeOp.Count) + " items?\n\n")
+			content.WriteString(a.styles.Help.Render("This includes everything inside the affected folders.") + "\n\n")
+		}
+		content.WriteString(a.renderHintsInline([]Hint{
+			{Key: "Enter", Desc: "confirm"},
+			{Key: "Esc", Desc: "cancel"},
+		}))
+
+	case ModeConfirmPruneEmpty:
+		empty := a.store.EmptyFolders()
+		title.WriteString("Remove " + strconv.Itoa(len(empty)) + " empty folders?\n\n")
+		const maxListed = 10
+		for i, f := range empty {
+			if i == maxListed {
+				content.WriteString(a.styles.Empty.Render("… and "+strconv.Itoa(len(empty)-maxListed)+" more") + "\n")
+				break
+			}
+			content.WriteString(a.store.GetFolderPath(&f.ID) + "\n")
 		}
+		content.WriteString("\n" + a.styles.Help.Render("Pinned and locked folders are kept.") + "\n\n")
+		content.WriteString(a.renderHintsInline([]Hint{
+			{Key: "Enter", Desc: "confirm"},
+			{Key: "Esc", Desc: "cancel"},
+		}))
 
 	case ModeSearch:
 		// Render full-screen fuzzy finder
@@ -305,13 +415,96 @@ func (a App) renderModal() string {
 		return a.renderHelpOverlay()
 
 	case ModeQuickAdd:
-		title.WriteString("AI Quick Add\n\n")
+		if a.quickAdd.ReadLater {
+			title.WriteString("Read Later\n\n")
+		} else if a.quickAdd.Inbox {
+			title.WriteString("Quick Capture\n\n")
+		} else if a.quickAdd.Template != "" {
+			title.WriteString("Add from Template: " + a.quickAdd.Template + "\n\n")
+		} else {
+			title.WriteString("AI Quick Add\n\n")
+		}
 		content.WriteString("URL:\n")
 		content.WriteString(a.quickAdd.Input.View())
 
+	case ModeSnooze:
+		title.WriteString("Snooze Bookmark\n\n")
+		content.WriteString("Hide for (m/h/d/w):\n")
+		content.WriteString(a.modal.SnoozeInput.View())
+
+	case ModeRemind:
+		title.WriteString("Remind Me\n\n")
+		content.WriteString("Remind in (m/h/d/w):\n")
+		content.WriteString(a.modal.RemindInput.View())
+
+	case ModeGotoIndex:
+		title.WriteString("Go to Item\n\n")
+		content.WriteString("Item number (1-" + strconv.Itoa(len(a.getDisplayItems())) + "):\n")
+		content.WriteString(a.modal.GotoInput.View())
+
+	case ModeReminders:
+		title.WriteString(reminderSummary(len(a.store.GetDueReminders(time.Now()))) + "\n\n")
+		content.WriteString(a.renderRemindersContent())
+
+	case ModeMergeTags:
+		if a.tagMerge.From == "" {
+			title.WriteString("Merge Tags\n\n")
+		} else {
+			title.WriteString("Merge #" + a.tagMerge.From + " into\n\n")
+		}
+		content.WriteString(a.renderTagMergeContent())
+
+	case ModePickTemplate:
+		title.WriteString("Add from Template\n\n")
+		content.WriteString(a.renderTemplatePickerContent())
+
+	case ModeDomains:
+		if a.domains.Domain == "" {
+			title.WriteString("Domains\n\n")
+		} else {
+			title.WriteString(a.domains.Domain + " (" + strconv.Itoa(len(a.selectedDomainBookmarks())) + ")\n\n")
+		}
+		content.WriteString(a.renderDomainsContent())
+
+	case ModeVerifyOpen:
+		title.WriteString("Open " + a.verifyOpen.Bookmark.Title + "\n\n")
+		content.WriteString(a.renderVerifyOpenContent())
+
+	case ModePeek:
+		title.WriteString("Peek " + a.peek.Bookmark.Title + "\n\n")
+		content.WriteString(a.renderPeekContent())
+
+	case ModeDetails:
+		// The blank lines go with the content: padded to the title's width,
+		// they would indent the first label
+		title.WriteString("Details")
+		content.WriteString("\n\n" + a.renderDetailsContent())
+
+	case ModeOnboarding:
+		title.WriteString("Welcome to bm\n\n")
+		content.WriteString(a.renderOnboardingContent())
+
+	case ModeAlias:
+		title.WriteString("Bookmark Alias\n\n")
+		content.WriteString("Open with bm <alias> (empty removes):\n")
+		content.WriteString(a.modal.AliasInput.View())
+
+	case ModePromote:
+		title.WriteString("Promote to Folder\n\n")
+		content.WriteString("New folder next to the bookmark:\n")
+		content.WriteString(a.modal.PromoteInput.View())
+		related := "[ ]"
+		if a.modal.PromoteRelated {
+			related = "[x]"
+		}
+		content.WriteString("\n\n" + related + " also move bookmarks sharing a tag")
+
 	case ModeQuickAddLoading:
 		title.WriteString("AI Quick Add\n\n")
 		content.WriteString("Analyzing link...\n\n")
+		if a.quickAdd.Regenerations > 0 {
+			content.WriteString(fmt.Sprintf("Regenerating (%d of %d)\n\n", a.quickAdd.Regenerations, maxQuickAddRegenerations))
+		}
 		content.WriteString(a.styles.Empty.Render("Please wait while AI suggests title, folder, and tags"))
 
 	case ModeReadLaterLoading:
@@ -329,6 +522,19 @@ func (a App) renderModal() string {
 	case ModeCullMenu:
 		return a.renderCullMenu()
 
+	case ModeBulkMenu:
+		return a.renderBulkMenu()
+
+	case ModeBulkTag:
+		title.WriteString("Tag Selection\n\n")
+		content.WriteString(fmt.Sprintf("Add tags to %d bookmarks:\n", a.countSelectedBookmarks()))
+		content.WriteString(a.bulk.TagInput.View())
+
+	case ModeBulkExport:
+		title.WriteString("Export Selection\n\n")
+		content.WriteString(fmt.Sprintf("Export %d items to (.html, .csv or .json):\n", a.selection.Count()))
+		content.WriteString(a.bulk.PathInput.View())
+
 	case ModeCullLoading:
 		return a.renderCullLoading()
 
@@ -462,15 +668,16 @@ func (a App) renderCurrentPane(width, height int) string {
 	var content strings.Builder
 
 	// Calculate header lines for filter
+	filtering := a.mode == ModeFilter && a.focusedPane != PanePinned
 	headerLines := 0
-	if a.mode == ModeFilter || a.search.FilterQuery != "" {
+	if filtering || a.search.FilterQuery != "" {
 		headerLines = 1
 	}
 	visibleHeight := layout.CalculateVisibleHeight(height, headerLines)
 	itemWidth := layout.CalculateItemWidth(width, a.layoutConfig.Pane)
 
 	// Show filter input or indicator at top
-	if a.mode == ModeFilter {
+	if filtering {
 		content.WriteString("/" + a.search.FilterInput.View() + "\n")
 	} else if a.search.FilterQuery != "" {
 		filterIndicator := a.styles.Tag.Render("/" + a.search.FilterQuery)
@@ -489,6 +696,7 @@ func (a App) renderCurrentPane(width, height int) string {
 	} else {
 		// Calculate viewport offset to keep cursor visible
 		offset := layout.CalculateViewportOffset(a.browser.Cursor, len(displayItems), visibleHeight)
+		digits := len(strconv.Itoa(len(displayItems)))
 
 		for i, item := range displayItems {
 			// Skip items before viewport
@@ -501,7 +709,12 @@ func (a App) renderCurrentPane(width, height int) string {
 			}
 			// Only show selection when browser pane is focused
 			isSelected := a.focusedPane == PaneBrowser && i == a.browser.Cursor
-			line := a.renderItem(item, isSelected, itemWidth)
+			index := ""
+			if a.itemNumbers {
+				// Right-aligned so titles line up, e.g. " 9 " and "10 "
+				index = fmt.Sprintf("%*d ", digits, i+1)
+			}
+			line := a.renderItemWithIndex(item, isSelected, itemWidth, index)
 			content.WriteString(line + "\n")
 		}
 	}
@@ -534,7 +747,9 @@ func (a App) renderPreviewPane(width, height int) string {
 			folderID := item.Folder.ID
 			children := a.getItemsForFolder(&folderID)
 
-			if len(children) == 0 {
+			if a.folderStats {
+				content.WriteString(a.renderFolderStats(folderID, itemWidth))
+			} else if len(children) == 0 {
 				content.WriteString(a.styles.Empty.Render("(empty folder)"))
 			} else {
 				// Limit to visible height
@@ -548,7 +763,11 @@ func (a App) renderPreviewPane(width, height int) string {
 		} else {
 			// Show bookmark details
 			b := item.Bookmark
-			content.WriteString(a.styles.Title.Render(b.Title) + "\n\n")
+			// Title and tags wrap to the pane width so long values don't break the borders
+			for _, line := range layout.WrapText(b.Title, itemWidth) {
+				content.WriteString(a.styles.Title.Render(line) + "\n")
+			}
+			content.WriteString("\n")
 
 			// URL (potentially truncated)
 			url, _ := layout.TruncateText(b.URL, itemWidth, a.layoutConfig.Text)
@@ -560,29 +779,52 @@ func (a App) renderPreviewPane(width, height int) string {
 				for i, tag := range b.Tags {
 					tags[i] = "#" + tag
 				}
-				content.WriteString(a.styles.Tag.Render(strings.Join(tags, " ")) + "\n\n")
+				for _, line := range layout.WrapText(strings.Join(tags, " "), itemWidth) {
+					content.WriteString(a.styles.Tag.Render(line) + "\n")
+				}
+				content.WriteString("\n")
+			}
+
+			if b.Priority > model.PriorityNone {
+				content.WriteString(a.styles.Tag.Render("Priority: "+model.PriorityName(b.Priority)) + "\n\n")
 			}
 
 			// Dates
 			content.WriteString(a.styles.Date.Render(
-				fmt.Sprintf("Created: %s", b.CreatedAt.Format("2006-01-02")),
+				fmt.Sprintf("Created: %s", a.config.FormatDate(b.CreatedAt)),
 			) + "\n")
 
+			if modified := a.config.FormatDate(b.LastModified()); modified != a.config.FormatDate(b.CreatedAt) {
+				content.WriteString(a.styles.Date.Render("Modified: "+modified) + "\n")
+			}
+
 			if b.VisitedAt != nil {
 				content.WriteString(a.styles.Date.Render(
-					fmt.Sprintf("Visited: %s", b.VisitedAt.Format("2006-01-02")),
+					fmt.Sprintf("Visited: %s", a.config.FormatDate(*b.VisitedAt)),
 				))
 			}
 		}
 	}
 
+	// Clip wrapped content so it can't push the pane taller than its neighbours
+	lines := strings.Split(strings.TrimRight(content.String(), "\n"), "\n")
+	if len(lines) > visibleHeight {
+		lines = lines[:visibleHeight]
+	}
+
 	return a.styles.Pane.
 		Width(width).
 		Height(height).
-		Render(strings.TrimRight(content.String(), "\n"))
+		Render(strings.Join(lines, "\n"))
 }
 
 func (a App) renderItem(item Item, isCursor bool, maxWidth int) string {
+	return a.renderItemWithIndex(item, isCursor, maxWidth, "")
+}
+
+// renderItemWithIndex renders an item with index (e.g. "14 ") in front of
+// its markers; an empty index renders it like renderItem.
+func (a App) renderItemWithIndex(item Item, isCursor bool, maxWidth int, index string) string {
 	var prefix, text, suffix string
 	var isPinned bool
 	isMarked := a.selection.IsSelected(item.ID())
@@ -592,6 +834,9 @@ func (a App) renderItem(item Item, isCursor bool, maxWidth int) string {
 		if isPinned {
 			prefix = "* "
 		}
+		if item.Folder.Locked {
+			prefix = "! " + prefix
+		}
 		text = item.Title()
 		suffix = "/"
 	} else {
@@ -599,6 +844,12 @@ func (a App) renderItem(item Item, isCursor bool, maxWidth int) string {
 		if isPinned {
 			prefix = "* "
 		}
+		if p := item.Bookmark.Priority; p > model.PriorityNone {
+			prefix += strings.Repeat("↑", p) + " "
+		}
+		if a.isRecentlyAdded(*item.Bookmark) {
+			prefix = "+ " + prefix
+		}
 		text = item.Title()
 	}
 
@@ -607,12 +858,21 @@ func (a App) renderItem(item Item, isCursor bool, maxWidth int) string {
 		prefix = "▸ " + prefix
 	}
 
+	prefix = index + prefix
+
 	// Truncate if too long using layout function
 	line, _ := layout.TruncateWithPrefixSuffix(text, maxWidth, prefix, suffix, a.layoutConfig.Text)
 
+	// Tags take whatever room the title leaves
+	var tags string
+	if a.config.ShowTagsInList && !item.IsFolder() {
+		tags = inlineTags(item.Bookmark.Tags, maxWidth-layout.VisibleLength(line), a.layoutConfig.Text.Ellipsis)
+	}
+
 	// Determine which style to use based on cursor and selection state
 	needsHighlight := isCursor || isMarked
 	if needsHighlight {
+		line += tags
 		// Pad to fill width for highlight
 		for len(line) < maxWidth {
 			line += " "
@@ -624,7 +884,67 @@ func (a App) renderItem(item Item, isCursor bool, maxWidth int) string {
 		}
 		return a.styles.ItemMarked.Render(line)
 	}
-	return a.styles.Item.Render(line)
+	if !item.IsFolder() && item.Bookmark.Archived {
+		return a.styles.ItemArchived.Render(line + tags)
+	}
+	return a.styles.Item.Render(line) + a.styles.Tag.Render(tags)
+}
+
+// inlineTags renders tags as " #a #b" in at most width runes. Tags that don't
+// fit are dropped and marked with ellipsis; if none fit, it returns "".
+func inlineTags(tags []string, width int, ellipsis string) string {
+	more := 1 + utf8.RuneCountInString(ellipsis) // " ..." after the last tag shown
+	var s strings.Builder
+	used := 0
+	for i, tag := range tags {
+		part := " #" + tag
+		need := utf8.RuneCountInString(part)
+		if i < len(tags)-1 {
+			need += more // keep room to mark the tags after this one
+		}
+		if used+need > width {
+			if i == 0 {
+				return ""
+			}
+			return s.String() + " " + ellipsis
+		}
+		s.WriteString(part)
+		used += utf8.RuneCountInString(part)
+	}
+	return s.String()
+}
+
+// isRecentlyAdded reports whether a bookmark was created within the configured recency window.
+func (a App) isRecentlyAdded(b model.Bookmark) bool {
+	if a.config.RecentWindowMinutes <= 0 {
+		return false
+	}
+	window := time.Duration(a.config.RecentWindowMinutes) * time.Minute
+	return time.Since(b.CreatedAt) < window
+}
+
+// renderFolderStats renders the expanded folder preview (ts): direct and
+// recursive counts plus the most used tags in the subtree.
+func (a App) renderFolderStats(folderID string, width int) string {
+	stats := a.store.FolderStats(folderID, 3)
+
+	line := func(text string) string {
+		text, _ = layout.TruncateText(text, width, a.layoutConfig.Text)
+		return text
+	}
+
+	var b strings.Builder
+	b.WriteString(a.styles.Title.Render("Contents") + "\n")
+	b.WriteString(line(fmt.Sprintf("%d bookmarks, %d folders here", stats.DirectBookmarks, stats.DirectFolders)) + "\n")
+	b.WriteString(line(fmt.Sprintf("%d bookmarks, %d folders in total", stats.TotalBookmarks, stats.TotalFolders)) + "\n")
+
+	if len(stats.TopTags) > 0 {
+		b.WriteString("\n" + a.styles.Title.Render("Top tags") + "\n")
+		for _, tc := range stats.TopTags {
+			b.WriteString(a.styles.Tag.Render(line("#"+tc.Tag+" ("+strconv.Itoa(tc.Count)+")")) + "\n")
+		}
+	}
+	return b.String()
 }
 
 // renderFuzzyFinder renders the fuzzy finder as a full-screen brutalist view.
@@ -645,6 +965,10 @@ func (a App) renderFuzzyFinder() string {
 	switch a.search.Source {
 	case SourceRecent:
 		title = "Recent Bookmarks"
+	case SourceUntitled:
+		title = "Untitled Bookmarks (Ctrl+E to rename)"
+	case SourceRecentlyVisited:
+		title = "Recently Visited"
 	default:
 		title = "Find"
 	}
@@ -659,8 +983,18 @@ func (a App) renderFuzzyFinder() string {
 				break
 			}
 			isSelected := i == a.search.FuzzyCursor
-			// For SourceRecent, show folder path; for SourceAll, no path
-			showFolderPath := a.search.Source == SourceRecent
+			// Show the folder path except in the global finder (SourceAll)
+			showFolderPath := a.search.Source != SourceAll
+			if a.config.FinderNumberKeys {
+				// Faint 1-9 for the number keys, blank beyond so titles stay aligned
+				number := "  "
+				if i < 9 {
+					number = strconv.Itoa(i+1) + " "
+				}
+				line := a.renderFuzzyItemWithPath(match, isSelected, listItemWidth-2, showFolderPath)
+				results.WriteString(a.styles.Empty.Render(number) + line + "\n")
+				continue
+			}
 			line := a.renderFuzzyItemWithPath(match, isSelected, listItemWidth, showFolderPath)
 			results.WriteString(line + "\n")
 		}
@@ -812,17 +1146,12 @@ func (a App) renderFuzzyItemWithPath(match fuzzyMatch, selected bool, maxWidth i
 
 	// Get folder path if showing paths (for bookmarks only)
 	var folderPath string
+	pathIndexes := match.PathIndexes
 	if showPath && !match.Item.IsFolder() {
-		folderID := match.Item.Bookmark.FolderID
-		if folderID == nil {
+		folderPath = a.finderPath(match.Item)
+		if folderPath == "" {
 			folderPath = "─" // Root-level bookmark
-		} else {
-			path := a.store.GetFolderPath(folderID)
-			// Remove leading slash if present
-			if len(path) > 0 && path[0] == '/' {
-				path = path[1:]
-			}
-			folderPath = path
+			pathIndexes = nil
 		}
 	}
 
@@ -836,28 +1165,7 @@ func (a App) renderFuzzyItemWithPath(match fuzzyMatch, selected bool, maxWidth i
 	}
 	titleMaxWidth := maxWidth - pathColWidth
 
-	// Build highlighted string with ANSI codes for matched characters
-	var line strings.Builder
-
-	// Apply highlighting to matched characters
-	matchSet := make(map[int]bool)
-	for _, idx := range match.MatchedIndexes {
-		matchSet[idx] = true
-	}
-
-	for i, r := range title {
-		if matchSet[i] {
-			// Highlight matched character (bold/underline)
-			line.WriteString("\033[1;4m")
-			line.WriteRune(r)
-			line.WriteString("\033[22;24m")
-		} else {
-			line.WriteRune(r)
-		}
-	}
-	line.WriteString(suffix)
-
-	result := line.String()
+	result := highlightMatches(title, match.MatchedIndexes) + suffix
 
 	// Truncate title if needed
 	if layout.VisibleLength(result) > titleMaxWidth {
@@ -874,15 +1182,24 @@ func (a App) renderFuzzyItemWithPath(match fuzzyMatch, selected bool, maxWidth i
 	if showPath && folderPath != "" && pathColWidth > 3 {
 		pathRunes := []rune(folderPath)
 		pathVisualLen := len(pathRunes) // visual length in characters
+		pathText := highlightMatches(folderPath, pathIndexes)
 
 		// Truncate path from left if needed (keep rightmost part with ellipsis)
 		if pathVisualLen > pathColWidth-1 {
 			keepLen := pathColWidth - 2 // space for "…" and at least one space padding
 			if keepLen > 0 && keepLen < pathVisualLen {
-				folderPath = "…" + string(pathRunes[pathVisualLen-keepLen:])
+				// Shift the matched byte offsets past the cut-off prefix
+				cut := len(string(pathRunes[:pathVisualLen-keepLen]))
+				var kept []int
+				for _, idx := range pathIndexes {
+					if idx >= cut {
+						kept = append(kept, idx-cut)
+					}
+				}
+				pathText = "…" + highlightMatches(folderPath[cut:], kept)
 				pathVisualLen = keepLen + 1 // ellipsis + kept chars
 			} else {
-				folderPath = "…"
+				pathText = "…"
 				pathVisualLen = 1
 			}
 		}
@@ -892,7 +1209,7 @@ func (a App) renderFuzzyItemWithPath(match fuzzyMatch, selected bool, maxWidth i
 		if padding < 0 {
 			padding = 0
 		}
-		pathPadded := strings.Repeat(" ", padding) + folderPath
+		pathPadded := strings.Repeat(" ", padding) + pathText
 		// Apply dimmed style to path
 		result += a.styles.Empty.Render(pathPadded)
 	}
@@ -903,12 +1220,58 @@ func (a App) renderFuzzyItemWithPath(match fuzzyMatch, selected bool, maxWidth i
 	return a.styles.Item.Render(result)
 }
 
+// highlightMatches marks the characters of s at the given byte offsets
+// bold and underlined, as fuzzy.Match reports them.
+func highlightMatches(s string, indexes []int) string {
+	if len(indexes) == 0 {
+		return s
+	}
+	matchSet := make(map[int]bool, len(indexes))
+	for _, idx := range indexes {
+		matchSet[idx] = true
+	}
+
+	var line strings.Builder
+	for i, r := range s {
+		if matchSet[i] {
+			line.WriteString("\033[1;4m")
+			line.WriteRune(r)
+			line.WriteString("\033[22;24m")
+		} else {
+			line.WriteRune(r)
+		}
+	}
+	return line.String()
+}
+
 func (a App) renderHelpBar() string {
+	hbLayout := a.helpBarLayout()
+
+	// Short terminals: a single line with the message or the most important local hints
+	if hbLayout.Compact {
+		if a.messageText != "" {
+			return a.renderMessageLine()
+		}
+		if a.saveBanner() != "" {
+			return a.renderSaveErrorLine()
+		}
+		return a.renderHintsFitting(a.getContextualHints().All(), a.width-4)
+	}
+
+	label := func(full, short string) string {
+		if hbLayout.Abbreviate {
+			return a.styles.HintLabel.Render(short)
+		}
+		return a.styles.HintLabel.Render(full)
+	}
+
 	var lines []string
 
 	// Line 1: Empty spacer OR message (message replaces the gap)
 	if a.messageText != "" {
 		lines = append(lines, a.renderMessageLine())
+	} else if a.saveBanner() != "" {
+		lines = append(lines, a.renderSaveErrorLine()) // Stays until a save succeeds
 	} else {
 		lines = append(lines, "") // Empty line provides gap when no message
 	}
@@ -921,20 +1284,44 @@ func (a App) renderHelpBar() string {
 	// Line 3: Local (contextual) keyboard hints
 	localHints := a.renderHints(a.getContextualHints())
 	if localHints != "" {
-		lines = append(lines, a.styles.HintLabel.Render("Local  ")+localHints)
+		lines = append(lines, label("Local  ", "L ")+localHints)
 	}
 
 	// Line 4: Global keyboard hints (only in normal mode - modals have their own flow)
 	if a.mode == ModeNormal {
 		globalHints := a.renderHintSlice(a.getGlobalHints())
 		if globalHints != "" {
-			lines = append(lines, a.styles.HintLabel.Render("Global ")+globalHints)
+			lines = append(lines, label("Global ", "G ")+globalHints)
 		}
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// helpBarLayout returns how the help bar adapts to the current terminal size.
+func (a App) helpBarLayout() layout.HelpBarLayout {
+	return layout.CalculateHelpBarLayout(a.width, a.height, a.layoutConfig.Help)
+}
+
+// renderHintsFitting renders hints in order, dropping those that don't fit in maxWidth.
+func (a App) renderHintsFitting(hints []Hint, maxWidth int) string {
+	var parts []string
+	width := 0
+	for _, h := range hints {
+		part := a.renderHint(h)
+		partWidth := lipgloss.Width(part)
+		if len(parts) > 0 {
+			partWidth++ // separator
+		}
+		if width+partWidth > maxWidth {
+			break
+		}
+		parts = append(parts, part)
+		width += partWidth
+	}
+	return strings.Join(parts, " ")
+}
+
 // renderHintSlice renders a slice of hints in horizontal format.
 func (a App) renderHintSlice(hints []Hint) string {
 	if len(hints) == 0 {
@@ -948,12 +1335,36 @@ func (a App) renderHintSlice(hints []Hint) string {
 	return strings.Join(parts, " ")
 }
 
+// saveBanner returns the text of the persistent banner for missing or
+// unwritable storage or a failed save, or "" when saving works.
+func (a App) saveBanner() string {
+	switch {
+	case a.noStorage:
+		return "Changes will NOT be saved (no storage)"
+	case a.unwritable != "":
+		return "Changes will NOT be saved: " + a.unwritable
+	case a.saveErr != "":
+		return "Not saved: " + a.saveErr + " (retried on next change)"
+	}
+	return ""
+}
+
+// renderSaveErrorLine renders the persistent banner from saveBanner.
+func (a App) renderSaveErrorLine() string {
+	return a.renderStyledMessage(MessageError, a.saveBanner())
+}
+
 // renderMessageLine renders the styled message with prefix icon based on type.
 func (a App) renderMessageLine() string {
+	return a.renderStyledMessage(a.messageType, a.messageText)
+}
+
+// renderStyledMessage renders text styled and prefixed for the message type.
+func (a App) renderStyledMessage(t MessageType, text string) string {
 	var msgStyle lipgloss.Style
 	var prefix string
 
-	switch a.messageType {
+	switch t {
 	case MessageError:
 		msgStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.AdaptiveColor{Light: "#CC3333", Dark: "#FF6666"}).
@@ -976,7 +1387,7 @@ func (a App) renderMessageLine() string {
 		prefix = ""
 	}
 
-	return msgStyle.Render(prefix + a.messageText)
+	return msgStyle.Render(prefix + text)
 }
 
 // renderStatusToggles renders the toggle hints and [ord:X] [cfm:X] indicators.
@@ -990,12 +1401,17 @@ func (a App) renderStatusToggles() string {
 
 	// Sort mode indicator (abbreviated)
 	sortLabels := map[SortMode]string{
-		SortManual:  "man",
-		SortAlpha:   "a-z",
-		SortCreated: "new",
-		SortVisited: "vis",
+		SortManual:   "man",
+		SortAlpha:    "a-z",
+		SortCreated:  "new",
+		SortVisited:  "vis",
+		SortModified: "mod",
+		SortPriority: "pri",
 	}
 	status.WriteString("[ord:" + sortLabels[a.browser.SortMode] + "]")
+	if mode := a.currentViewMode(); mode != model.ViewColumns {
+		status.WriteString(" [view:" + mode + "]")
+	}
 
 	// Confirm mode indicator (abbreviated)
 	if a.confirmDelete {
@@ -1004,10 +1420,15 @@ func (a App) renderStatusToggles() string {
 		status.WriteString(" [cfm:off]")
 	}
 
+	// Prefix waiting for the rest of its sequence, e.g. the g of gg
+	if a.pending.prefix != prefixNone {
+		status.WriteString(" " + a.styles.HintKey.Render(a.pending.key+"-"))
+	}
+
 	return status.String()
 }
 
-// renderQuickAddConfirm renders the AI quick add confirmation modal.
+// renderQuickAddConfirm renders the quick add confirmation modal (AI or template).
 func (a App) renderQuickAddConfirm() string {
 	modalWidth := layout.CalculateModalWidth(a.width, a.layoutConfig.Modal.LargeWidthPercent, a.layoutConfig.Modal)
 
@@ -1019,7 +1440,11 @@ func (a App) renderQuickAddConfirm() string {
 		Width(modalWidth)
 
 	var content strings.Builder
-	content.WriteString(a.styles.Title.Render("AI Quick Add - Confirm"))
+	if a.quickAdd.Template != "" {
+		content.WriteString(a.styles.Title.Render("Add from Template: " + a.quickAdd.Template + " - Confirm"))
+	} else {
+		content.WriteString(a.styles.Title.Render("AI Quick Add - Confirm"))
+	}
 	content.WriteString("\n\n")
 
 	// Title input
@@ -1158,35 +1583,76 @@ func (a App) renderHelpOverlay() string {
 	left.WriteString("gg   top\n")
 	left.WriteString("G    bottom\n")
 	left.WriteString("0    go to pins\n")
+	left.WriteString("tab  switch pane\n")
+	left.WriteString("^n^p sibling folder\n")
+	left.WriteString("'x   jump to x\n")
+	left.WriteString("`x   mark/swap\n")
+	left.WriteString("#N   go to item N\n")
+	left.WriteString("</>  pane width\n")
 	left.WriteString("\n")
 	left.WriteString(a.styles.Title.Render("pins") + "\n")
 	left.WriteString("1-9  open pin\n")
 	left.WriteString("J/K  reorder\n")
+	left.WriteString("/    filter pins\n")
 	left.WriteString("\n")
 	left.WriteString(a.styles.Title.Render("act") + "\n")
 	left.WriteString("l    open url\n")
+	left.WriteString("gb   terminal browser\n")
+	left.WriteString("gv   check, then open\n")
+	left.WriteString("gp   peek page info\n")
+	left.WriteString("gI   bookmark details\n")
+	left.WriteString("gi   capture (no AI)\n")
+	left.WriteString("gL   back to read later\n")
+	left.WriteString("I    add from template\n")
 	left.WriteString("Y    yank url\n")
+	left.WriteString("gd   yank domain\n")
+	left.WriteString("M    copy as link\n")
 	left.WriteString("*    pin/unpin\n")
 	left.WriteString("s    search\n")
 	left.WriteString("R    recent\n")
+	left.WriteString("U    untitled\n")
+	left.WriteString("H    recently visited\n")
+	left.WriteString("E    prune empty\n")
 	left.WriteString("/    filter\n")
 	left.WriteString("o    sort mode\n")
 	left.WriteString("m    move\n")
+	left.WriteString("gm   move selection here\n")
+	left.WriteString("z    snooze\n")
+	left.WriteString("r    remind me\n")
+	left.WriteString("N    due reminders\n")
+	left.WriteString("T    merge tags\n")
+	left.WriteString("D    domains\n")
+	left.WriteString("@    alias\n")
+	left.WriteString("X    archive\n")
+	left.WriteString("+    cycle priority\n")
+	left.WriteString("!    lock folder\n")
+	left.WriteString(":    bulk actions\n")
 
 	// Right column: Edit + Selection
 	var right strings.Builder
 	right.WriteString(a.styles.Title.Render("edit") + "\n")
 	right.WriteString("a    add bookmark\n")
 	right.WriteString("A    add folder\n")
-	right.WriteString("i    AI add\n")
-	right.WriteString("L    read later\n")
-	right.WriteString("O    organize\n")
+	if a.aiDisabled {
+		right.WriteString("i/L  capture (AI off)\n")
+		right.WriteString("O    organize (AI off)\n")
+	} else {
+		right.WriteString("i    AI add\n")
+		right.WriteString("L    read later\n")
+		right.WriteString("O    organize\n")
+	}
 	right.WriteString("e    edit\n")
+	right.WriteString("^o   field in $EDITOR\n")
+	right.WriteString("F    promote to folder\n")
 	right.WriteString("y    yank\n")
 	right.WriteString("d    delete\n")
+	right.WriteString(".    repeat d/*/X\n")
 	right.WriteString("x    cut\n")
 	right.WriteString("p/P  paste\n")
 	right.WriteString("c    confirm toggle\n")
+	right.WriteString("ts   folder stats\n")
+	right.WriteString("tn   item numbers\n")
+	right.WriteString("tv   folder view mode\n")
 	right.WriteString("\n")
 	right.WriteString(a.styles.Title.Render("select") + "\n")
 	right.WriteString("v    select item\n")
@@ -1236,7 +1702,7 @@ func (a App) renderCullMenu() string {
 
 	// Format cached option with age and count
 	if a.cull.HasCache {
-		age := formatTimeAgo(a.cull.CacheTime)
+		age := model.FormatTimeAgo(a.cull.CacheTime)
 		count := a.countCachedProblems()
 		options[1] = fmt.Sprintf("Use cached results (%s, %d issues)", age, count)
 	}
@@ -1270,6 +1736,70 @@ func (a App) renderCullMenu() string {
 	return lipgloss.JoinVertical(lipgloss.Left, modal, a.renderHelpBar())
 }
 
+// renderBulkMenu renders the batch operations available for the selection.
+func (a App) renderBulkMenu() string {
+	modalWidth := layout.CalculateModalWidth(a.width, a.layoutConfig.Modal.DefaultWidthPercent, a.layoutConfig.Modal)
+
+	accent := lipgloss.AdaptiveColor{Light: "#4A7070", Dark: "#5F8787"}
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(accent).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	var content strings.Builder
+	content.WriteString(a.styles.Title.Render(fmt.Sprintf("Bulk Actions (%d selected)", a.selection.Count())))
+	content.WriteString("\n\n")
+
+	for i, action := range a.bulk.Actions {
+		line := a.bulkActionLabel(action)
+		if i == a.bulk.MenuCursor {
+			// Pad for selection highlight
+			padded := line
+			for len(padded) < modalWidth-8 {
+				padded += " "
+			}
+			content.WriteString(a.styles.ItemSelected.Render("▸ " + padded))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	}
+
+	modal := lipgloss.Place(
+		a.width,
+		a.height-3,
+		lipgloss.Center,
+		lipgloss.Center,
+		modalStyle.Render(content.String()),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, modal, a.renderHelpBar())
+}
+
+// bulkActionLabel describes a bulk action with the number of items it affects.
+func (a App) bulkActionLabel(action BulkAction) string {
+	items := a.selection.Count()
+	bookmarks := a.countSelectedBookmarks()
+	switch action {
+	case BulkTag:
+		return fmt.Sprintf("Add tags to %d bookmarks", bookmarks)
+	case BulkMove:
+		return fmt.Sprintf("Move %d items", items)
+	case BulkPin:
+		return fmt.Sprintf("Pin/unpin %d items", items)
+	case BulkOpen:
+		return fmt.Sprintf("Open %d bookmarks", bookmarks)
+	case BulkExport:
+		return fmt.Sprintf("Export %d items to a file", items)
+	case BulkYank:
+		return fmt.Sprintf("Yank %d items", items)
+	case BulkDelete:
+		return fmt.Sprintf("Delete %d items", items)
+	}
+	return ""
+}
+
 // renderOrganizeMenu renders the menu to choose between fresh or cached organize.
 func (a App) renderOrganizeMenu() string {
 	modalWidth := layout.CalculateModalWidth(a.width, a.layoutConfig.Modal.DefaultWidthPercent, a.layoutConfig.Modal)
@@ -1293,7 +1823,7 @@ func (a App) renderOrganizeMenu() string {
 
 	// Format cached option with age and count
 	if a.organize.HasCache {
-		age := formatTimeAgo(a.organize.CacheTime)
+		age := model.FormatTimeAgo(a.organize.CacheTime)
 		count := a.countCachedOrganizeSuggestions()
 		options[1] = fmt.Sprintf("Use cached results (%s, %d items)", age, count)
 	}
@@ -1327,31 +1857,6 @@ func (a App) renderOrganizeMenu() string {
 	return lipgloss.JoinVertical(lipgloss.Left, modal, a.renderHelpBar())
 }
 
-// formatTimeAgo formats a duration since a timestamp in human-readable form.
-func formatTimeAgo(t time.Time) string {
-	d := time.Since(t)
-	if d < time.Minute {
-		return "just now"
-	} else if d < time.Hour {
-		m := int(d.Minutes())
-		if m == 1 {
-			return "1m ago"
-		}
-		return fmt.Sprintf("%dm ago", m)
-	} else if d < 24*time.Hour {
-		h := int(d.Hours())
-		if h == 1 {
-			return "1h ago"
-		}
-		return fmt.Sprintf("%dh ago", h)
-	}
-	days := int(d.Hours() / 24)
-	if days == 1 {
-		return "1d ago"
-	}
-	return fmt.Sprintf("%dd ago", days)
-}
-
 // renderCullLoading renders the loading screen during URL checking.
 func (a App) renderCullLoading() string {
 	modalWidth := layout.CalculateModalWidth(a.width, a.layoutConfig.Modal.DefaultWidthPercent, a.layoutConfig.Modal)
@@ -1567,6 +2072,30 @@ func (a App) renderOrganizeLoading() string {
 	return lipgloss.JoinVertical(lipgloss.Left, modal, a.renderHelpBar())
 }
 
+// renderOrganizePreview spells out exactly what accepting a suggestion will change.
+func (a App) renderOrganizePreview(sug OrganizeSuggestion) string {
+	var b strings.Builder
+
+	if sug.HasFolderChanges() {
+		b.WriteString(a.styles.URL.Render("  From: "+sug.CurrentPath) + "\n")
+		b.WriteString(a.styles.URL.Render("  To:   "+sug.SuggestedPath) + "\n")
+		if created := a.newFoldersForPath(sug.SuggestedPath); len(created) > 0 {
+			b.WriteString(a.styles.Tag.Render("  Creates: "+strings.Join(created, ", ")) + "\n")
+		}
+	}
+
+	if !sug.Item.IsFolder() && sug.HasTagChanges() {
+		if added := sug.AddedTags(); len(added) > 0 {
+			b.WriteString(a.styles.Tag.Render("  + "+strings.Join(added, ", ")) + "\n")
+		}
+		if removed := sug.RemovedTags(); len(removed) > 0 {
+			b.WriteString(a.styles.Tag.Render("  - "+strings.Join(removed, ", ")) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
 // renderOrganizeResults renders the list of suggested organization changes.
 func (a App) renderOrganizeResults() string {
 	modalWidth := layout.CalculateModalWidth(a.width, a.layoutConfig.Modal.LargeWidthPercent, a.layoutConfig.Modal)
@@ -1650,7 +2179,12 @@ func (a App) renderOrganizeResults() string {
 				}
 			}
 
-			if isSelected {
+			if isSelected && a.organize.Confirming {
+				content.WriteString(a.styles.ItemSelected.Render("> " + titleLine))
+				content.WriteString("\n")
+				content.WriteString(a.renderOrganizePreview(sug))
+				content.WriteString("\n")
+			} else if isSelected {
 				content.WriteString(a.styles.ItemSelected.Render("> " + titleLine))
 				content.WriteString("\n")
 				if pathLine != "" {
@@ -1697,7 +2231,7 @@ func (a App) getItemsForFolder(folderID *string) []Item {
 		items = append(items, Item{Kind: ItemFolder, Folder: &folders[i]})
 	}
 
-	bookmarks := a.store.GetBookmarksInFolder(folderID)
+	bookmarks := a.store.GetBookmarksInFolder(folderID, a.showArchived)
 	for i := range bookmarks {
 		items = append(items, Item{Kind: ItemBookmark, Bookmark: &bookmarks[i]})
 	}
@@ -1712,13 +2246,18 @@ func (a App) Store() *model.Store {
 
 // saveStore persists the current store to storage (if storage is configured).
 // This should be called after any mutation to the store.
+// With SaveDebounceMs set, the write is deferred until mutations go quiet.
 func (a *App) saveStore() {
 	if a.storage == nil {
 		return
 	}
-	if err := a.storage.Save(a.store); err != nil {
-		a.setMessage(MessageError, "Save failed: "+err.Error())
+	if a.config.SaveDebounceMs <= 0 {
+		a.recordSaveResult(a.storage.Save(a.store))
+		return
 	}
+	a.saveDirty = true
+	a.saveGen++
+	a.saveScheduled = true
 }
 
 // renderTooSmallError displays an error when the terminal is too small.
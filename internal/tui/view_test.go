@@ -1,10 +1,13 @@
 package tui_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/nikbrunner/bm/internal/model"
+	"github.com/nikbrunner/bm/internal/storage"
 	"github.com/nikbrunner/bm/internal/tui"
 	"github.com/nikbrunner/bm/internal/tui/layout"
 	"gotest.tools/v3/golden"
@@ -252,3 +255,193 @@ func TestView_Flow_EnterFolderAndBack(t *testing.T) {
 	output := layout.StripANSI(app.View())
 	golden.Assert(t, output, "golden/flow_enter_folder_and_back.golden")
 }
+
+func TestView_RecentlyAddedMarker(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "bm-new", Title: "Fresh", URL: "https://fresh.dev", CreatedAt: time.Now()},
+			{ID: "bm-old", Title: "Stale", URL: "https://stale.dev", CreatedAt: time.Now().Add(-time.Hour)},
+		},
+	}
+	cfg := testLayoutConfig()
+	app := tui.NewApp(tui.AppParams{
+		Store:        store,
+		LayoutConfig: &cfg,
+	})
+	app = app.WithDimensions(120, 30)
+	output := layout.StripANSI(app.View())
+
+	if !strings.Contains(output, "+ Fresh") {
+		t.Errorf("expected recent bookmark to be marked, got:\n%s", output)
+	}
+	if strings.Contains(output, "+ Stale") {
+		t.Errorf("expected old bookmark not to be marked, got:\n%s", output)
+	}
+}
+
+func TestView_HelpBarFitsSmallTerminal(t *testing.T) {
+	sizes := [][2]int{{115, 20}, {120, 24}, {200, 40}}
+	for _, size := range sizes {
+		width, height := size[0], size[1]
+		app := createTestApp(width, height)
+		output := layout.StripANSI(app.View())
+
+		if lines := strings.Count(output, "\n") + 1; lines > height {
+			t.Errorf("%dx%d: view has %d lines, overflows terminal", width, height, lines)
+		}
+	}
+}
+
+func TestView_HelpBarCompactOnShortTerminal(t *testing.T) {
+	app := createTestApp(120, 20)
+	output := layout.StripANSI(app.View())
+
+	if strings.Contains(output, "Global") || strings.Contains(output, "Toggle") {
+		t.Errorf("expected compact help bar without Global/Toggle lines, got:\n%s", output)
+	}
+	if !strings.Contains(output, "j/k:move") {
+		t.Errorf("expected compact help bar to keep contextual hints, got:\n%s", output)
+	}
+}
+
+func TestView_HelpBarAbbreviatesOnNarrowTerminal(t *testing.T) {
+	app := createTestApp(115, 40)
+	output := layout.StripANSI(app.View())
+
+	if !strings.Contains(output, "O:orga ") {
+		t.Errorf("expected abbreviated hint labels, got:\n%s", output)
+	}
+	if strings.Contains(output, "Local  ") {
+		t.Errorf("expected abbreviated row labels, got:\n%s", output)
+	}
+}
+
+func TestView_HelpBarKeepsFullLabelsAt120Columns(t *testing.T) {
+	app := createTestApp(120, 24)
+	output := layout.StripANSI(app.View())
+
+	if !strings.Contains(output, "O:organize ") || !strings.Contains(output, "Local  ") {
+		t.Errorf("expected full hint labels on a 120 column terminal, got:\n%s", output)
+	}
+	if strings.Contains(output, "O:orga ") {
+		t.Errorf("expected no abbreviated labels on a 120 column terminal, got:\n%s", output)
+	}
+}
+
+func TestView_ShowTagsInList(t *testing.T) {
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "bm-1", Title: "Go", URL: "https://go.dev", Tags: []string{"lang", "docs"}},
+			{ID: "bm-2", Title: "A longer bookmark", URL: "https://long.dev", Tags: []string{"first", "second", "third"}},
+		},
+	}
+	cfg := testLayoutConfig()
+	appCfg := storage.DefaultConfig()
+	appCfg.ShowTagsInList = true
+	app := tui.NewApp(tui.AppParams{
+		Store:        store,
+		Config:       &appCfg,
+		LayoutConfig: &cfg,
+	}).WithDimensions(120, 30)
+	output := layout.StripANSI(app.View())
+
+	if !strings.Contains(output, "Go #lang #docs") {
+		t.Errorf("expected tags after the title, got:\n%s", output)
+	}
+	// Only the first tag fits after the long title
+	if !strings.Contains(output, "bookmark #first ...") || strings.Contains(output, "#third") {
+		t.Errorf("expected overflowing tags to be dropped, got:\n%s", output)
+	}
+
+	appCfg.ShowTagsInList = false
+	app = tui.NewApp(tui.AppParams{Store: store, Config: &appCfg, LayoutConfig: &cfg}).WithDimensions(120, 30)
+	if output := layout.StripANSI(app.View()); strings.Contains(output, "Go #lang") {
+		t.Errorf("expected no inline tags when disabled, got:\n%s", output)
+	}
+}
+
+func TestView_DetailsShowsFullURL(t *testing.T) {
+	longURL := "https://docs.example.com/guides/configuration/advanced/networking/proxies/" +
+		"authentication?section=kerberos&version=latest#troubleshooting-ticket-renewal"
+	store := &model.Store{
+		Bookmarks: []model.Bookmark{
+			{ID: "bm-1", Title: "Proxy auth", URL: longURL, Tags: []string{"ops", "network"}, VisitCount: 3},
+		},
+	}
+	cfg := testLayoutConfig()
+	app := tui.NewApp(tui.AppParams{Store: store, LayoutConfig: &cfg}).WithDimensions(120, 30)
+
+	for _, r := range "gI" {
+		updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+	if app.Mode() != tui.ModeDetails {
+		t.Fatalf("expected the details modal, got mode %v", app.Mode())
+	}
+
+	// The URL is wrapped inside the modal borders; joining the modal's
+	// lines back together must give the whole URL
+	var joined strings.Builder
+	output := layout.StripANSI(app.View())
+	for _, line := range strings.Split(output, "\n") {
+		first, last := strings.Index(line, "┃"), strings.LastIndex(line, "┃")
+		if first < 0 || last <= first {
+			continue
+		}
+		joined.WriteString(strings.TrimSpace(line[first+len("┃") : last]))
+	}
+	if !strings.Contains(joined.String(), longURL) {
+		t.Errorf("expected the untruncated URL in the details modal, got:\n%s", output)
+	}
+	if !strings.Contains(output, "#ops #network") {
+		t.Errorf("expected all tags in the details modal, got:\n%s", output)
+	}
+}
+
+func TestView_RecentFinderHighlightsMatchedPath(t *testing.T) {
+	devID, goID := "f-dev", "f-go"
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: devID, Name: "Development"},
+			{ID: goID, Name: "Golang", ParentID: &devID},
+		},
+		Bookmarks: []model.Bookmark{
+			{ID: "bm-1", Title: "Tour", URL: "https://tour.example", FolderID: &goID, CreatedAt: time.Now()},
+		},
+	}
+	cfg := testLayoutConfig()
+	app := tui.NewApp(tui.AppParams{Store: store, LayoutConfig: &cfg}).WithDimensions(120, 30)
+
+	for _, r := range "Rgolang" {
+		updated, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		app = updated.(tui.App)
+	}
+	if matches := app.FuzzyMatches(); len(matches) != 1 {
+		t.Fatalf("expected the path to match the bookmark, got %d matches", len(matches))
+	}
+
+	// Only the path contains "golang"; each of its letters is highlighted
+	highlighted := "\033[1;4mG\033[22;24m\033[1;4mo\033[22;24m\033[1;4ml\033[22;24m"
+	if output := app.View(); !strings.Contains(output, highlighted) {
+		t.Errorf("expected the matched path characters to be highlighted, got:\n%q", output)
+	}
+}
+
+func TestView_LockedFolderMarkedInBrowser(t *testing.T) {
+	store := &model.Store{
+		Folders: []model.Folder{
+			{ID: "ref", Name: "Reference", Locked: true},
+			{ID: "inbox", Name: "Inbox"},
+		},
+	}
+	cfg := testLayoutConfig()
+	app := tui.NewApp(tui.AppParams{Store: store, LayoutConfig: &cfg}).WithDimensions(120, 30)
+	output := layout.StripANSI(app.View())
+
+	if !strings.Contains(output, "! Reference/") {
+		t.Errorf("expected the locked folder to be marked, got:\n%s", output)
+	}
+	if strings.Contains(output, "! Inbox/") {
+		t.Errorf("expected the unlocked folder not to be marked, got:\n%s", output)
+	}
+}